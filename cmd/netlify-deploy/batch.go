@@ -0,0 +1,76 @@
+package main
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/kodestech/poc-netlify/internal/batch"
+	"github.com/urfave/cli/v2"
+)
+
+// batchCommand publica todas as pastas de sites de uma conta
+func batchCommand() *cli.Command {
+	return &cli.Command{
+		Name:  "batch",
+		Usage: "Publica todos os sites de uma conta (uma pasta por site em web/accounts/<conta>)",
+		Description: "Cria na Netlify os sites que ainda não existem e publica as pastas em paralelo, com o token informado em --token. " +
+			"O comando termina com erro se algum site falhar.",
+		Flags: []cli.Flag{
+			&cli.StringFlag{Name: "account", Usage: "Pasta da conta a ser publicada", Required: true},
+			&cli.StringFlag{Name: "dir", Usage: "Pasta que contém as contas", DefaultText: "ACCOUNTS_PATH"},
+			&cli.StringFlag{Name: "pattern", Usage: "Padrão do nome dos sites na Netlify (<account> e <site>)", DefaultText: "BATCH_NAME_PATTERN"},
+			&cli.IntFlag{Name: "concurrency", Usage: "Quantidade de sites publicados ao mesmo tempo", DefaultText: "BATCH_CONCURRENCY"},
+		},
+		Action: deployBatch,
+	}
+}
+
+// batchHeader são as colunas da tabela do relatório do lote
+var batchHeader = []string{"PASTA", "SITE", "ID", "DEPLOY", "ESTADO", "DURAÇÃO", "ERRO"}
+
+// deployBatch publica os sites da conta e imprime o relatório
+func deployBatch(c *cli.Context) error {
+	cfg, err := loadConfig(c)
+	if err != nil {
+		return err
+	}
+	netlifyClient, err := newClient(cfg)
+	if err != nil {
+		return err
+	}
+
+	opts := batch.Options{
+		AccountsDir: cfg.AccountsPath,
+		Account:     c.String("account"),
+		NamePattern: cfg.BatchNamePattern,
+		Concurrency: cfg.BatchConcurrency,
+	}
+	if c.IsSet("dir") {
+		opts.AccountsDir = c.String("dir")
+	}
+	if c.IsSet("pattern") {
+		opts.NamePattern = c.String("pattern")
+	}
+	if c.IsSet("concurrency") {
+		opts.Concurrency = c.Int("concurrency")
+	}
+
+	report, err := batch.DeployAccount(c.Context, netlifyClient, opts)
+	if err != nil {
+		return err
+	}
+
+	rows := make([][]string, 0, len(report.Sites))
+	for _, site := range report.Sites {
+		duration := (time.Duration(site.DurationMs) * time.Millisecond).String()
+		rows = append(rows, []string{site.Folder, site.SiteName, dash(site.SiteID), dash(site.DeployID), dash(site.State), duration, dash(site.Error)})
+	}
+	if err := printTable(c, report, batchHeader, rows); err != nil {
+		return err
+	}
+
+	if report.Failed > 0 {
+		return fmt.Errorf("%d de %d site(s) com erro", report.Failed, report.Total)
+	}
+	return nil
+}
@@ -0,0 +1,215 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/kodestech/poc-netlify/internal/archive"
+	"github.com/kodestech/poc-netlify/internal/aws"
+	"github.com/kodestech/poc-netlify/internal/config"
+	"github.com/kodestech/poc-netlify/internal/netlify"
+	"github.com/netlify/open-api/go/models"
+	"github.com/urfave/cli/v2"
+)
+
+// deployCommand publica uma pasta local, um caminho do S3 ou um arquivo compactado em um site
+func deployCommand() *cli.Command {
+	return &cli.Command{
+		Name:  "deploy",
+		Usage: "Publica uma pasta local, um caminho do bucket S3 ou um arquivo .zip, .tar ou .tar.gz",
+		Description: "Informe o site pelo ID (--site-id) ou pelo nome (--site-name, criado se não existir) " +
+			"e exatamente uma origem: --folder, --s3-prefix ou --archive.",
+		Flags: []cli.Flag{
+			&cli.StringFlag{Name: "site-id", Usage: "ID do site na Netlify"},
+			&cli.StringFlag{Name: "site-name", Usage: "Nome do site (criado se não existir)"},
+			&cli.StringFlag{Name: "folder", Usage: "Pasta local com os arquivos do site"},
+			&cli.StringFlag{Name: "s3-prefix", Usage: "Caminho no bucket S3 (S3_BUCKET_NAME) com os arquivos do site"},
+			&cli.StringFlag{Name: "archive", Aliases: []string{"zip"}, Usage: "Arquivo .zip, .tar ou .tar.gz com os arquivos do site"},
+			&cli.BoolFlag{Name: "wait", Usage: "Aguarda o deploy ficar pronto (--wait=false apenas inicia o deploy)", Value: true},
+			&cli.BoolFlag{Name: "draft", Usage: "Cria o deploy como rascunho, disponível apenas na URL de pré-visualização"},
+			&cli.BoolFlag{Name: "dry-run", Usage: "Apenas lista os arquivos que seriam adicionados, modificados e removidos (exige --site-id)"},
+		},
+		Action: deploy,
+	}
+}
+
+// deploy publica os arquivos da origem informada e, por padrão, aguarda o deploy ficar pronto
+func deploy(c *cli.Context) error {
+	sources := 0
+	for _, name := range []string{"folder", "s3-prefix", "archive"} {
+		if c.String(name) != "" {
+			sources++
+		}
+	}
+	if sources != 1 {
+		return fmt.Errorf("informe exatamente uma origem: --folder, --s3-prefix ou --archive")
+	}
+	if c.String("site-id") == "" && c.String("site-name") == "" {
+		return fmt.Errorf("informe o site com --site-id ou --site-name")
+	}
+	if c.Bool("dry-run") && c.String("site-id") == "" {
+		return fmt.Errorf("informe o site com --site-id no dry-run")
+	}
+
+	cfg, err := loadConfig(c)
+	if err != nil {
+		return err
+	}
+	netlifyClient, err := newClient(cfg)
+	if err != nil {
+		return err
+	}
+
+	ctx := c.Context
+	if c.Bool("dry-run") {
+		return dryRun(c, netlifyClient, cfg)
+	}
+	if c.Bool("draft") {
+		ctx = netlify.WithDraft(ctx)
+	}
+	var site *models.Site
+	if siteID := c.String("site-id"); siteID != "" {
+		site, err = netlifyClient.GetSite(ctx, siteID)
+	} else {
+		site, err = netlifyClient.CreateOrGetSite(ctx, c.String("site-name"), "")
+	}
+	if err != nil {
+		return err
+	}
+
+	var deploy *models.Deploy
+	switch {
+	case c.String("folder") != "":
+		deploy, err = netlifyClient.DeployLocalFolder(ctx, site, c.String("folder"))
+	case c.String("archive") != "":
+		deploy, err = deployArchive(ctx, netlifyClient, cfg, site, c.String("archive"))
+	default:
+		deploy, err = deployS3(ctx, netlifyClient, cfg, site, c.String("s3-prefix"))
+	}
+	if err != nil {
+		return err
+	}
+
+	if c.Bool("wait") {
+		deploy, err = netlifyClient.WaitForDeploy(ctx, deploy.ID)
+		if err != nil {
+			return err
+		}
+	}
+	return printDeploy(c, deploy)
+}
+
+// dryRun compara os arquivos da origem informada com o deploy publicado do site, sem criar o deploy
+func dryRun(c *cli.Context, netlifyClient *netlify.Client, cfg *config.Config) error {
+	var files []netlify.RemoteFile
+	var err error
+	switch {
+	case c.String("folder") != "":
+		files, err = netlify.FolderManifest(c.String("folder"))
+	case c.String("archive") != "":
+		files, err = archiveManifest(cfg, c.String("archive"))
+	default:
+		files, err = s3Manifest(c.Context, cfg, c.String("site-id"), c.String("s3-prefix"))
+	}
+	if err != nil {
+		return err
+	}
+
+	diff, err := netlifyClient.DiffDeploy(c.Context, c.String("site-id"), files)
+	if err != nil {
+		return err
+	}
+	return printDiff(c, diff)
+}
+
+// archiveManifest extrai o arquivo compactado em um diretório temporário e calcula o manifesto do seu conteúdo
+func archiveManifest(cfg *config.Config, path string) ([]netlify.RemoteFile, error) {
+	dir, root, err := extractArchive(cfg, path)
+	if err != nil {
+		return nil, err
+	}
+	defer os.RemoveAll(dir)
+
+	return netlify.FolderManifest(root)
+}
+
+// deployArchive extrai o arquivo compactado em um diretório temporário e publica o seu conteúdo
+func deployArchive(ctx context.Context, netlifyClient *netlify.Client, cfg *config.Config, site *models.Site, path string) (*models.Deploy, error) {
+	dir, root, err := extractArchive(cfg, path)
+	if err != nil {
+		return nil, err
+	}
+	defer os.RemoveAll(dir)
+
+	return netlifyClient.DeployLocalFolder(ctx, site, root)
+}
+
+// extractArchive extrai o arquivo compactado em um diretório temporário, que deve ser removido pelo chamador,
+// e retorna também a raiz do site dentro dele
+func extractArchive(cfg *config.Config, path string) (dir, root string, err error) {
+	dir, err = os.MkdirTemp("", "netlify-deploy-archive")
+	if err != nil {
+		return "", "", fmt.Errorf("erro ao criar diretório temporário: %w", err)
+	}
+
+	root, err = archive.ExtractFile(path, dir, archive.Limits{MaxFiles: cfg.ArchiveMaxFiles, MaxBytes: cfg.ArchiveMaxBytes})
+	if err != nil {
+		os.RemoveAll(dir)
+		return "", "", err
+	}
+	return dir, root, nil
+}
+
+// deployS3 publica os arquivos de um caminho do bucket S3, enviando apenas os que a Netlify ainda não possui
+func deployS3(ctx context.Context, netlifyClient *netlify.Client, cfg *config.Config, site *models.Site, prefix string) (*models.Deploy, error) {
+	if err := cfg.SetDeployParams(site.Name, "", prefix); err != nil {
+		return nil, err
+	}
+
+	s3Client, err := aws.NewS3Client(cfg)
+	if err != nil {
+		return nil, fmt.Errorf("erro ao inicializar cliente S3: %w", err)
+	}
+
+	files, err := buildS3Manifest(ctx, s3Client, cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	return netlifyClient.DeployRemoteFiles(ctx, site, files, func(ctx context.Context, f netlify.RemoteFile) (io.ReadCloser, error) {
+		return s3Client.OpenFile(ctx, f.Path)
+	})
+}
+
+// s3Manifest calcula o manifesto dos arquivos de um caminho do bucket S3
+func s3Manifest(ctx context.Context, cfg *config.Config, siteID, prefix string) ([]netlify.RemoteFile, error) {
+	// O nome de usuário só define o subdomínio, que o dry-run não usa
+	if err := cfg.SetDeployParams(siteID, "", prefix); err != nil {
+		return nil, err
+	}
+
+	s3Client, err := aws.NewS3Client(cfg)
+	if err != nil {
+		return nil, fmt.Errorf("erro ao inicializar cliente S3: %w", err)
+	}
+	return buildS3Manifest(ctx, s3Client, cfg)
+}
+
+// buildS3Manifest lista os arquivos do caminho configurado no cliente S3 com o SHA1 de cada um
+func buildS3Manifest(ctx context.Context, s3Client *aws.S3Client, cfg *config.Config) ([]netlify.RemoteFile, error) {
+	objects, err := s3Client.BuildManifest(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("erro ao listar arquivos do S3: %w", err)
+	}
+	if len(objects) == 0 {
+		return nil, fmt.Errorf("nenhum arquivo encontrado no caminho S3 especificado: %s", cfg.S3Path)
+	}
+
+	files := make([]netlify.RemoteFile, 0, len(objects))
+	for _, obj := range objects {
+		files = append(files, netlify.RemoteFile{Path: obj.Path, SHA1: obj.SHA1, Size: obj.Size})
+	}
+	return files, nil
+}
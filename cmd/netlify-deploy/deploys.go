@@ -0,0 +1,112 @@
+package main
+
+import (
+	"github.com/kodestech/poc-netlify/internal/netlify"
+	"github.com/urfave/cli/v2"
+)
+
+// deploysCommand agrupa os subcomandos de deploys existentes
+func deploysCommand() *cli.Command {
+	return &cli.Command{
+		Name:  "deploys",
+		Usage: "Lista, acompanha, publica e restaura deploys",
+		Subcommands: []*cli.Command{
+			{
+				Name:   "list",
+				Usage:  "Lista os deploys de um site, do mais recente para o mais antigo",
+				Flags:  []cli.Flag{siteIDFlag},
+				Action: listDeploys,
+			},
+			{
+				Name:  "wait",
+				Usage: "Aguarda um deploy ficar pronto (falha se o deploy falhar ou o tempo se esgotar)",
+				Flags: []cli.Flag{
+					&cli.StringFlag{Name: "deploy-id", Usage: "ID do deploy", Required: true},
+					&cli.DurationFlag{Name: "timeout", Usage: "Tempo máximo de espera (padrão DEPLOY_WAIT_TIMEOUT)"},
+				},
+				Action: waitDeploy,
+			},
+			{
+				Name:  "rollback",
+				Usage: "Publica novamente um deploy anterior do site e aguarda a publicação",
+				Flags: []cli.Flag{
+					siteIDFlag,
+					&cli.StringFlag{Name: "deploy-id", Usage: "ID do deploy a restaurar", Value: netlify.PreviousDeployTarget, DefaultText: "deploy publicado antes do atual"},
+				},
+				Action: rollbackDeploy,
+			},
+			{
+				Name:  "publish",
+				Usage: "Publica em produção um deploy criado como rascunho e aguarda a publicação",
+				Flags: []cli.Flag{
+					siteIDFlag,
+					&cli.StringFlag{Name: "deploy-id", Usage: "ID do deploy em rascunho", Required: true},
+				},
+				Action: publishDeploy,
+			},
+		},
+	}
+}
+
+// listDeploys lista os deploys de um site
+func listDeploys(c *cli.Context) error {
+	netlifyClient, err := clientFromFlags(c)
+	if err != nil {
+		return err
+	}
+
+	deploys, err := netlifyClient.ListSiteDeploys(c.Context, c.String("site-id"))
+	if err != nil {
+		return err
+	}
+	return printDeploys(c, deploys)
+}
+
+// waitDeploy aguarda um deploy ficar pronto
+func waitDeploy(c *cli.Context) error {
+	cfg, err := loadConfig(c)
+	if err != nil {
+		return err
+	}
+	if timeout := c.Duration("timeout"); timeout > 0 {
+		cfg.DeployWaitTimeout = timeout
+	}
+	netlifyClient, err := newClient(cfg)
+	if err != nil {
+		return err
+	}
+
+	deploy, err := netlifyClient.WaitForDeploy(c.Context, c.String("deploy-id"))
+	if err != nil {
+		return err
+	}
+	return printDeploy(c, deploy)
+}
+
+// rollbackDeploy restaura um deploy anterior do site
+func rollbackDeploy(c *cli.Context) error {
+	netlifyClient, err := clientFromFlags(c)
+	if err != nil {
+		return err
+	}
+
+	deploy, err := netlifyClient.RollbackDeploy(c.Context, c.String("site-id"), c.String("deploy-id"))
+	if err != nil {
+		return err
+	}
+	return printDeploy(c, deploy)
+}
+
+// publishDeploy publica em produção um deploy em rascunho
+func publishDeploy(c *cli.Context) error {
+	netlifyClient, err := clientFromFlags(c)
+	if err != nil {
+		return err
+	}
+
+	deploy, err := netlifyClient.PublishDeploy(c.Context, c.String("site-id"), c.String("deploy-id"))
+	if err != nil {
+		return err
+	}
+	return printDeploy(c, deploy)
+}
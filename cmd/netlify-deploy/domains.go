@@ -0,0 +1,90 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/kodestech/poc-netlify/internal/netlify"
+	"github.com/urfave/cli/v2"
+)
+
+// domainFlag identifica o domínio personalizado nos subcomandos
+var domainFlag = &cli.StringFlag{
+	Name:     "domain",
+	Usage:    "Domínio personalizado (ex: www.exemplo.com.br)",
+	Required: true,
+}
+
+// domainAction executa uma operação de domínio do cliente Netlify
+type domainAction func(c *cli.Context, netlifyClient *netlify.Client, siteID, domain string) error
+
+// domainsCommand agrupa os subcomandos de domínios personalizados
+func domainsCommand() *cli.Command {
+	return &cli.Command{
+		Name:  "domains",
+		Usage: "Gerencia os domínios personalizados de um site",
+		Subcommands: []*cli.Command{
+			{
+				Name:  "add",
+				Usage: "Adiciona um domínio (principal, se o site não tiver um, ou alias)",
+				Flags: []cli.Flag{siteIDFlag, domainFlag},
+				Action: runDomain("Domínio %s adicionado ao site %s", func(c *cli.Context, netlifyClient *netlify.Client, siteID, domain string) error {
+					return netlifyClient.AddCustomDomain(c.Context, siteID, domain)
+				}),
+			},
+			{
+				Name:  "remove",
+				Usage: "Remove um alias de domínio",
+				Flags: []cli.Flag{siteIDFlag, domainFlag},
+				Action: runDomain("Domínio %s removido do site %s", func(c *cli.Context, netlifyClient *netlify.Client, siteID, domain string) error {
+					return netlifyClient.RemoveCustomDomain(c.Context, siteID, domain)
+				}),
+			},
+			{
+				Name:  "set-default",
+				Usage: "Define um domínio como principal",
+				Flags: []cli.Flag{siteIDFlag, domainFlag},
+				Action: runDomain("Domínio %s definido como principal do site %s", func(c *cli.Context, netlifyClient *netlify.Client, siteID, domain string) error {
+					return netlifyClient.SetDefaultDomain(c.Context, siteID, domain, "")
+				}),
+			},
+			{
+				Name:  "switch",
+				Usage: "Promove um alias a domínio principal, mantendo o principal atual como alias",
+				Flags: []cli.Flag{siteIDFlag, domainFlag},
+				Action: runDomain("Domínio %s agora é o principal do site %s", func(c *cli.Context, netlifyClient *netlify.Client, siteID, domain string) error {
+					return netlifyClient.SwitchDefaultDomain(c.Context, siteID, domain, "")
+				}),
+			},
+			{
+				Name:  "remove-primary",
+				Usage: "Remove o domínio principal",
+				Flags: []cli.Flag{siteIDFlag},
+				Action: runDomain("Domínio principal removido do site %[2]s", func(c *cli.Context, netlifyClient *netlify.Client, siteID, _ string) error {
+					return netlifyClient.RemovePrimaryDomain(c.Context, siteID)
+				}),
+			},
+		},
+	}
+}
+
+// runDomain cria a ação de um subcomando de domínio, que imprime a mensagem com o domínio e o site
+func runDomain(message string, action domainAction) cli.ActionFunc {
+	return func(c *cli.Context) error {
+		netlifyClient, err := clientFromFlags(c)
+		if err != nil {
+			return err
+		}
+
+		siteID, domain := c.String("site-id"), c.String("domain")
+		if err := action(c, netlifyClient, siteID, domain); err != nil {
+			return err
+		}
+
+		return printResult(c, actionResult{
+			Success: true,
+			Message: fmt.Sprintf(message, domain, siteID),
+			SiteID:  siteID,
+			Domain:  domain,
+		})
+	}
+}
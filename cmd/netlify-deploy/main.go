@@ -0,0 +1,129 @@
+// Comando netlify-deploy: gerencia sites, deploys e domínios na Netlify pela linha de comando,
+// usando o mesmo cliente da API HTTP (internal/netlify), sem precisar do servidor.
+//
+// Exemplos:
+//
+//	netlify-deploy sites list
+//	netlify-deploy deploy --site-name meu-site --folder ./dist
+//	netlify-deploy deploy --site-id a1b2c3d4 --folder ./dist --dry-run
+//	netlify-deploy -o json deploys rollback --site-id a1b2c3d4
+//	netlify-deploy batch --account elizio --concurrency 4
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"github.com/kodestech/poc-netlify/internal/config"
+	"github.com/kodestech/poc-netlify/internal/dns"
+	"github.com/kodestech/poc-netlify/internal/logging"
+	"github.com/kodestech/poc-netlify/internal/netlify"
+	"github.com/sirupsen/logrus"
+	"github.com/urfave/cli/v2"
+)
+
+func main() {
+	app := &cli.App{
+		Name:  "netlify-deploy",
+		Usage: "Gerencia sites, deploys e domínios na Netlify",
+		Description: "As configurações são lidas das mesmas variáveis de ambiente (ou do arquivo .env) do servidor. " +
+			"O token da Netlify pode ser informado com --token.",
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:    "token",
+				Usage:   "Token de acesso da Netlify",
+				EnvVars: []string{"NETLIFY_TOKEN"},
+			},
+			&cli.StringFlag{
+				Name:    "output",
+				Aliases: []string{"o"},
+				Usage:   "Formato da saída: table ou json",
+				Value:   outputTable,
+			},
+			&cli.BoolFlag{
+				Name:  "verbose",
+				Usage: "Exibe os logs das operações na saída de erros",
+			},
+		},
+		Before: setup,
+		Commands: []*cli.Command{
+			sitesCommand(),
+			deployCommand(),
+			deploysCommand(),
+			domainsCommand(),
+			batchCommand(),
+		},
+	}
+
+	// Interromper as operações em andamento com Ctrl+C
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	if err := app.RunContext(ctx, os.Args); err != nil {
+		fmt.Fprintf(os.Stderr, "Erro: %v\n", err)
+		stop()
+		os.Exit(1)
+	}
+}
+
+// setup valida as opções globais e configura os logs, exibidos na saída de erros apenas com --verbose
+func setup(c *cli.Context) error {
+	switch c.String("output") {
+	case outputTable, outputJSON:
+	default:
+		return fmt.Errorf("formato de saída inválido: %s (use table ou json)", c.String("output"))
+	}
+
+	level := slog.LevelWarn
+	if c.Bool("verbose") {
+		level = slog.LevelInfo
+	} else {
+		// A biblioteca da Netlify registra cada arquivo enviado
+		logrus.SetOutput(io.Discard)
+	}
+	handler := logging.NewHandler(slog.NewTextHandler(os.Stderr, &slog.HandlerOptions{Level: level}))
+	slog.SetDefault(slog.New(handler))
+	return nil
+}
+
+// loadConfig carrega a configuração da aplicação, usando o token informado em --token
+func loadConfig(c *cli.Context) (*config.Config, error) {
+	cfg, err := config.LoadConfig()
+	if err != nil {
+		return nil, fmt.Errorf("erro ao carregar configurações: %w", err)
+	}
+	if token := c.String("token"); token != "" {
+		cfg.NetlifyToken = token
+	}
+	logging.AddSecrets(cfg.Secrets()...)
+	return cfg, nil
+}
+
+// newClient cria o cliente Netlify com o provedor DNS configurado (DNS_PROVIDER)
+func newClient(cfg *config.Config) (*netlify.Client, error) {
+	netlifyClient, err := netlify.NewClient(cfg)
+	if err != nil {
+		return nil, fmt.Errorf("erro ao criar cliente Netlify: %w", err)
+	}
+
+	dnsProvider, err := dns.NewProvider(cfg)
+	if err != nil {
+		return nil, fmt.Errorf("erro ao criar provedor DNS: %w", err)
+	}
+	netlifyClient.SetDNSProvider(dnsProvider)
+	return netlifyClient, nil
+}
+
+// clientFromFlags carrega a configuração e cria o cliente Netlify
+func clientFromFlags(c *cli.Context) (*netlify.Client, error) {
+	cfg, err := loadConfig(c)
+	if err != nil {
+		return nil, err
+	}
+	return newClient(cfg)
+}
@@ -0,0 +1,165 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/kodestech/poc-netlify/internal/netlify"
+	"github.com/netlify/open-api/go/models"
+	"github.com/urfave/cli/v2"
+)
+
+// Formatos de saída aceitos em --output
+const (
+	outputTable = "table"
+	outputJSON  = "json"
+)
+
+// actionResult é a saída dos comandos que não retornam um site ou deploy
+type actionResult struct {
+	Success  bool   `json:"success"`
+	Message  string `json:"message"`
+	SiteID   string `json:"site_id,omitempty"`
+	DeployID string `json:"deploy_id,omitempty"`
+	Domain   string `json:"domain,omitempty"`
+}
+
+// printJSON imprime v em JSON indentado
+func printJSON(c *cli.Context, v interface{}) error {
+	enc := json.NewEncoder(c.App.Writer)
+	enc.SetIndent("", "  ")
+	return enc.Encode(v)
+}
+
+// printTable imprime v em JSON ou, no formato de tabela, o cabeçalho e as linhas informados
+func printTable(c *cli.Context, v interface{}, header []string, rows [][]string) error {
+	if c.String("output") == outputJSON {
+		return printJSON(c, v)
+	}
+
+	w := tabwriter.NewWriter(c.App.Writer, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, strings.Join(header, "\t"))
+	for _, row := range rows {
+		fmt.Fprintln(w, strings.Join(row, "\t"))
+	}
+	return w.Flush()
+}
+
+// printResult imprime o resultado de uma ação em JSON ou apenas a mensagem
+func printResult(c *cli.Context, result actionResult) error {
+	if c.String("output") == outputJSON {
+		return printJSON(c, result)
+	}
+	_, err := fmt.Fprintln(c.App.Writer, result.Message)
+	return err
+}
+
+// siteHeader são as colunas da tabela de sites
+var siteHeader = []string{"ID", "NOME", "URL", "DOMÍNIO", "ALIASES", "DEPLOY PUBLICADO"}
+
+// siteRow retorna a linha da tabela de sites
+func siteRow(site *models.Site) []string {
+	published := ""
+	if site.PublishedDeploy != nil {
+		published = site.PublishedDeploy.ID
+	}
+	return []string{site.ID, site.Name, siteURL(site), dash(site.CustomDomain), dash(strings.Join(site.DomainAliases, ",")), dash(published)}
+}
+
+// printSites imprime uma lista de sites
+func printSites(c *cli.Context, sites []*models.Site) error {
+	rows := make([][]string, 0, len(sites))
+	for _, site := range sites {
+		rows = append(rows, siteRow(site))
+	}
+	return printTable(c, sites, siteHeader, rows)
+}
+
+// printSite imprime um site
+func printSite(c *cli.Context, site *models.Site) error {
+	return printTable(c, site, siteHeader, [][]string{siteRow(site)})
+}
+
+// deployHeader são as colunas da tabela de deploys
+var deployHeader = []string{"ID", "ESTADO", "URL", "CRIADO EM", "RASCUNHO"}
+
+// deployRow retorna a linha da tabela de deploys
+func deployRow(deploy *models.Deploy) []string {
+	url := deploy.DeploySslURL
+	if url == "" {
+		url = deploy.DeployURL
+	}
+	return []string{deploy.ID, deploy.State, dash(url), dash(formatTime(deploy.CreatedAt)), fmt.Sprintf("%t", deploy.Draft)}
+}
+
+// printDeploys imprime uma lista de deploys
+func printDeploys(c *cli.Context, deploys []*models.Deploy) error {
+	rows := make([][]string, 0, len(deploys))
+	for _, deploy := range deploys {
+		rows = append(rows, deployRow(deploy))
+	}
+	return printTable(c, deploys, deployHeader, rows)
+}
+
+// printDeploy imprime um deploy
+func printDeploy(c *cli.Context, deploy *models.Deploy) error {
+	return printTable(c, deploy, deployHeader, [][]string{deployRow(deploy)})
+}
+
+// diffHeader são as colunas da tabela de um dry-run
+var diffHeader = []string{"ALTERAÇÃO", "CAMINHO", "TAMANHO"}
+
+// printDiff imprime os arquivos que um deploy adicionaria, modificaria e removeria
+func printDiff(c *cli.Context, diff *netlify.DeployDiff) error {
+	var rows [][]string
+	for _, change := range []struct {
+		name  string
+		files []netlify.DiffFile
+	}{
+		{"adicionado", diff.Added},
+		{"modificado", diff.Modified},
+		{"removido", diff.Removed},
+	} {
+		for _, f := range change.files {
+			rows = append(rows, []string{change.name, f.Path, fmt.Sprintf("%d", f.Size)})
+		}
+	}
+	if err := printTable(c, diff, diffHeader, rows); err != nil {
+		return err
+	}
+
+	if c.String("output") != outputJSON {
+		_, err := fmt.Fprintf(c.App.Writer, "%d adicionados, %d modificados, %d removidos, %d sem alteração\n",
+			len(diff.Added), len(diff.Modified), len(diff.Removed), diff.Unchanged)
+		return err
+	}
+	return nil
+}
+
+// siteURL retorna a URL HTTPS do site, quando disponível
+func siteURL(site *models.Site) string {
+	if site.SslURL != "" {
+		return site.SslURL
+	}
+	return dash(site.URL)
+}
+
+// formatTime converte uma data da API da Netlify para o horário local, mantendo o texto original se inválida
+func formatTime(value string) string {
+	t, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return value
+	}
+	return t.Local().Format("2006-01-02 15:04:05")
+}
+
+// dash substitui valores vazios na tabela
+func dash(value string) string {
+	if value == "" {
+		return "-"
+	}
+	return value
+}
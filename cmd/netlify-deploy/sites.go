@@ -0,0 +1,110 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/urfave/cli/v2"
+)
+
+// siteIDFlag identifica o site nos subcomandos
+var siteIDFlag = &cli.StringFlag{
+	Name:     "site-id",
+	Usage:    "ID do site na Netlify",
+	Required: true,
+}
+
+// sitesCommand agrupa os subcomandos de sites
+func sitesCommand() *cli.Command {
+	return &cli.Command{
+		Name:  "sites",
+		Usage: "Lista, cria e exclui sites",
+		Subcommands: []*cli.Command{
+			{
+				Name:   "list",
+				Usage:  "Lista os sites da conta",
+				Action: listSites,
+			},
+			{
+				Name:   "get",
+				Usage:  "Exibe um site",
+				Flags:  []cli.Flag{siteIDFlag},
+				Action: getSite,
+			},
+			{
+				Name:  "create",
+				Usage: "Cria um site (ou retorna o site existente com o mesmo nome)",
+				Flags: []cli.Flag{
+					&cli.StringFlag{Name: "name", Usage: "Nome do site (subdomínio .netlify.app)", Required: true},
+					&cli.StringFlag{Name: "description", Usage: "Descrição do site"},
+				},
+				Action: createSite,
+			},
+			{
+				Name:   "delete",
+				Usage:  "Exclui um site",
+				Flags:  []cli.Flag{siteIDFlag},
+				Action: deleteSite,
+			},
+		},
+	}
+}
+
+// listSites lista os sites da conta
+func listSites(c *cli.Context) error {
+	netlifyClient, err := clientFromFlags(c)
+	if err != nil {
+		return err
+	}
+
+	sites, err := netlifyClient.ListSites(c.Context)
+	if err != nil {
+		return err
+	}
+	return printSites(c, sites)
+}
+
+// getSite exibe um site
+func getSite(c *cli.Context) error {
+	netlifyClient, err := clientFromFlags(c)
+	if err != nil {
+		return err
+	}
+
+	site, err := netlifyClient.GetSite(c.Context, c.String("site-id"))
+	if err != nil {
+		return err
+	}
+	return printSite(c, site)
+}
+
+// createSite cria um site ou retorna o existente com o mesmo nome
+func createSite(c *cli.Context) error {
+	netlifyClient, err := clientFromFlags(c)
+	if err != nil {
+		return err
+	}
+
+	site, err := netlifyClient.CreateOrGetSite(c.Context, c.String("name"), c.String("description"))
+	if err != nil {
+		return err
+	}
+	return printSite(c, site)
+}
+
+// deleteSite exclui um site
+func deleteSite(c *cli.Context) error {
+	netlifyClient, err := clientFromFlags(c)
+	if err != nil {
+		return err
+	}
+
+	siteID := c.String("site-id")
+	if err := netlifyClient.DeleteSite(c.Context, siteID); err != nil {
+		return err
+	}
+	return printResult(c, actionResult{
+		Success: true,
+		Message: fmt.Sprintf("Site %s excluído", siteID),
+		SiteID:  siteID,
+	})
+}
@@ -15,89 +15,43 @@ const docTemplate = `{
     "host": "{{.Host}}",
     "basePath": "{{.BasePath}}",
     "paths": {
-        "/api/deploy/site": {
-            "post": {
-                "description": "Cria um novo site na Netlify ou atualiza um existente quando o ID é fornecido",
-                "consumes": [
-                    "multipart/form-data"
+        "/api/accounts": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
                 ],
+                "description": "Retorna as contas cadastradas, sem expor os tokens da Netlify",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "deploy"
-                ],
-                "summary": "Cria ou atualiza sites na Netlify",
-                "parameters": [
-                    {
-                        "type": "string",
-                        "description": "ID do site na Netlify para atualização (opcional)",
-                        "name": "site_id",
-                        "in": "formData"
-                    },
-                    {
-                        "type": "string",
-                        "description": "Nome do site para teste",
-                        "name": "site_name",
-                        "in": "formData",
-                        "required": true
-                    },
-                    {
-                        "type": "string",
-                        "description": "Descrição do site para teste",
-                        "name": "description",
-                        "in": "formData"
-                    },
-                    {
-                        "type": "boolean",
-                        "description": "Remover o site após o teste",
-                        "name": "cleanup_after",
-                        "in": "formData"
-                    },
-                    {
-                        "type": "string",
-                        "description": "Domínio personalizado para o site (opcional)",
-                        "name": "custom_domain",
-                        "in": "formData"
-                    },
-                    {
-                        "type": "file",
-                        "description": "Arquivo HTML para deploy (opcional)",
-                        "name": "file",
-                        "in": "formData"
-                    },
-                    {
-                        "type": "string",
-                        "description": "Caminho da pasta local para deploy (opcional)",
-                        "name": "folder_path",
-                        "in": "formData"
-                    }
+                    "accounts"
                 ],
+                "summary": "Lista as contas",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/api.TestDeployResponse"
-                        }
-                    },
-                    "400": {
-                        "description": "Bad Request",
-                        "schema": {
-                            "$ref": "#/definitions/api.TestDeployResponse"
+                            "$ref": "#/definitions/api.AccountListResponse"
                         }
                     },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
-                            "$ref": "#/definitions/api.TestDeployResponse"
+                            "$ref": "#/definitions/api.ErrorResponse"
                         }
                     }
                 }
-            }
-        },
-        "/api/domains/add": {
+            },
             "post": {
-                "description": "Adiciona um domínio personalizado como alias para um site existente na Netlify",
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Cadastra uma conta com seu próprio token da Netlify, prefixo no S3, domínio base e idioma das mensagens",
                 "consumes": [
                     "application/json"
                 ],
@@ -105,45 +59,94 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "domains"
+                    "accounts"
                 ],
-                "summary": "Adiciona um domínio personalizado a um site",
+                "summary": "Cria uma conta",
                 "parameters": [
                     {
-                        "description": "Dados do domínio a ser adicionado",
+                        "description": "Dados da conta",
                         "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/api.DomainRequest"
+                            "$ref": "#/definitions/api.AccountRequest"
                         }
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "OK",
+                    "201": {
+                        "description": "Created",
                         "schema": {
-                            "$ref": "#/definitions/api.DomainResponse"
+                            "$ref": "#/definitions/api.AccountResponse"
                         }
                     },
                     "400": {
                         "description": "Bad Request",
                         "schema": {
-                            "$ref": "#/definitions/api.DomainResponse"
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
                         }
                     },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
-                            "$ref": "#/definitions/api.DomainResponse"
+                            "$ref": "#/definitions/api.ErrorResponse"
                         }
                     }
                 }
             }
         },
-        "/api/domains/remove": {
-            "post": {
-                "description": "Remove um domínio personalizado dos aliases de um site existente na Netlify",
+        "/api/accounts/{account}": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Retorna os dados de uma conta, sem expor o token da Netlify",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "accounts"
+                ],
+                "summary": "Consulta uma conta",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID da conta",
+                        "name": "account",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/api.AccountResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Atualiza o nome, o token da Netlify, o prefixo no S3, o domínio base ou o idioma de uma conta. Campos vazios são mantidos.",
                 "consumes": [
                     "application/json"
                 ],
@@ -151,17 +154,24 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "domains"
+                    "accounts"
                 ],
-                "summary": "Remove um domínio personalizado de um site",
+                "summary": "Atualiza uma conta",
                 "parameters": [
                     {
-                        "description": "Dados do domínio a ser removido",
+                        "type": "string",
+                        "description": "ID da conta",
+                        "name": "account",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Dados a atualizar",
                         "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/api.DomainRequest"
+                            "$ref": "#/definitions/api.AccountRequest"
                         }
                     }
                 ],
@@ -169,73 +179,82 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/api.DomainResponse"
+                            "$ref": "#/definitions/api.AccountResponse"
                         }
                     },
                     "400": {
                         "description": "Bad Request",
                         "schema": {
-                            "$ref": "#/definitions/api.DomainResponse"
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
                         }
                     },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
-                            "$ref": "#/definitions/api.DomainResponse"
+                            "$ref": "#/definitions/api.ErrorResponse"
                         }
                     }
                 }
-            }
-        },
-        "/api/domains/remove-primary": {
-            "post": {
-                "description": "Remove o domínio principal de um site existente na Netlify, mantendo os aliases",
-                "consumes": [
-                    "application/json"
+            },
+            "delete": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
                 ],
+                "description": "Remove a conta do cadastro local. Os sites da conta na Netlify não são alterados.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "domains"
+                    "accounts"
                 ],
-                "summary": "Remove o domínio principal de um site",
+                "summary": "Remove uma conta",
                 "parameters": [
                     {
-                        "description": "ID do site a ter o domínio principal removido",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/api.DomainRequest"
-                        }
+                        "type": "string",
+                        "description": "ID da conta",
+                        "name": "account",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/api.DomainResponse"
+                            "$ref": "#/definitions/api.AccountResponse"
                         }
                     },
-                    "400": {
-                        "description": "Bad Request",
+                    "404": {
+                        "description": "Not Found",
                         "schema": {
-                            "$ref": "#/definitions/api.DomainResponse"
+                            "$ref": "#/definitions/api.ErrorResponse"
                         }
                     },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
-                            "$ref": "#/definitions/api.DomainResponse"
+                            "$ref": "#/definitions/api.ErrorResponse"
                         }
                     }
                 }
             }
         },
-        "/api/domains/set-default": {
+        "/api/accounts/{account}/batch/deploy": {
             "post": {
-                "description": "Define um domínio personalizado como o domínio principal de um site existente na Netlify",
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Percorre as pastas de sites da conta em web/accounts, cria os sites ausentes na Netlify e publica todos em paralelo. O relatório por site fica disponível no job.",
                 "consumes": [
                     "application/json"
                 ],
@@ -243,122 +262,1930 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "domains"
+                    "deploy"
                 ],
-                "summary": "Define um domínio como o domínio principal",
+                "summary": "Publica todos os sites de uma conta",
                 "parameters": [
                     {
-                        "description": "Dados do domínio a ser definido como principal",
+                        "type": "string",
+                        "description": "ID da conta",
+                        "name": "account",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Pasta da conta e opções do lote",
                         "name": "request",
                         "in": "body",
-                        "required": true,
                         "schema": {
-                            "$ref": "#/definitions/api.DomainRequest"
+                            "$ref": "#/definitions/api.BatchDeployRequest"
                         }
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "OK",
+                    "202": {
+                        "description": "Accepted",
                         "schema": {
-                            "$ref": "#/definitions/api.DomainResponse"
+                            "$ref": "#/definitions/api.JobResponse"
                         }
                     },
                     "400": {
                         "description": "Bad Request",
                         "schema": {
-                            "$ref": "#/definitions/api.DomainResponse"
+                            "$ref": "#/definitions/api.ErrorResponse"
                         }
                     },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
-                            "$ref": "#/definitions/api.DomainResponse"
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
                         }
                     }
                 }
             }
         },
-        "/api/test/netlify/connection": {
-            "get": {
-                "description": "Testa a conexão com a API da Netlify e exibe informações sobre o token",
+        "/api/accounts/{account}/deploy/s3": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Enfileira o deploy dos arquivos de um caminho do bucket S3. Os arquivos são lidos diretamente do bucket e apenas os que a Netlify ainda não possui são enviados.",
                 "consumes": [
-                    "application/json"
+                    "multipart/form-data"
                 ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "netlify"
+                    "deploy"
+                ],
+                "summary": "Realiza o deploy de um caminho do bucket S3",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID da conta",
+                        "name": "account",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ID do site na Netlify para atualização (opcional)",
+                        "name": "site_id",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Nome do site (usado como subdomínio)",
+                        "name": "site_name",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Caminho no bucket S3",
+                        "name": "s3_path",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Domínio personalizado para o site (opcional)",
+                        "name": "custom_domain",
+                        "in": "formData"
+                    }
                 ],
-                "summary": "Testa a conexão com a API da Netlify",
                 "responses": {
-                    "200": {
-                        "description": "OK",
+                    "202": {
+                        "description": "Accepted",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": true
+                            "$ref": "#/definitions/api.JobResponse"
                         }
                     },
-                    "500": {
-                        "description": "Internal Server Error",
+                    "400": {
+                        "description": "Bad Request",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": true
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
                         }
                     }
                 }
             }
-        }
-    },
-    "definitions": {
-        "api.DomainRequest": {
-            "type": "object",
-            "required": [
-                "site_id"
-            ],
-            "properties": {
-                "domain": {
-                    "type": "string",
-                    "example": "example.com"
-                },
-                "site_id": {
-                    "type": "string",
-                    "example": "e17e2166-d8ab-4cad-9916-a9a3fed7750d"
-                }
-            }
         },
-        "api.DomainResponse": {
-            "type": "object",
-            "properties": {
-                "domain": {
-                    "type": "string",
-                    "example": "example.com"
-                },
-                "message": {
-                    "type": "string",
-                    "example": "Domínio adicionado com sucesso"
-                },
-                "site_id": {
-                    "type": "string",
-                    "example": "e17e2166-d8ab-4cad-9916-a9a3fed7750d"
-                },
-                "success": {
-                    "type": "boolean",
-                    "example": true
+        "/api/accounts/{account}/deploy/site": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Cria um novo site na Netlify ou atualiza um existente quando o ID é fornecido",
+                "consumes": [
+                    "multipart/form-data"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "deploy"
+                ],
+                "summary": "Cria ou atualiza sites na Netlify",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID da conta",
+                        "name": "account",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ID do site na Netlify para atualização (opcional)",
+                        "name": "site_id",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Nome do site para teste",
+                        "name": "site_name",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Descrição do site para teste",
+                        "name": "description",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Remover o site após o teste",
+                        "name": "cleanup_after",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Domínio personalizado para o site (opcional)",
+                        "name": "custom_domain",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "file",
+                        "description": "Arquivo HTML para deploy (opcional)",
+                        "name": "file",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Caminho da pasta local para deploy (opcional)",
+                        "name": "folder_path",
+                        "in": "formData"
+                    }
+                ],
+                "responses": {
+                    "202": {
+                        "description": "Accepted",
+                        "schema": {
+                            "$ref": "#/definitions/api.JobResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/accounts/{account}/deploys/{id}/events": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Transmite via Server-Sent Events as fases do deploy, o hash e o upload de cada arquivo, as mudanças de estado na Netlify e a URL final. O ID pode ser o do job ou o do deploy na Netlify. O evento done encerra a transmissão; envie Last-Event-ID para retomar de onde parou.",
+                "produces": [
+                    "text/event-stream"
+                ],
+                "tags": [
+                    "deploy"
+                ],
+                "summary": "Acompanha um deploy em tempo real",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID da conta",
+                        "name": "account",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ID do job ou do deploy na Netlify",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ID do último evento recebido",
+                        "name": "Last-Event-ID",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/api.JobEvent"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/accounts/{account}/domains/add": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Adiciona um domínio personalizado como alias para um site existente na Netlify",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "domains"
+                ],
+                "summary": "Adiciona um domínio personalizado a um site",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID da conta",
+                        "name": "account",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Dados do domínio a ser adicionado",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/api.DomainRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/api.DomainResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/accounts/{account}/domains/remove": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Remove um domínio personalizado dos aliases de um site existente na Netlify",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "domains"
+                ],
+                "summary": "Remove um domínio personalizado de um site",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID da conta",
+                        "name": "account",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Dados do domínio a ser removido",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/api.DomainRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/api.DomainResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/accounts/{account}/domains/remove-primary": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Remove o domínio principal de um site existente na Netlify, mantendo os aliases",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "domains"
+                ],
+                "summary": "Remove o domínio principal de um site",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID da conta",
+                        "name": "account",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "ID do site a ter o domínio principal removido",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/api.DomainRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/api.DomainResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/accounts/{account}/domains/set-default": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Define um domínio personalizado como o domínio principal de um site existente na Netlify",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "domains"
+                ],
+                "summary": "Define um domínio como o domínio principal",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID da conta",
+                        "name": "account",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Dados do domínio a ser definido como principal",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/api.DomainRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/api.DomainResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/accounts/{account}/jobs/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Retorna a fase, os contadores de progresso e a URL final de um job de deploy",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "deploy"
+                ],
+                "summary": "Consulta o andamento de um deploy",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID da conta",
+                        "name": "account",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ID do job",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/api.JobStatus"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/accounts/{account}/sites/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Retorna todos os detalhes do site na Netlify e a exclusão agendada, quando o site é temporário",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "sites"
+                ],
+                "summary": "Consulta um site",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID da conta",
+                        "name": "account",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ID do site na Netlify",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/api.SiteResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Exclui o site da Netlify e cancela a exclusão agendada, se houver",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "sites"
+                ],
+                "summary": "Exclui um site",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID da conta",
+                        "name": "account",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ID do site na Netlify",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/api.SiteResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "patch": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Altera o nome do site na Netlify, o que também altera seu subdomínio .netlify.app",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "sites"
+                ],
+                "summary": "Renomeia um site",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID da conta",
+                        "name": "account",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ID do site na Netlify",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Novo nome do site",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/api.RenameSiteRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/api.SiteResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/accounts/{account}/sites/{id}/deploys": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Retorna os deploys registrados para o site, do mais recente para o mais antigo, com paginação e filtro por estado",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "deploy"
+                ],
+                "summary": "Lista o histórico de deploys de um site",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID da conta",
+                        "name": "account",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ID do site na Netlify",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filtrar pelo estado do deploy (ex: ready, error)",
+                        "name": "state",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Página (padrão 1)",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Itens por página (padrão 20, máximo 100)",
+                        "name": "per_page",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/api.DeployHistoryResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/accounts/{account}/sites/{id}/domains/status": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Consulta o DNS (CNAME/A e TXT de verificação) e o certificado TLS de cada domínio do site, retornando a etapa de cada um (pending_dns, verified, cert_provisioning, live)",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "domains"
+                ],
+                "summary": "Estado dos domínios de um site",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID da conta",
+                        "name": "account",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ID do site na Netlify",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/api.DomainsStatusResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/accounts/{account}/sites/{id}/rollback": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Publica novamente um deploy pronto do site, informado pelo ID ou como \"previous\" para o deploy publicado antes do atual, e aguarda a publicação",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "deploy"
+                ],
+                "summary": "Restaura um deploy anterior de um site",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID da conta",
+                        "name": "account",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ID do site na Netlify",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Deploy a ser restaurado",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/api.RollbackRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/api.RollbackResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/accounts/{account}/sites/{id}/ssl/provision": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Solicita à Netlify a emissão do certificado Let's Encrypt para os domínios do site. Acompanhe a emissão pelo estado dos domínios.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "domains"
+                ],
+                "summary": "Solicita o certificado TLS de um site",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID da conta",
+                        "name": "account",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ID do site na Netlify",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/api.SSLProvisionResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/accounts/{account}/test/netlify/connection": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Testa a conexão com a API da Netlify e exibe informações sobre o token",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "netlify"
+                ],
+                "summary": "Testa a conexão com a API da Netlify",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID da conta",
+                        "name": "account",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/admin/keys": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Retorna as chaves de API cadastradas, sem os segredos",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "admin"
+                ],
+                "summary": "Lista as chaves de API",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/api.APIKeyListResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Cria uma chave vinculada a uma conta e a um papel. O segredo é retornado apenas nesta resposta; somente seu hash é armazenado.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "admin"
+                ],
+                "summary": "Cria uma chave de API",
+                "parameters": [
+                    {
+                        "description": "Dados da chave",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/api.APIKeyRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/api.APIKeyResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/admin/keys/{id}": {
+            "delete": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Remove a chave de API, que deixa de ser aceita imediatamente",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "admin"
+                ],
+                "summary": "Revoga uma chave de API",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID da chave",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/api.APIKeyResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/i18n/messages": {
+            "get": {
+                "description": "Retorna as mensagens da interface web e as descrições dos códigos de erro no idioma informado em lang ou negociado pelo cabeçalho Accept-Language",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "interface"
+                ],
+                "summary": "Catálogo de mensagens da interface web",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Idioma (pt-BR ou en)",
+                        "name": "lang",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/api.MessagesResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/logs": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Retorna os registros de log (JSON), do mais recente ao mais antigo, com filtros por nível, período, requisição, conta, site e deploy e paginação por cursor. Inclui os arquivos rotacionados.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "logs"
+                ],
+                "summary": "Consulta os logs da aplicação",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Nível mínimo (debug, info, warn, error; padrão debug)",
+                        "name": "level",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Início do período (RFC 3339, ex: 2025-05-01T00:00:00Z)",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Fim do período (RFC 3339)",
+                        "name": "to",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "ID da requisição",
+                        "name": "request_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "ID da conta",
+                        "name": "account",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "ID do site na Netlify",
+                        "name": "site_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "ID do deploy na Netlify",
+                        "name": "deploy_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Cursor da próxima página (next_cursor da resposta anterior)",
+                        "name": "cursor",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Registros por página (padrão 100, máximo 1000)",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/api.LogsResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        }
+    },
+    "definitions": {
+        "api.APIKeyListResponse": {
+            "type": "object",
+            "properties": {
+                "keys": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/api.APIKeyView"
+                    }
+                },
+                "success": {
+                    "type": "boolean",
+                    "example": true
+                }
+            }
+        },
+        "api.APIKeyRequest": {
+            "type": "object",
+            "required": [
+                "role"
+            ],
+            "properties": {
+                "account_id": {
+                    "type": "string",
+                    "example": "elizio"
+                },
+                "name": {
+                    "type": "string",
+                    "example": "CI do site da Elizio"
+                },
+                "role": {
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/api.Role"
+                        }
+                    ],
+                    "example": "deployer"
+                }
+            }
+        },
+        "api.APIKeyResponse": {
+            "type": "object",
+            "properties": {
+                "key": {
+                    "$ref": "#/definitions/api.APIKeyView"
+                },
+                "message": {
+                    "type": "string",
+                    "example": "Chave criada com sucesso"
+                },
+                "secret": {
+                    "type": "string",
+                    "example": "npk_1a2b3c4d..."
+                },
+                "success": {
+                    "type": "boolean",
+                    "example": true
+                }
+            }
+        },
+        "api.APIKeyView": {
+            "type": "object",
+            "properties": {
+                "account_id": {
+                    "type": "string",
+                    "example": "elizio"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string",
+                    "example": "3f2a9c1d7e8b4a60"
+                },
+                "name": {
+                    "type": "string",
+                    "example": "CI do site da Elizio"
+                },
+                "prefix": {
+                    "type": "string",
+                    "example": "npk_1a2b3c4d"
+                },
+                "role": {
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/api.Role"
+                        }
+                    ],
+                    "example": "deployer"
+                }
+            }
+        },
+        "api.AccountListResponse": {
+            "type": "object",
+            "properties": {
+                "accounts": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/api.AccountView"
+                    }
+                },
+                "success": {
+                    "type": "boolean",
+                    "example": true
+                }
+            }
+        },
+        "api.AccountRequest": {
+            "type": "object",
+            "properties": {
+                "base_domain": {
+                    "type": "string",
+                    "example": "sites.elizio.com.br"
+                },
+                "id": {
+                    "type": "string",
+                    "example": "elizio"
+                },
+                "language": {
+                    "type": "string",
+                    "example": "en"
+                },
+                "name": {
+                    "type": "string",
+                    "example": "Elizio Confeitaria"
+                },
+                "netlify_token": {
+                    "type": "string",
+                    "example": "nfp_xxxxxxxx"
+                },
+                "s3_prefix": {
+                    "type": "string",
+                    "example": "clientes/elizio"
+                }
+            }
+        },
+        "api.AccountResponse": {
+            "type": "object",
+            "properties": {
+                "account": {
+                    "$ref": "#/definitions/api.AccountView"
+                },
+                "message": {
+                    "type": "string",
+                    "example": "Conta criada com sucesso"
+                },
+                "success": {
+                    "type": "boolean",
+                    "example": true
+                }
+            }
+        },
+        "api.AccountView": {
+            "type": "object",
+            "properties": {
+                "base_domain": {
+                    "type": "string",
+                    "example": "sites.elizio.com.br"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "has_netlify_token": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "id": {
+                    "type": "string",
+                    "example": "elizio"
+                },
+                "language": {
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/i18n.Lang"
+                        }
+                    ],
+                    "example": "en"
+                },
+                "name": {
+                    "type": "string",
+                    "example": "Elizio Confeitaria"
+                },
+                "s3_prefix": {
+                    "type": "string",
+                    "example": "clientes/elizio"
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "api.BatchDeployRequest": {
+            "type": "object",
+            "properties": {
+                "account": {
+                    "type": "string",
+                    "example": "elizio"
+                },
+                "concurrency": {
+                    "type": "integer",
+                    "example": 4
+                },
+                "name_pattern": {
+                    "type": "string",
+                    "example": "\u003caccount\u003e-\u003csite\u003e"
+                }
+            }
+        },
+        "api.DeployHistoryResponse": {
+            "type": "object",
+            "properties": {
+                "deploys": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/store.DeployRecord"
+                    }
+                },
+                "page": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "per_page": {
+                    "type": "integer",
+                    "example": 20
+                },
+                "site_id": {
+                    "type": "string",
+                    "example": "a1b2c3d4"
+                },
+                "success": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "total": {
+                    "type": "integer",
+                    "example": 57
+                }
+            }
+        },
+        "api.DomainRequest": {
+            "type": "object",
+            "required": [
+                "site_id"
+            ],
+            "properties": {
+                "domain": {
+                    "type": "string",
+                    "example": "example.com"
+                },
+                "site_id": {
+                    "type": "string",
+                    "example": "e17e2166-d8ab-4cad-9916-a9a3fed7750d"
+                }
+            }
+        },
+        "api.DomainResponse": {
+            "type": "object",
+            "properties": {
+                "domain": {
+                    "type": "string",
+                    "example": "example.com"
+                },
+                "message": {
+                    "type": "string",
+                    "example": "Domínio adicionado com sucesso"
+                },
+                "site_id": {
+                    "type": "string",
+                    "example": "e17e2166-d8ab-4cad-9916-a9a3fed7750d"
+                },
+                "success": {
+                    "type": "boolean",
+                    "example": true
+                }
+            }
+        },
+        "api.DomainsStatusResponse": {
+            "type": "object",
+            "properties": {
+                "message": {
+                    "type": "string"
+                },
+                "status": {
+                    "$ref": "#/definitions/netlify.SiteDomainsStatus"
+                },
+                "success": {
+                    "type": "boolean",
+                    "example": true
+                }
+            }
+        },
+        "api.ErrorCode": {
+            "type": "string",
+            "enum": [
+                "invalid_request",
+                "unauthorized",
+                "forbidden",
+                "not_found",
+                "account_not_found",
+                "account_exists",
+                "job_not_found",
+                "site_not_found",
+                "deploy_not_found",
+                "domain_exists",
+                "domain_not_found",
+                "primary_domain_set",
+                "deploy_not_ready",
+                "no_previous_deploy",
+                "deploy_failed",
+                "deploy_rejected",
+                "deploy_timeout",
+                "rate_limited",
+                "netlify_unavailable",
+                "netlify_unauthorized",
+                "netlify_rejected",
+                "queue_full",
+                "timeout",
+                "internal_error"
+            ],
+            "x-enum-varnames": [
+                "CodeInvalidRequest",
+                "CodeUnauthorized",
+                "CodeForbidden",
+                "CodeNotFound",
+                "CodeAccountNotFound",
+                "CodeAccountExists",
+                "CodeJobNotFound",
+                "CodeSiteNotFound",
+                "CodeDeployNotFound",
+                "CodeDomainExists",
+                "CodeDomainNotFound",
+                "CodePrimaryDomainSet",
+                "CodeDeployNotReady",
+                "CodeNoPreviousDeploy",
+                "CodeDeployFailed",
+                "CodeDeployRejected",
+                "CodeDeployTimeout",
+                "CodeRateLimited",
+                "CodeNetlifyUnavailable",
+                "CodeNetlifyUnauthorized",
+                "CodeNetlifyRejected",
+                "CodeQueueFull",
+                "CodeTimeout",
+                "CodeInternal"
+            ]
+        },
+        "api.ErrorResponse": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/api.ErrorCode"
+                        }
+                    ],
+                    "example": "site_not_found"
+                },
+                "detail": {
+                    "type": "string",
+                    "example": "site não encontrado: a1b2c3d4"
+                },
+                "message": {
+                    "type": "string",
+                    "example": "Erro ao obter site: site não encontrado"
+                },
+                "request_id": {
+                    "type": "string",
+                    "example": "3f2a9c1d7e8b4a60"
+                },
+                "success": {
+                    "type": "boolean",
+                    "example": false
+                }
+            }
+        },
+        "api.JobEvent": {
+            "type": "object",
+            "properties": {
+                "bytes": {
+                    "type": "integer",
+                    "example": 2048
+                },
+                "bytes_done": {
+                    "type": "integer",
+                    "example": 262144
+                },
+                "bytes_total": {
+                    "type": "integer",
+                    "example": 1048576
+                },
+                "deploy_id": {
+                    "type": "string",
+                    "example": "5f1b2c3d4e5f6a7b8c9d0e1f"
+                },
+                "deploy_url": {
+                    "type": "string",
+                    "example": "https://test-site.netlify.app"
+                },
+                "done": {
+                    "type": "integer",
+                    "example": 3
+                },
+                "error": {
+                    "type": "string"
+                },
+                "error_code": {
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/api.ErrorCode"
+                        }
+                    ],
+                    "example": "deploy_failed"
+                },
+                "from": {
+                    "type": "string",
+                    "example": "uploading"
+                },
+                "id": {
+                    "type": "integer",
+                    "example": 12
+                },
+                "message": {
+                    "type": "string"
+                },
+                "path": {
+                    "type": "string",
+                    "example": "css/style.css"
+                },
+                "phase": {
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/api.JobPhase"
+                        }
+                    ],
+                    "example": "uploading"
+                },
+                "time": {
+                    "type": "string"
+                },
+                "to": {
+                    "type": "string",
+                    "example": "processing"
+                },
+                "total": {
+                    "type": "integer",
+                    "example": 42
+                },
+                "type": {
+                    "type": "string",
+                    "example": "file_uploaded"
+                }
+            }
+        },
+        "api.JobPhase": {
+            "type": "string",
+            "enum": [
+                "queued",
+                "downloading",
+                "uploading",
+                "processing",
+                "ready",
+                "error"
+            ],
+            "x-enum-varnames": [
+                "JobQueued",
+                "JobDownloading",
+                "JobUploading",
+                "JobProcessing",
+                "JobReady",
+                "JobError"
+            ]
+        },
+        "api.JobResponse": {
+            "type": "object",
+            "properties": {
+                "job_id": {
+                    "type": "string",
+                    "example": "9f86d081884c7d65"
+                },
+                "message": {
+                    "type": "string",
+                    "example": "Deploy enfileirado com sucesso"
+                },
+                "status_url": {
+                    "type": "string",
+                    "example": "/api/accounts/elizio/jobs/9f86d081884c7d65"
+                },
+                "success": {
+                    "type": "boolean",
+                    "example": true
                 }
             }
         },
-        "api.TestDeployResponse": {
+        "api.JobStatus": {
             "type": "object",
             "properties": {
+                "account_id": {
+                    "type": "string",
+                    "example": "elizio"
+                },
+                "bytes_total": {
+                    "type": "integer",
+                    "example": 1048576
+                },
+                "bytes_uploaded": {
+                    "type": "integer",
+                    "example": 262144
+                },
                 "created_at": {
+                    "type": "string"
+                },
+                "deploy": {
+                    "type": "object"
+                },
+                "deploy_id": {
+                    "type": "string",
+                    "example": "5f1b2c3d4e5f6a7b8c9d0e1f"
+                },
+                "deploy_state": {
+                    "type": "string",
+                    "example": "processing"
+                },
+                "deploy_url": {
+                    "type": "string",
+                    "example": "https://test-site.netlify.app"
+                },
+                "error": {
+                    "type": "string"
+                },
+                "error_code": {
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/api.ErrorCode"
+                        }
+                    ],
+                    "example": "deploy_failed"
+                },
+                "files_total": {
+                    "type": "integer",
+                    "example": 42
+                },
+                "files_uploaded": {
+                    "type": "integer",
+                    "example": 10
+                },
+                "finished_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string",
+                    "example": "9f86d081884c7d65"
+                },
+                "last_retry_error": {
                     "type": "string",
-                    "example": "2023-10-25T15:30:45Z"
+                    "example": "status 429"
                 },
                 "message": {
                     "type": "string",
-                    "example": "Site de teste criado com sucesso"
+                    "example": "Deploy concluído com sucesso"
+                },
+                "phase": {
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/api.JobPhase"
+                        }
+                    ],
+                    "example": "uploading"
+                },
+                "result": {
+                    "type": "object"
+                },
+                "retries": {
+                    "type": "integer",
+                    "example": 0
                 },
                 "site_id": {
                     "type": "string",
@@ -368,15 +2195,402 @@ const docTemplate = `{
                     "type": "string",
                     "example": "https://test-site.netlify.app"
                 },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "api.LogsResponse": {
+            "type": "object",
+            "properties": {
+                "entries": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/logging.Entry"
+                    }
+                },
+                "next_cursor": {
+                    "type": "string",
+                    "example": "MTc2MDY0NjcyMjk2MTkxODIzMi4x"
+                },
+                "success": {
+                    "type": "boolean",
+                    "example": true
+                }
+            }
+        },
+        "api.MessagesResponse": {
+            "type": "object",
+            "properties": {
+                "language": {
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/i18n.Lang"
+                        }
+                    ],
+                    "example": "en"
+                },
+                "languages": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/i18n.Lang"
+                    }
+                },
+                "messages": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "api.RenameSiteRequest": {
+            "type": "object",
+            "required": [
+                "name"
+            ],
+            "properties": {
+                "name": {
+                    "type": "string",
+                    "example": "meu-novo-site"
+                }
+            }
+        },
+        "api.Role": {
+            "type": "string",
+            "enum": [
+                "viewer",
+                "deployer",
+                "domain-admin",
+                "admin"
+            ],
+            "x-enum-varnames": [
+                "RoleViewer",
+                "RoleDeployer",
+                "RoleDomainAdmin",
+                "RoleAdmin"
+            ]
+        },
+        "api.RollbackRequest": {
+            "type": "object",
+            "required": [
+                "deploy_id"
+            ],
+            "properties": {
+                "deploy_id": {
+                    "type": "string",
+                    "example": "previous"
+                }
+            }
+        },
+        "api.RollbackResponse": {
+            "type": "object",
+            "properties": {
+                "deploy_id": {
+                    "type": "string",
+                    "example": "5f1b2c3d4e5f6a7b8c9d0e1f"
+                },
+                "deploy_url": {
+                    "type": "string",
+                    "example": "https://test-site.netlify.app"
+                },
+                "message": {
+                    "type": "string",
+                    "example": "Rollback concluído com sucesso"
+                },
+                "site_id": {
+                    "type": "string",
+                    "example": "a1b2c3d4"
+                },
+                "success": {
+                    "type": "boolean",
+                    "example": true
+                }
+            }
+        },
+        "api.SSLProvisionResponse": {
+            "type": "object",
+            "properties": {
+                "certificate": {
+                    "type": "object"
+                },
+                "message": {
+                    "type": "string",
+                    "example": "Certificado solicitado com sucesso"
+                },
+                "site_id": {
+                    "type": "string",
+                    "example": "a1b2c3d4"
+                },
+                "success": {
+                    "type": "boolean",
+                    "example": true
+                }
+            }
+        },
+        "api.SiteResponse": {
+            "type": "object",
+            "properties": {
+                "expiration": {
+                    "$ref": "#/definitions/store.SiteExpiration"
+                },
+                "message": {
+                    "type": "string",
+                    "example": "Site renomeado com sucesso"
+                },
+                "site": {
+                    "type": "object"
+                },
                 "success": {
                     "type": "boolean",
                     "example": true
+                }
+            }
+        },
+        "i18n.Lang": {
+            "type": "string",
+            "enum": [
+                "pt-BR",
+                "en",
+                "pt-BR"
+            ],
+            "x-enum-varnames": [
+                "PortugueseBR",
+                "English",
+                "Default"
+            ]
+        },
+        "logging.Entry": {
+            "type": "object",
+            "properties": {
+                "account": {
+                    "type": "string",
+                    "example": "elizio"
+                },
+                "deploy_id": {
+                    "type": "string",
+                    "example": "5f1b2c3d4e5f6a7b8c9d0e1f"
+                },
+                "fields": {
+                    "type": "object"
+                },
+                "job_id": {
+                    "type": "string",
+                    "example": "9f86d081884c7d65"
+                },
+                "level": {
+                    "type": "string",
+                    "example": "ERROR"
+                },
+                "message": {
+                    "type": "string",
+                    "example": "Erro no rollback"
+                },
+                "request_id": {
+                    "type": "string",
+                    "example": "3f2a9c1d7e8b4a60"
+                },
+                "site_id": {
+                    "type": "string",
+                    "example": "a1b2c3d4"
+                },
+                "time": {
+                    "type": "string"
+                }
+            }
+        },
+        "netlify.DomainState": {
+            "type": "string",
+            "enum": [
+                "pending_dns",
+                "verified",
+                "cert_provisioning",
+                "live"
+            ],
+            "x-enum-varnames": [
+                "DomainPendingDNS",
+                "DomainVerified",
+                "DomainCertProvisioning",
+                "DomainLive"
+            ]
+        },
+        "netlify.DomainStatus": {
+            "type": "object",
+            "properties": {
+                "addresses": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "cert_covered": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "cname": {
+                    "type": "string",
+                    "example": "meu-site.netlify.app"
+                },
+                "domain": {
+                    "type": "string",
+                    "example": "meu-site.exemplo.com"
+                },
+                "errors": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "expected_target": {
+                    "type": "string",
+                    "example": "meu-site.netlify.app"
+                },
+                "points_to_netlify": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "primary": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "state": {
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/netlify.DomainState"
+                        }
+                    ],
+                    "example": "live"
+                },
+                "txt_record": {
+                    "type": "string",
+                    "example": "netlify-challenge.meu-site.exemplo.com"
                 },
-                "test_success": {
+                "txt_verified": {
                     "type": "boolean",
                     "example": true
                 }
             }
+        },
+        "netlify.SiteDomainsStatus": {
+            "type": "object",
+            "properties": {
+                "certificate": {
+                    "type": "object"
+                },
+                "checked_at": {
+                    "type": "string"
+                },
+                "domains": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/netlify.DomainStatus"
+                    }
+                },
+                "site_id": {
+                    "type": "string",
+                    "example": "a1b2c3d4"
+                },
+                "site_name": {
+                    "type": "string",
+                    "example": "meu-site"
+                }
+            }
+        },
+        "store.DeployRecord": {
+            "type": "object",
+            "properties": {
+                "deploy_id": {
+                    "type": "string",
+                    "example": "5f1b2c3d4e5f6a7b8c9d0e1f"
+                },
+                "duration_ms": {
+                    "type": "integer",
+                    "example": 5300
+                },
+                "error": {
+                    "type": "string"
+                },
+                "file_count": {
+                    "type": "integer",
+                    "example": 12
+                },
+                "finished_at": {
+                    "type": "string"
+                },
+                "site_id": {
+                    "type": "string",
+                    "example": "a1b2c3d4"
+                },
+                "site_name": {
+                    "type": "string",
+                    "example": "test-site"
+                },
+                "source": {
+                    "type": "string",
+                    "example": "web/accounts/elizio/bolo-brigadeiro"
+                },
+                "source_type": {
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/store.SourceType"
+                        }
+                    ],
+                    "example": "folder"
+                },
+                "started_at": {
+                    "type": "string"
+                },
+                "state": {
+                    "type": "string",
+                    "example": "ready"
+                },
+                "total_bytes": {
+                    "type": "integer",
+                    "example": 1048576
+                }
+            }
+        },
+        "store.SiteExpiration": {
+            "type": "object",
+            "properties": {
+                "account_id": {
+                    "type": "string",
+                    "example": "elizio"
+                },
+                "expires_at": {
+                    "type": "string"
+                },
+                "site_id": {
+                    "type": "string",
+                    "example": "a1b2c3d4"
+                },
+                "site_name": {
+                    "type": "string",
+                    "example": "test-site"
+                }
+            }
+        },
+        "store.SourceType": {
+            "type": "string",
+            "enum": [
+                "upload",
+                "folder",
+                "s3",
+                "content"
+            ],
+            "x-enum-varnames": [
+                "SourceUpload",
+                "SourceFolder",
+                "SourceS3",
+                "SourceContent"
+            ]
+        }
+    },
+    "securityDefinitions": {
+        "ApiKeyAuth": {
+            "type": "apiKey",
+            "name": "X-API-Key",
+            "in": "header"
         }
     }
 }`
@@ -0,0 +1,302 @@
+package api
+
+import (
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/kodestech/poc-netlify/internal/config"
+	"github.com/kodestech/poc-netlify/internal/i18n"
+	"github.com/kodestech/poc-netlify/internal/store"
+)
+
+// accountContextKey é a chave da conta da requisição no contexto do Gin
+const accountContextKey = "account"
+
+// AccountRequest representa os dados de criação ou atualização de uma conta
+type AccountRequest struct {
+	ID           string `json:"id" example:"elizio" swagger:"description=ID da conta (apenas na criação; letras minúsculas, números e hífens)"`
+	Name         string `json:"name" example:"Elizio Confeitaria" swagger:"description=Nome de exibição da conta"`
+	NetlifyToken string `json:"netlify_token" example:"nfp_xxxxxxxx" swagger:"description=Token de acesso ao time da conta na Netlify"`
+	S3Prefix     string `json:"s3_prefix" example:"clientes/elizio" swagger:"description=Prefixo da conta no bucket S3"`
+	BaseDomain   string `json:"base_domain" example:"sites.elizio.com.br" swagger:"description=Domínio base dos subdomínios da conta"`
+	Language     string `json:"language" example:"en" swagger:"description=Idioma das mensagens da API quando a requisição não envia Accept-Language (pt-BR ou en)"`
+}
+
+// AccountView representa uma conta nas respostas da API, sem expor o token
+type AccountView struct {
+	ID              string    `json:"id" example:"elizio" swagger:"description=ID da conta"`
+	Name            string    `json:"name,omitempty" example:"Elizio Confeitaria" swagger:"description=Nome de exibição da conta"`
+	S3Prefix        string    `json:"s3_prefix,omitempty" example:"clientes/elizio" swagger:"description=Prefixo da conta no bucket S3"`
+	BaseDomain      string    `json:"base_domain,omitempty" example:"sites.elizio.com.br" swagger:"description=Domínio base dos subdomínios da conta"`
+	Language        i18n.Lang `json:"language,omitempty" example:"en" swagger:"description=Idioma das mensagens da API para a conta"`
+	HasNetlifyToken bool      `json:"has_netlify_token" example:"true" swagger:"description=Indica se a conta possui token da Netlify"`
+	CreatedAt       time.Time `json:"created_at"`
+	UpdatedAt       time.Time `json:"updated_at"`
+}
+
+// AccountResponse representa a resposta das operações sobre uma conta
+type AccountResponse struct {
+	Success bool         `json:"success" example:"true" swagger:"description=Indica se a operação foi bem-sucedida"`
+	Message string       `json:"message,omitempty" example:"Conta criada com sucesso" swagger:"description=Mensagem descritiva sobre o resultado da operação"`
+	Account *AccountView `json:"account,omitempty" swagger:"description=Conta criada, atualizada ou consultada"`
+}
+
+// AccountListResponse representa a lista de contas cadastradas
+type AccountListResponse struct {
+	Success  bool          `json:"success" example:"true" swagger:"description=Indica se a operação foi bem-sucedida"`
+	Accounts []AccountView `json:"accounts" swagger:"description=Contas cadastradas"`
+}
+
+// newAccountView converte uma conta para a representação da API
+func newAccountView(acc *store.Account) *AccountView {
+	return &AccountView{
+		ID:              acc.ID,
+		Name:            acc.Name,
+		S3Prefix:        acc.S3Prefix,
+		BaseDomain:      acc.BaseDomain,
+		Language:        i18n.Lang(acc.Language),
+		HasNetlifyToken: acc.NetlifyToken != "",
+		CreatedAt:       acc.CreatedAt,
+		UpdatedAt:       acc.UpdatedAt,
+	}
+}
+
+// loadAccount carrega a conta da rota e a disponibiliza para os handlers
+func (s *Server) loadAccount(c *gin.Context) {
+	id := c.Param("account")
+	ctx := logWith(c, "account", id)
+	acc, ok, err := s.store.GetAccount(id)
+	if err != nil {
+		slog.ErrorContext(ctx, "Erro ao carregar conta", "error", err)
+		respondError(c, err, "account.load_failed")
+		return
+	}
+	if !ok {
+		respondErrorCode(c, http.StatusNotFound, CodeAccountNotFound, "account.not_found", id)
+		return
+	}
+
+	c.Set(accountContextKey, acc)
+	c.Next()
+}
+
+// accountLanguage normaliza o idioma informado para uma conta; vazio usa o idioma padrão da API
+func accountLanguage(value string) (string, bool) {
+	if value == "" {
+		return "", true
+	}
+	lang, ok := i18n.Parse(value)
+	return string(lang), ok
+}
+
+// currentAccount retorna a conta carregada para a requisição
+func currentAccount(c *gin.Context) *store.Account {
+	return c.MustGet(accountContextKey).(*store.Account)
+}
+
+// accountConfig retorna a configuração da conta da requisição, sem alterar a configuração global
+func (s *Server) accountConfig(c *gin.Context) *config.Config {
+	acc := currentAccount(c)
+	return s.config.ForAccount(acc.ID, acc.NetlifyToken, acc.BaseDomain, acc.S3Prefix)
+}
+
+// accountPath monta o caminho de uma rota dentro da conta da requisição
+func accountPath(c *gin.Context, path string) string {
+	return "/api/accounts/" + currentAccount(c).ID + path
+}
+
+// handleListAccounts lista as contas cadastradas
+// @Summary Lista as contas
+// @Description Retorna as contas cadastradas, sem expor os tokens da Netlify
+// @Tags accounts
+// @Produce json
+// @Success 200 {object} AccountListResponse
+// @Failure 500 {object} ErrorResponse
+// @Security ApiKeyAuth
+// @Router /api/accounts [get]
+func (s *Server) handleListAccounts(c *gin.Context) {
+	accounts, err := s.store.ListAccounts()
+	if err != nil {
+		slog.ErrorContext(c.Request.Context(), "Erro ao listar contas", "error", err)
+		respondError(c, err, "account.list_failed")
+		return
+	}
+
+	views := make([]AccountView, 0, len(accounts))
+	for i := range accounts {
+		views = append(views, *newAccountView(&accounts[i]))
+	}
+
+	c.JSON(http.StatusOK, AccountListResponse{
+		Success:  true,
+		Accounts: views,
+	})
+}
+
+// handleCreateAccount cadastra uma nova conta
+// @Summary Cria uma conta
+// @Description Cadastra uma conta com seu próprio token da Netlify, prefixo no S3, domínio base e idioma das mensagens
+// @Tags accounts
+// @Accept json
+// @Produce json
+// @Param request body AccountRequest true "Dados da conta"
+// @Success 201 {object} AccountResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Security ApiKeyAuth
+// @Router /api/accounts [post]
+func (s *Server) handleCreateAccount(c *gin.Context) {
+	var req AccountRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondInvalidRequest(c, err, "request.invalid_body")
+		return
+	}
+
+	if !store.ValidAccountID(req.ID) {
+		respondInvalidRequest(c, nil, "account.invalid_id")
+		return
+	}
+	if req.NetlifyToken == "" {
+		respondInvalidRequest(c, nil, "account.token_required")
+		return
+	}
+	language, ok := accountLanguage(req.Language)
+	if !ok {
+		respondInvalidRequest(c, nil, "account.invalid_language", req.Language)
+		return
+	}
+
+	_, exists, err := s.store.GetAccount(req.ID)
+	if err != nil {
+		respondError(c, err, "account.check_failed")
+		return
+	}
+	if exists {
+		respondErrorCode(c, http.StatusConflict, CodeAccountExists, "account.exists", req.ID)
+		return
+	}
+
+	acc := &store.Account{
+		ID:           req.ID,
+		Name:         req.Name,
+		NetlifyToken: req.NetlifyToken,
+		S3Prefix:     req.S3Prefix,
+		BaseDomain:   req.BaseDomain,
+		Language:     language,
+	}
+	if err := s.store.SaveAccount(acc); err != nil {
+		slog.ErrorContext(c.Request.Context(), "Erro ao criar conta", "account", req.ID, "error", err)
+		respondError(c, err, "account.create_failed")
+		return
+	}
+
+	slog.InfoContext(c.Request.Context(), "Conta criada", "account", acc.ID)
+	c.JSON(http.StatusCreated, AccountResponse{
+		Success: true,
+		Message: translate(c, "account.created"),
+		Account: newAccountView(acc),
+	})
+}
+
+// handleGetAccount retorna uma conta
+// @Summary Consulta uma conta
+// @Description Retorna os dados de uma conta, sem expor o token da Netlify
+// @Tags accounts
+// @Produce json
+// @Param account path string true "ID da conta"
+// @Success 200 {object} AccountResponse
+// @Failure 404 {object} ErrorResponse
+// @Security ApiKeyAuth
+// @Router /api/accounts/{account} [get]
+func (s *Server) handleGetAccount(c *gin.Context) {
+	c.JSON(http.StatusOK, AccountResponse{
+		Success: true,
+		Account: newAccountView(currentAccount(c)),
+	})
+}
+
+// handleUpdateAccount atualiza uma conta
+// @Summary Atualiza uma conta
+// @Description Atualiza o nome, o token da Netlify, o prefixo no S3, o domínio base ou o idioma de uma conta. Campos vazios são mantidos.
+// @Tags accounts
+// @Accept json
+// @Produce json
+// @Param account path string true "ID da conta"
+// @Param request body AccountRequest true "Dados a atualizar"
+// @Success 200 {object} AccountResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Security ApiKeyAuth
+// @Router /api/accounts/{account} [put]
+func (s *Server) handleUpdateAccount(c *gin.Context) {
+	var req AccountRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondInvalidRequest(c, err, "request.invalid_body")
+		return
+	}
+
+	acc := *currentAccount(c)
+	if req.Name != "" {
+		acc.Name = req.Name
+	}
+	if req.NetlifyToken != "" {
+		acc.NetlifyToken = req.NetlifyToken
+	}
+	if req.S3Prefix != "" {
+		acc.S3Prefix = req.S3Prefix
+	}
+	if req.BaseDomain != "" {
+		acc.BaseDomain = req.BaseDomain
+	}
+	if req.Language != "" {
+		language, ok := accountLanguage(req.Language)
+		if !ok {
+			respondInvalidRequest(c, nil, "account.invalid_language", req.Language)
+			return
+		}
+		acc.Language = language
+	}
+
+	if err := s.store.SaveAccount(&acc); err != nil {
+		slog.ErrorContext(c.Request.Context(), "Erro ao atualizar conta", "error", err)
+		respondError(c, err, "account.update_failed")
+		return
+	}
+
+	slog.InfoContext(c.Request.Context(), "Conta atualizada")
+	c.JSON(http.StatusOK, AccountResponse{
+		Success: true,
+		Message: translate(c, "account.updated"),
+		Account: newAccountView(&acc),
+	})
+}
+
+// handleDeleteAccount remove uma conta
+// @Summary Remove uma conta
+// @Description Remove a conta do cadastro local. Os sites da conta na Netlify não são alterados.
+// @Tags accounts
+// @Produce json
+// @Param account path string true "ID da conta"
+// @Success 200 {object} AccountResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Security ApiKeyAuth
+// @Router /api/accounts/{account} [delete]
+func (s *Server) handleDeleteAccount(c *gin.Context) {
+	id := currentAccount(c).ID
+	if err := s.store.DeleteAccount(id); err != nil {
+		respondError(c, err, "account.delete_failed")
+		return
+	}
+
+	slog.InfoContext(c.Request.Context(), "Conta removida")
+
+	c.JSON(http.StatusOK, AccountResponse{
+		Success: true,
+		Message: translate(c, "account.deleted"),
+	})
+}
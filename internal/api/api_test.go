@@ -0,0 +1,157 @@
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"log/slog"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/kodestech/poc-netlify/internal/config"
+	"github.com/kodestech/poc-netlify/internal/dns"
+	"github.com/kodestech/poc-netlify/internal/netlify/netlifytest"
+	"github.com/kodestech/poc-netlify/internal/store"
+)
+
+// Contas e chave de administrador criadas por newTestServer
+const (
+	testAccountA = "conta-a"
+	testAccountB = "conta-b"
+	testAdminKey = "adm-chave-de-teste"
+	testToken    = "token-teste"
+)
+
+func TestMain(m *testing.M) {
+	gin.SetMode(gin.TestMode)
+	slog.SetDefault(slog.New(slog.NewTextHandler(io.Discard, nil)))
+	os.Exit(m.Run())
+}
+
+// testServer é a API ligada a um servidor falso da Netlify e a um banco temporário
+type testServer struct {
+	*Server
+	netlify *netlifytest.Server
+}
+
+// newTestServer cria a API com as contas testAccountA e testAccountB, ambas com o token aceito pelo servidor falso
+func newTestServer(t *testing.T) *testServer {
+	t.Helper()
+
+	fake := netlifytest.NewServer()
+	fake.Token = testToken
+	t.Cleanup(fake.Close)
+
+	dir := t.TempDir()
+	st, err := store.Open(filepath.Join(dir, "data.db"), bytes.Repeat([]byte{1}, store.EncryptionKeySize))
+	if err != nil {
+		t.Fatalf("erro ao abrir o banco: %v", err)
+	}
+	t.Cleanup(func() { st.Close() })
+
+	cfg := &config.Config{
+		NetlifyAPIURL:     fake.APIURL(),
+		BaseDomain:        "sites.exemplo.com",
+		AdminAPIKey:       testAdminKey,
+		JobWorkers:        2,
+		JobQueueSize:      10,
+		DeployWaitTimeout: 5 * time.Second,
+		TestSiteTTL:       time.Hour,
+		ArchiveMaxFiles:   100,
+		ArchiveMaxBytes:   1 << 20,
+		AccountsPath:      filepath.Join(dir, "accounts"),
+		BatchNamePattern:  "<account>-<site>",
+		BatchConcurrency:  2,
+	}
+
+	for _, id := range []string{testAccountA, testAccountB} {
+		if err := st.SaveAccount(&store.Account{ID: id, NetlifyToken: testToken}); err != nil {
+			t.Fatalf("erro ao criar conta %s: %v", id, err)
+		}
+	}
+
+	return &testServer{Server: NewServer(cfg, st, dns.NewMemoryProvider()), netlify: fake}
+}
+
+// createKey grava uma chave de API com o papel informado e retorna o segredo
+func (s *testServer) createKey(t *testing.T, accountID string, role Role) string {
+	t.Helper()
+
+	secret, err := generateAPIKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+	id, err := newID()
+	if err != nil {
+		t.Fatal(err)
+	}
+	key := &store.APIKey{ID: id, AccountID: accountID, Role: string(role), Prefix: secret[:12], Hash: hashAPIKey(secret)}
+	if err := s.store.SaveAPIKey(key); err != nil {
+		t.Fatalf("erro ao gravar chave: %v", err)
+	}
+	return secret
+}
+
+// do envia uma requisição à API com a chave informada; body, se não for nil, é enviado em JSON
+func (s *testServer) do(t *testing.T, method, path, key string, body interface{}) *httptest.ResponseRecorder {
+	t.Helper()
+
+	var reader io.Reader
+	if body != nil {
+		data, err := json.Marshal(body)
+		if err != nil {
+			t.Fatal(err)
+		}
+		reader = bytes.NewReader(data)
+	}
+
+	req := httptest.NewRequest(method, path, reader)
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	if key != "" {
+		req.Header.Set("X-API-Key", key)
+	}
+
+	rec := httptest.NewRecorder()
+	s.router.ServeHTTP(rec, req)
+	return rec
+}
+
+// decode lê a resposta JSON em v
+func decode(t *testing.T, rec *httptest.ResponseRecorder, v interface{}) {
+	t.Helper()
+
+	if err := json.Unmarshal(rec.Body.Bytes(), v); err != nil {
+		t.Fatalf("resposta inválida (%d): %v: %s", rec.Code, err, rec.Body.String())
+	}
+}
+
+// expectError confere o status e o código do envelope de erro
+func expectError(t *testing.T, rec *httptest.ResponseRecorder, status int, code ErrorCode) {
+	t.Helper()
+
+	var resp ErrorResponse
+	decode(t, rec, &resp)
+	if rec.Code != status || resp.Code != code {
+		t.Fatalf("resposta %d %s, esperado %d %s: %s", rec.Code, resp.Code, status, code, rec.Body.String())
+	}
+}
+
+// expectStatus confere apenas o status da resposta
+func expectStatus(t *testing.T, rec *httptest.ResponseRecorder, status int) {
+	t.Helper()
+
+	if rec.Code != status {
+		t.Fatalf("resposta %d, esperado %d: %s", rec.Code, status, rec.Body.String())
+	}
+}
+
+// accountURL monta o caminho de uma rota da conta
+func accountURL(accountID, path string) string {
+	return "/api/accounts/" + accountID + path
+}
@@ -0,0 +1,155 @@
+package api
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"log/slog"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/kodestech/poc-netlify/internal/store"
+)
+
+// Role define o nível de acesso de uma chave de API
+type Role string
+
+const (
+	RoleViewer      Role = "viewer"
+	RoleDeployer    Role = "deployer"
+	RoleDomainAdmin Role = "domain-admin"
+	RoleAdmin       Role = "admin"
+)
+
+// roleGrants lista, para cada papel, os papéis cujas rotas ele pode acessar
+var roleGrants = map[Role][]Role{
+	RoleViewer:      {RoleViewer},
+	RoleDeployer:    {RoleViewer, RoleDeployer},
+	RoleDomainAdmin: {RoleViewer, RoleDomainAdmin},
+	RoleAdmin:       {RoleViewer, RoleDeployer, RoleDomainAdmin, RoleAdmin},
+}
+
+// apiKeyContextKey é a chave da chave de API autenticada no contexto do Gin
+const apiKeyContextKey = "api_key"
+
+// apiKeyPrefix identifica as chaves geradas pela aplicação
+const apiKeyPrefix = "npk_"
+
+// ValidRole indica se o papel informado existe
+func ValidRole(role Role) bool {
+	_, ok := roleGrants[role]
+	return ok
+}
+
+// Allows indica se o papel pode acessar rotas que exigem o papel required
+func (r Role) Allows(required Role) bool {
+	for _, granted := range roleGrants[r] {
+		if granted == required {
+			return true
+		}
+	}
+	return false
+}
+
+// hashAPIKey calcula o hash armazenado de uma chave de API
+func hashAPIKey(secret string) string {
+	sum := sha256.Sum256([]byte(secret))
+	return hex.EncodeToString(sum[:])
+}
+
+// generateAPIKey gera o segredo de uma nova chave de API
+func generateAPIKey() (string, error) {
+	b := make([]byte, 24)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return apiKeyPrefix + hex.EncodeToString(b), nil
+}
+
+// requestAPIKey extrai a chave de API dos cabeçalhos X-API-Key ou Authorization: Bearer
+func requestAPIKey(c *gin.Context) string {
+	if key := c.GetHeader("X-API-Key"); key != "" {
+		return key
+	}
+	if auth := c.GetHeader("Authorization"); strings.HasPrefix(auth, "Bearer ") {
+		return strings.TrimPrefix(auth, "Bearer ")
+	}
+	return ""
+}
+
+// authenticate valida a chave de API da requisição e a disponibiliza para os handlers.
+// A chave ADMIN_API_KEY, quando definida, é aceita como administradora global.
+func (s *Server) authenticate(c *gin.Context) {
+	secret := requestAPIKey(c)
+	if secret == "" {
+		respondErrorCode(c, http.StatusUnauthorized, CodeUnauthorized, "auth.key_missing")
+		return
+	}
+
+	hash := hashAPIKey(secret)
+	if s.config.AdminAPIKey != "" && subtle.ConstantTimeCompare([]byte(hash), []byte(hashAPIKey(s.config.AdminAPIKey))) == 1 {
+		c.Set(apiKeyContextKey, &store.APIKey{ID: "bootstrap", Name: "ADMIN_API_KEY", Role: string(RoleAdmin)})
+		c.Next()
+		return
+	}
+
+	key, ok, err := s.store.GetAPIKeyByHash(hash)
+	if err != nil {
+		slog.ErrorContext(c.Request.Context(), "Erro ao validar chave de API", "error", err)
+		respondErrorCode(c, http.StatusInternalServerError, CodeInternal, "auth.key_check_failed")
+		return
+	}
+	if !ok {
+		slog.WarnContext(c.Request.Context(), "Chave de API inválida", "method", c.Request.Method, "path", c.Request.URL.Path)
+
+		respondErrorCode(c, http.StatusUnauthorized, CodeUnauthorized, "auth.key_invalid")
+		return
+	}
+
+	c.Set(apiKeyContextKey, key)
+	c.Next()
+}
+
+// currentAPIKey retorna a chave de API autenticada na requisição
+func currentAPIKey(c *gin.Context) *store.APIKey {
+	return c.MustGet(apiKeyContextKey).(*store.APIKey)
+}
+
+// requireRole exige que a chave da requisição tenha o papel informado
+func requireRole(required Role) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if !Role(currentAPIKey(c).Role).Allows(required) {
+			respondErrorCode(c, http.StatusForbidden, CodeForbidden, "auth.role_required", required)
+			return
+		}
+		c.Next()
+	}
+}
+
+// isGlobalAdmin indica se a chave é de administrador e não está vinculada a uma conta
+func isGlobalAdmin(key *store.APIKey) bool {
+	return Role(key.Role) == RoleAdmin && key.AccountID == ""
+}
+
+// requireGlobalAdmin exige uma chave de administrador não vinculada a uma conta
+func requireGlobalAdmin(c *gin.Context) {
+	if !isGlobalAdmin(currentAPIKey(c)) {
+		respondErrorCode(c, http.StatusForbidden, CodeForbidden, "auth.global_admin_required")
+		return
+	}
+	c.Next()
+}
+
+// requireAccountAccess exige que a chave pertença à conta da rota.
+// Administradores globais podem operar em qualquer conta.
+func requireAccountAccess(c *gin.Context) {
+	key := currentAPIKey(c)
+	if key.AccountID == c.Param("account") || isGlobalAdmin(key) {
+		c.Next()
+		return
+	}
+
+	respondErrorCode(c, http.StatusForbidden, CodeForbidden, "auth.account_forbidden")
+}
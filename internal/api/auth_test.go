@@ -0,0 +1,142 @@
+package api
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestRoleAllows(t *testing.T) {
+	tests := []struct {
+		role     Role
+		required Role
+		want     bool
+	}{
+		{RoleViewer, RoleViewer, true},
+		{RoleViewer, RoleDeployer, false},
+		{RoleViewer, RoleDomainAdmin, false},
+		{RoleDeployer, RoleViewer, true},
+		{RoleDeployer, RoleDeployer, true},
+		{RoleDeployer, RoleDomainAdmin, false},
+		{RoleDeployer, RoleAdmin, false},
+		{RoleDomainAdmin, RoleViewer, true},
+		{RoleDomainAdmin, RoleDomainAdmin, true},
+		{RoleDomainAdmin, RoleDeployer, false},
+		{RoleDomainAdmin, RoleAdmin, false},
+		{RoleAdmin, RoleDeployer, true},
+		{RoleAdmin, RoleDomainAdmin, true},
+		{RoleAdmin, RoleAdmin, true},
+		{Role("desconhecido"), RoleViewer, false},
+	}
+
+	for _, tt := range tests {
+		if got := tt.role.Allows(tt.required); got != tt.want {
+			t.Errorf("%s.Allows(%s) = %v, esperado %v", tt.role, tt.required, got, tt.want)
+		}
+	}
+}
+
+func TestAPIKeyRequired(t *testing.T) {
+	s := newTestServer(t)
+
+	expectError(t, s.do(t, http.MethodGet, accountURL(testAccountA, "/sites"), "", nil), http.StatusUnauthorized, CodeUnauthorized)
+	expectError(t, s.do(t, http.MethodGet, accountURL(testAccountA, "/sites"), "npk_inexistente", nil), http.StatusUnauthorized, CodeUnauthorized)
+}
+
+func TestBearerAPIKey(t *testing.T) {
+	s := newTestServer(t)
+	key := s.createKey(t, testAccountA, RoleViewer)
+
+	req, _ := http.NewRequest(http.MethodGet, accountURL(testAccountA, "/sites"), nil)
+	req.Header.Set("Authorization", "Bearer "+key)
+	rec := httptest.NewRecorder()
+	s.router.ServeHTTP(rec, req)
+	expectStatus(t, rec, http.StatusOK)
+}
+
+func TestViewerDeniedOnDeployRoutes(t *testing.T) {
+	s := newTestServer(t)
+	key := s.createKey(t, testAccountA, RoleViewer)
+
+	routes := []struct{ method, path string }{
+		{http.MethodPost, "/deploy/site"},
+		{http.MethodPost, "/deploy/s3"},
+		{http.MethodPost, "/batch/deploy"},
+		{http.MethodPost, "/sites/site-1/rollback"},
+		{http.MethodPost, "/sites/site-1/deploys/deploy-1/publish"},
+		{http.MethodPatch, "/sites/site-1"},
+		{http.MethodDelete, "/sites/site-1"},
+		{http.MethodPost, "/domains/add"},
+		{http.MethodPut, ""},
+	}
+	for _, route := range routes {
+		rec := s.do(t, route.method, accountURL(testAccountA, route.path), key, map[string]string{})
+		expectError(t, rec, http.StatusForbidden, CodeForbidden)
+	}
+
+	// As rotas de leitura continuam liberadas
+	expectStatus(t, s.do(t, http.MethodGet, accountURL(testAccountA, "/sites"), key, nil), http.StatusOK)
+	expectStatus(t, s.do(t, http.MethodGet, accountURL(testAccountA, ""), key, nil), http.StatusOK)
+}
+
+func TestDomainAdminScope(t *testing.T) {
+	s := newTestServer(t)
+	key := s.createKey(t, testAccountA, RoleDomainAdmin)
+
+	// Rotas de domínio passam pela autorização e chegam à validação do corpo
+	for _, path := range []string{"/domains/add", "/domains/remove", "/domains/set-default", "/domains/remove-primary"} {
+		rec := s.do(t, http.MethodPost, accountURL(testAccountA, path), key, map[string]string{})
+		expectError(t, rec, http.StatusBadRequest, CodeInvalidRequest)
+	}
+
+	// Rotas de deploy e de administração continuam bloqueadas
+	for _, path := range []string{"/deploy/site", "/batch/deploy", "/sites/site-1/rollback"} {
+		rec := s.do(t, http.MethodPost, accountURL(testAccountA, path), key, map[string]string{})
+		expectError(t, rec, http.StatusForbidden, CodeForbidden)
+	}
+	expectError(t, s.do(t, http.MethodGet, "/api/admin/keys", key, nil), http.StatusForbidden, CodeForbidden)
+	expectError(t, s.do(t, http.MethodGet, "/api/accounts", key, nil), http.StatusForbidden, CodeForbidden)
+}
+
+func TestAccountScopedKey(t *testing.T) {
+	s := newTestServer(t)
+
+	// Nem mesmo uma chave de administrador da conta A opera na conta B
+	for _, role := range []Role{RoleViewer, RoleDeployer, RoleAdmin} {
+		key := s.createKey(t, testAccountA, role)
+
+		expectStatus(t, s.do(t, http.MethodGet, accountURL(testAccountA, "/sites"), key, nil), http.StatusOK)
+		expectError(t, s.do(t, http.MethodGet, accountURL(testAccountB, "/sites"), key, nil), http.StatusForbidden, CodeForbidden)
+		expectError(t, s.do(t, http.MethodGet, accountURL(testAccountB, ""), key, nil), http.StatusForbidden, CodeForbidden)
+	}
+
+	// Administradores de conta não acessam as rotas globais
+	key := s.createKey(t, testAccountA, RoleAdmin)
+	expectError(t, s.do(t, http.MethodGet, "/api/admin/keys", key, nil), http.StatusForbidden, CodeForbidden)
+	expectError(t, s.do(t, http.MethodDelete, accountURL(testAccountA, ""), key, nil), http.StatusForbidden, CodeForbidden)
+}
+
+func TestGlobalAdminAccessesAllAccounts(t *testing.T) {
+	s := newTestServer(t)
+	key := s.createKey(t, "", RoleAdmin)
+
+	for _, account := range []string{testAccountA, testAccountB} {
+		expectStatus(t, s.do(t, http.MethodGet, accountURL(account, "/sites"), key, nil), http.StatusOK)
+	}
+	expectStatus(t, s.do(t, http.MethodGet, "/api/admin/keys", testAdminKey, nil), http.StatusOK)
+}
+
+func TestRevokedAPIKeyRejected(t *testing.T) {
+	s := newTestServer(t)
+
+	rec := s.do(t, http.MethodPost, "/api/admin/keys", testAdminKey, APIKeyRequest{AccountID: testAccountA, Role: RoleDeployer})
+	expectStatus(t, rec, http.StatusCreated)
+	var created APIKeyResponse
+	decode(t, rec, &created)
+
+	expectStatus(t, s.do(t, http.MethodGet, accountURL(testAccountA, "/sites"), created.Secret, nil), http.StatusOK)
+
+	expectStatus(t, s.do(t, http.MethodDelete, "/api/admin/keys/"+created.Key.ID, testAdminKey, nil), http.StatusOK)
+
+	expectError(t, s.do(t, http.MethodGet, accountURL(testAccountA, "/sites"), created.Secret, nil), http.StatusUnauthorized, CodeUnauthorized)
+}
@@ -0,0 +1,133 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"sync"
+
+	"github.com/gin-gonic/gin"
+	"github.com/kodestech/poc-netlify/internal/batch"
+	"github.com/kodestech/poc-netlify/internal/netlify"
+)
+
+// BatchDeployRequest representa uma requisição de deploy em lote de uma conta
+type BatchDeployRequest struct {
+	Account     string `json:"account" example:"elizio" swagger:"description=Pasta da conta dentro de web/accounts (padrão: ID da conta; outra pasta apenas para administradores globais)"`
+	NamePattern string `json:"name_pattern" example:"<account>-<site>" swagger:"description=Padrão do nome dos sites na Netlify (opcional)"`
+	Concurrency int    `json:"concurrency" example:"4" swagger:"description=Quantidade de sites publicados ao mesmo tempo (opcional; no máximo BATCH_CONCURRENCY, que também é o padrão)"`
+}
+
+// handleBatchDeploy enfileira o deploy de todos os sites de uma conta
+// @Summary Publica todos os sites de uma conta
+// @Description Percorre as pastas de sites da conta em web/accounts, cria os sites ausentes na Netlify e publica todos em paralelo. O relatório por site fica disponível no job.
+// @Tags deploy
+// @Accept json
+// @Produce json
+// @Param account path string true "ID da conta"
+// @Param request body BatchDeployRequest false "Pasta da conta e opções do lote"
+// @Success 202 {object} JobResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Failure 503 {object} ErrorResponse
+// @Security ApiKeyAuth
+// @Router /api/accounts/{account}/batch/deploy [post]
+func (s *Server) handleBatchDeploy(c *gin.Context) {
+	var req BatchDeployRequest
+	if err := c.ShouldBindJSON(&req); err != nil && !errors.Is(err, io.EOF) {
+		respondInvalidRequest(c, err, "request.invalid_body")
+		return
+	}
+
+	// A pasta de outra conta seria publicada com o token da Netlify desta conta:
+	// apenas administradores globais podem informá-la
+	if req.Account == "" {
+		req.Account = currentAccount(c).ID
+	} else if req.Account != currentAccount(c).ID && !isGlobalAdmin(currentAPIKey(c)) {
+		respondErrorCode(c, http.StatusForbidden, CodeForbidden, "auth.account_forbidden")
+		return
+	}
+
+	// A concorrência pedida não pode passar do limite configurado em BATCH_CONCURRENCY
+	if req.Concurrency < 0 || req.Concurrency > s.config.BatchConcurrency {
+		err := fmt.Errorf("concurrency deve estar entre 1 e %d, recebido %d", s.config.BatchConcurrency, req.Concurrency)
+		respondInvalidRequest(c, err, "batch.invalid_concurrency", s.config.BatchConcurrency)
+		return
+	}
+
+	opts := batch.Options{
+		AccountsDir: s.config.AccountsPath,
+		Account:     req.Account,
+		NamePattern: req.NamePattern,
+		Concurrency: req.Concurrency,
+	}
+	if opts.NamePattern == "" {
+		opts.NamePattern = s.config.BatchNamePattern
+	}
+	if opts.Concurrency == 0 {
+		opts.Concurrency = s.config.BatchConcurrency
+	}
+
+	// Validar a conta antes de enfileirar o job
+	if _, err := batch.ListSites(opts.AccountsDir, opts.Account); err != nil {
+		respondInvalidRequest(c, err, "batch.invalid_account")
+		return
+	}
+
+	netlifyClient, err := s.newNetlifyClient(s.accountConfig(c))
+	if err != nil {
+		slog.ErrorContext(c.Request.Context(), "Erro ao criar cliente Netlify", "error", err)
+		respondError(c, err, "netlify.client_failed")
+		return
+	}
+
+	job, err := s.jobs.Submit(c.Request.Context(), currentAccount(c).ID, requestLang(c), func(ctx context.Context, job *Job) error {
+		return s.runBatchDeploy(ctx, job, netlifyClient, opts)
+	})
+	if err != nil {
+		respondError(c, err, "batch.enqueue_failed")
+		return
+	}
+
+	slog.InfoContext(c.Request.Context(), "Deploy em lote enfileirado", "batch_account", req.Account, "job_id", job.ID())
+
+	c.JSON(http.StatusAccepted, JobResponse{
+		Success:   true,
+		Message:   translate(c, "batch.enqueued"),
+		JobID:     job.ID(),
+		StatusURL: accountPath(c, "/jobs/"+job.ID()),
+	})
+}
+
+// runBatchDeploy executa o deploy em lote de uma conta, registrando o relatório no job
+func (s *Server) runBatchDeploy(ctx context.Context, job *Job, netlifyClient *netlify.Client, opts batch.Options) error {
+	defer func() { job.SetRetries(netlifyClient.RetryStats()) }()
+
+	job.SetPhase(netlify.PhaseUploading)
+
+	var mu sync.Mutex
+	done := 0
+	opts.OnSiteDone = func(result batch.SiteResult) {
+		mu.Lock()
+		defer mu.Unlock()
+		done++
+		job.SetMessage(job.translate("batch.progress", done))
+	}
+
+	report, err := batch.DeployAccount(ctx, netlifyClient, opts)
+	if err != nil {
+		return err
+	}
+	job.SetResult(report)
+
+	if report.Succeeded == 0 {
+		return fmt.Errorf("%w: conta %s", batch.ErrNothingPublished, opts.Account)
+	}
+
+	job.Succeed(job.translate("batch.done", report.Succeeded, report.Total))
+	return nil
+}
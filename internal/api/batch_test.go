@@ -0,0 +1,101 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/kodestech/poc-netlify/internal/batch"
+)
+
+// writeSites cria as pastas de sites da conta em ACCOUNTS_PATH, cada uma com um index.html
+func (s *testServer) writeSites(t *testing.T, accountID string, sites ...string) {
+	t.Helper()
+
+	if err := os.MkdirAll(filepath.Join(s.config.AccountsPath, accountID), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	for _, site := range sites {
+		dir := filepath.Join(s.config.AccountsPath, accountID, site)
+		if err := os.MkdirAll(dir, 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(filepath.Join(dir, "index.html"), []byte("<h1>"+site+"</h1>"), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+// batchReport converte o resultado do job no relatório do lote
+func batchReport(t *testing.T, status JobStatus) batch.Report {
+	t.Helper()
+
+	data, err := json.Marshal(status.Result)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var report batch.Report
+	if err := json.Unmarshal(data, &report); err != nil {
+		t.Fatalf("resultado do job inválido: %v: %s", err, data)
+	}
+	return report
+}
+
+func TestBatchDeploy(t *testing.T) {
+	s := newTestServer(t)
+	key := s.createKey(t, testAccountA, RoleDeployer)
+	s.writeSites(t, testAccountA, "bolo", "torta")
+
+	rec := s.do(t, http.MethodPost, accountURL(testAccountA, "/batch/deploy"), key, BatchDeployRequest{})
+	status := s.waitJobResponse(t, testAccountA, key, rec)
+	if status.Phase != JobReady {
+		t.Fatalf("lote terminou como %+v", status)
+	}
+
+	report := batchReport(t, status)
+	if report.Account != testAccountA || report.Total != 2 || report.Succeeded != 2 || report.Failed != 0 {
+		t.Fatalf("relatório do lote: %+v", report)
+	}
+	for _, site := range report.Sites {
+		if want := testAccountA + "-" + site.Folder; site.SiteName != want || !site.Success || site.DeployID == "" {
+			t.Fatalf("site %s publicado como %+v, esperado o nome %s", site.Folder, site, want)
+		}
+		if deploy, ok := s.netlify.Deploy(site.DeployID); !ok || deploy.State != "ready" {
+			t.Fatalf("deploy %s do site %s: %+v", site.DeployID, site.Folder, deploy)
+		}
+	}
+}
+
+func TestBatchDeployNoSites(t *testing.T) {
+	s := newTestServer(t)
+	key := s.createKey(t, testAccountA, RoleDeployer)
+	s.writeSites(t, testAccountA)
+
+	rec := s.do(t, http.MethodPost, accountURL(testAccountA, "/batch/deploy"), key, nil)
+	status := s.waitJobResponse(t, testAccountA, key, rec)
+	if status.Phase != JobError || status.ErrorCode != CodeNoSites {
+		t.Fatalf("lote sem sites terminou como %+v", status)
+	}
+}
+
+func TestBatchDeployValidation(t *testing.T) {
+	s := newTestServer(t)
+	key := s.createKey(t, testAccountA, RoleDeployer)
+	s.writeSites(t, testAccountA, "bolo")
+	s.writeSites(t, testAccountB, "torta")
+
+	// Concorrência acima de BATCH_CONCURRENCY
+	rec := s.do(t, http.MethodPost, accountURL(testAccountA, "/batch/deploy"), key, BatchDeployRequest{Concurrency: s.config.BatchConcurrency + 1})
+	expectError(t, rec, http.StatusBadRequest, CodeInvalidRequest)
+
+	// Pasta de outra conta, apenas para administradores globais
+	rec = s.do(t, http.MethodPost, accountURL(testAccountA, "/batch/deploy"), key, BatchDeployRequest{Account: testAccountB})
+	expectError(t, rec, http.StatusForbidden, CodeForbidden)
+
+	// Pasta de conta inexistente
+	admin := s.createKey(t, "", RoleAdmin)
+	rec = s.do(t, http.MethodPost, accountURL(testAccountA, "/batch/deploy"), admin, BatchDeployRequest{Account: "inexistente"})
+	expectError(t, rec, http.StatusBadRequest, CodeInvalidRequest)
+}
@@ -0,0 +1,245 @@
+package api
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/kodestech/poc-netlify/internal/netlify"
+	"github.com/kodestech/poc-netlify/internal/store"
+)
+
+// maxDeploysPerPage limita a quantidade de deploys retornados por página
+const maxDeploysPerPage = 100
+
+// DeployHistoryResponse representa uma página do histórico de deploys de um site
+type DeployHistoryResponse struct {
+	Success bool                 `json:"success" example:"true" swagger:"description=Indica se a operação foi bem-sucedida"`
+	SiteID  string               `json:"site_id" example:"a1b2c3d4" swagger:"description=ID do site na Netlify"`
+	Page    int                  `json:"page" example:"1" swagger:"description=Página retornada"`
+	PerPage int                  `json:"per_page" example:"20" swagger:"description=Itens por página"`
+	Total   int                  `json:"total" example:"57" swagger:"description=Total de deploys que atendem ao filtro"`
+	Deploys []store.DeployRecord `json:"deploys" swagger:"description=Deploys da página"`
+}
+
+// handleListSiteDeploys lista o histórico de deploys de um site
+// @Summary Lista o histórico de deploys de um site
+// @Description Retorna os deploys registrados para o site, do mais recente para o mais antigo, com paginação e filtro por estado
+// @Tags deploy
+// @Produce json
+// @Param account path string true "ID da conta"
+// @Param id path string true "ID do site na Netlify"
+// @Param state query string false "Filtrar pelo estado do deploy (ex: ready, error)"
+// @Param page query int false "Página (padrão 1)"
+// @Param per_page query int false "Itens por página (padrão 20, máximo 100)"
+// @Success 200 {object} DeployHistoryResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Security ApiKeyAuth
+// @Router /api/accounts/{account}/sites/{id}/deploys [get]
+func (s *Server) handleListSiteDeploys(c *gin.Context) {
+	siteID := c.Param("id")
+	ctx := logWith(c, "site_id", siteID)
+
+	page, err := strconv.Atoi(c.DefaultQuery("page", "1"))
+	if err != nil || page < 1 {
+		respondInvalidRequest(c, nil, "request.invalid_page")
+		return
+	}
+
+	perPage, err := strconv.Atoi(c.DefaultQuery("per_page", "20"))
+	if err != nil || perPage < 1 || perPage > maxDeploysPerPage {
+		respondInvalidRequest(c, nil, "request.invalid_per_page", maxDeploysPerPage)
+		return
+	}
+
+	// O histórico é restrito aos deploys feitos pela conta da requisição
+	deploys, total, err := s.store.ListSiteDeploys(siteID, store.DeployFilter{
+		AccountID: currentAccount(c).ID,
+		State:     c.Query("state"),
+		Page:      page,
+		PerPage:   perPage,
+	})
+	if err != nil {
+		slog.ErrorContext(ctx, "Erro ao listar histórico de deploys", "error", err)
+		respondError(c, err, "deploy.history_failed")
+		return
+	}
+
+	c.JSON(http.StatusOK, DeployHistoryResponse{
+		Success: true,
+		SiteID:  siteID,
+		Page:    page,
+		PerPage: perPage,
+		Total:   total,
+		Deploys: deploys,
+	})
+}
+
+// rollbackTimeout limita o tempo de espera pela publicação do deploy restaurado
+const rollbackTimeout = 5 * time.Minute
+
+// RollbackRequest representa uma requisição de rollback de um site
+type RollbackRequest struct {
+	DeployID string `json:"deploy_id" binding:"required" example:"previous" swagger:"description=ID do deploy a restaurar ou \"previous\" para o deploy publicado antes do atual"`
+}
+
+// RollbackResponse representa a resposta de um rollback
+type RollbackResponse struct {
+	Success   bool   `json:"success" example:"true" swagger:"description=Indica se o rollback foi concluído"`
+	Message   string `json:"message" example:"Rollback concluído com sucesso" swagger:"description=Mensagem descritiva sobre o resultado da operação"`
+	SiteID    string `json:"site_id,omitempty" example:"a1b2c3d4" swagger:"description=ID do site na Netlify"`
+	DeployID  string `json:"deploy_id,omitempty" example:"5f1b2c3d4e5f6a7b8c9d0e1f" swagger:"description=ID do deploy publicado"`
+	DeployURL string `json:"deploy_url,omitempty" example:"https://test-site.netlify.app" swagger:"description=URL do site após o rollback"`
+}
+
+// handleRollbackSite restaura um deploy anterior de um site
+// @Summary Restaura um deploy anterior de um site
+// @Description Publica novamente um deploy pronto do site, informado pelo ID ou como "previous" para o deploy publicado antes do atual, e aguarda a publicação
+// @Tags deploy
+// @Accept json
+// @Produce json
+// @Param account path string true "ID da conta"
+// @Param id path string true "ID do site na Netlify"
+// @Param request body RollbackRequest true "Deploy a ser restaurado"
+// @Success 200 {object} RollbackResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Security ApiKeyAuth
+// @Router /api/accounts/{account}/sites/{id}/rollback [post]
+func (s *Server) handleRollbackSite(c *gin.Context) {
+	siteID := c.Param("id")
+	logWith(c, "site_id", siteID)
+
+	var req RollbackRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondInvalidRequest(c, err, "request.invalid_body")
+		return
+	}
+
+	slog.InfoContext(c.Request.Context(), "Rollback solicitado", "target_deploy", req.DeployID)
+
+	ctx, cancel := context.WithTimeout(c.Request.Context(), rollbackTimeout)
+	defer cancel()
+
+	netlifyClient, err := s.newNetlifyClient(s.accountConfig(c))
+	if err != nil {
+		slog.ErrorContext(ctx, "Erro ao criar cliente Netlify", "error", err)
+		respondError(c, err, "netlify.client_failed")
+		return
+	}
+
+	deploy, err := netlifyClient.RollbackDeploy(ctx, siteID, req.DeployID)
+	if err != nil {
+		slog.ErrorContext(ctx, "Erro no rollback", "error", err)
+
+		respondError(c, err, "deploy.rollback_failed")
+		return
+	}
+
+	deployURL := deploy.SslURL
+	if deployURL == "" {
+		deployURL = deploy.URL
+	}
+
+	c.JSON(http.StatusOK, RollbackResponse{
+		Success:   true,
+		Message:   translate(c, "deploy.rollback_done"),
+		SiteID:    siteID,
+		DeployID:  deploy.ID,
+		DeployURL: deployURL,
+	})
+}
+
+// PublishResponse representa a resposta da publicação de um rascunho
+type PublishResponse struct {
+	Success   bool   `json:"success" example:"true" swagger:"description=Indica se o rascunho foi publicado"`
+	Message   string `json:"message" example:"Rascunho publicado em produção com sucesso" swagger:"description=Mensagem descritiva sobre o resultado da operação"`
+	SiteID    string `json:"site_id,omitempty" example:"a1b2c3d4" swagger:"description=ID do site na Netlify"`
+	DeployID  string `json:"deploy_id,omitempty" example:"5f1b2c3d4e5f6a7b8c9d0e1f" swagger:"description=ID do deploy publicado"`
+	DeployURL string `json:"deploy_url,omitempty" example:"https://test-site.netlify.app" swagger:"description=URL de produção do site"`
+}
+
+// handlePublishDeploy publica em produção um deploy em rascunho
+// @Summary Publica um rascunho em produção
+// @Description Promove a produção um deploy criado com draft=true, depois de revisado na URL de pré-visualização, e aguarda a publicação
+// @Tags deploy
+// @Produce json
+// @Param account path string true "ID da conta"
+// @Param id path string true "ID do site na Netlify"
+// @Param deployID path string true "ID do deploy em rascunho"
+// @Success 200 {object} PublishResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Security ApiKeyAuth
+// @Router /api/accounts/{account}/sites/{id}/deploys/{deployID}/publish [post]
+func (s *Server) handlePublishDeploy(c *gin.Context) {
+	siteID, deployID := c.Param("id"), c.Param("deployID")
+	logWith(c, "site_id", siteID, "deploy_id", deployID)
+
+	slog.InfoContext(c.Request.Context(), "Publicação de rascunho solicitada")
+
+	ctx, cancel := context.WithTimeout(c.Request.Context(), rollbackTimeout)
+	defer cancel()
+
+	netlifyClient, err := s.newNetlifyClient(s.accountConfig(c))
+	if err != nil {
+		slog.ErrorContext(ctx, "Erro ao criar cliente Netlify", "error", err)
+		respondError(c, err, "netlify.client_failed")
+		return
+	}
+
+	deploy, err := netlifyClient.PublishDeploy(ctx, siteID, deployID)
+	if err != nil {
+		slog.ErrorContext(ctx, "Erro ao publicar rascunho", "error", err)
+		respondError(c, err, "deploy.publish_failed")
+		return
+	}
+
+	deployURL := deploy.SslURL
+	if deployURL == "" {
+		deployURL = deploy.URL
+	}
+
+	c.JSON(http.StatusOK, PublishResponse{
+		Success:   true,
+		Message:   translate(c, "deploy.publish_done"),
+		SiteID:    siteID,
+		DeployID:  deploy.ID,
+		DeployURL: deployURL,
+	})
+}
+
+// dryRunTimeout limita o tempo de montagem do manifesto e de comparação de um dry-run
+const dryRunTimeout = 5 * time.Minute
+
+// DryRunResponse representa o resultado de um deploy em modo dry-run
+type DryRunResponse struct {
+	Success bool                `json:"success" example:"true" swagger:"description=Indica se a comparação foi concluída"`
+	Message string              `json:"message" example:"Dry-run concluído: 2 adicionados, 1 modificados, 0 removidos" swagger:"description=Resumo das alterações"`
+	Diff    *netlify.DeployDiff `json:"diff" swagger:"description=Arquivos adicionados, modificados e removidos em relação ao deploy publicado"`
+}
+
+// respondDryRun compara o manifesto da origem com o deploy publicado do site e responde com as
+// alterações, sem criar o deploy
+func respondDryRun(ctx context.Context, c *gin.Context, netlifyClient *netlify.Client, siteID string, files []netlify.RemoteFile) {
+	diff, err := netlifyClient.DiffDeploy(ctx, siteID, files)
+	if err != nil {
+		slog.ErrorContext(ctx, "Erro ao comparar com o deploy publicado", "error", err)
+		respondError(c, err, "deploy.dry_run_failed")
+		return
+	}
+
+	c.JSON(http.StatusOK, DryRunResponse{
+		Success: true,
+		Message: translate(c, "deploy.dry_run_done", len(diff.Added), len(diff.Modified), len(diff.Removed)),
+		Diff:    diff,
+	})
+}
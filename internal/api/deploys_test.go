@@ -0,0 +1,147 @@
+package api
+
+import (
+	"bytes"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+// postForm envia um formulário multipart à API, como a interface web faz no deploy
+func (s *testServer) postForm(t *testing.T, path, key string, fields map[string]string) *httptest.ResponseRecorder {
+	t.Helper()
+
+	var body bytes.Buffer
+	w := multipart.NewWriter(&body)
+	for name, value := range fields {
+		if err := w.WriteField(name, value); err != nil {
+			t.Fatal(err)
+		}
+	}
+	w.Close()
+
+	req := httptest.NewRequest(http.MethodPost, path, &body)
+	req.Header.Set("Content-Type", w.FormDataContentType())
+	req.Header.Set("X-API-Key", key)
+
+	rec := httptest.NewRecorder()
+	s.router.ServeHTTP(rec, req)
+	return rec
+}
+
+// waitJobResponse acompanha o job pela rota /jobs/:id até o fim e retorna o estado final
+func (s *testServer) waitJobResponse(t *testing.T, accountID, key string, rec *httptest.ResponseRecorder) JobStatus {
+	t.Helper()
+
+	expectStatus(t, rec, http.StatusAccepted)
+	var enqueued JobResponse
+	decode(t, rec, &enqueued)
+	if enqueued.JobID == "" || enqueued.StatusURL != accountURL(accountID, "/jobs/"+enqueued.JobID) {
+		t.Fatalf("resposta de job inválida: %+v", enqueued)
+	}
+
+	deadline := time.Now().Add(10 * time.Second)
+	for time.Now().Before(deadline) {
+		rec := s.do(t, http.MethodGet, enqueued.StatusURL, key, nil)
+		expectStatus(t, rec, http.StatusOK)
+
+		var status JobStatus
+		decode(t, rec, &status)
+		if status.FinishedAt != nil {
+			return status
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	t.Fatalf("job %s não terminou", enqueued.JobID)
+	return JobStatus{}
+}
+
+// deploySite publica conteúdo HTML pela rota de deploy e aguarda o job
+func (s *testServer) deploySite(t *testing.T, key string, fields map[string]string) JobStatus {
+	t.Helper()
+
+	rec := s.postForm(t, accountURL(testAccountA, "/deploy/site"), key, fields)
+	return s.waitJobResponse(t, testAccountA, key, rec)
+}
+
+func TestDeployJob(t *testing.T) {
+	s := newTestServer(t)
+	key := s.createKey(t, testAccountA, RoleDeployer)
+
+	status := s.deploySite(t, key, map[string]string{"site_name": "meu-site", "test_content": "<h1>Olá</h1>"})
+	if status.Phase != JobReady || status.SiteID == "" || status.DeployID == "" || status.DeployURL == "" {
+		t.Fatalf("job terminou como %+v", status)
+	}
+	if status.AccountID != testAccountA || status.FilesTotal == 0 || status.FilesUploaded != status.FilesTotal {
+		t.Fatalf("andamento do job inválido: %+v", status)
+	}
+
+	site, ok := s.netlify.Site(status.SiteID)
+	if !ok || site.PublishedDeploy == nil || site.PublishedDeploy.ID != status.DeployID {
+		t.Fatalf("deploy %s não publicado no site %+v", status.DeployID, site)
+	}
+	files := s.netlify.Files(status.DeployID)
+	if len(files) == 0 {
+		t.Fatal("nenhum arquivo enviado para a Netlify")
+	}
+
+	// O job é visível apenas na conta que o criou
+	other := s.createKey(t, testAccountB, RoleViewer)
+	expectError(t, s.do(t, http.MethodGet, accountURL(testAccountB, "/jobs/"+status.ID), other, nil), http.StatusNotFound, CodeJobNotFound)
+}
+
+func TestDeployJobSiteNotFound(t *testing.T) {
+	s := newTestServer(t)
+	key := s.createKey(t, testAccountA, RoleDeployer)
+
+	status := s.deploySite(t, key, map[string]string{"site_id": "inexistente", "site_name": "meu-site", "test_content": "<h1>Olá</h1>"})
+	if status.Phase != JobError || status.ErrorCode != CodeSiteNotFound || status.ErrorDetail == "" {
+		t.Fatalf("job terminou como %+v", status)
+	}
+}
+
+func TestDeployRequiresSiteName(t *testing.T) {
+	s := newTestServer(t)
+	key := s.createKey(t, testAccountA, RoleDeployer)
+
+	expectError(t, s.postForm(t, accountURL(testAccountA, "/deploy/site"), key, map[string]string{"test_content": "<h1>Olá</h1>"}), http.StatusBadRequest, CodeInvalidRequest)
+}
+
+func TestRollbackSite(t *testing.T) {
+	s := newTestServer(t)
+	key := s.createKey(t, testAccountA, RoleDeployer)
+
+	first := s.deploySite(t, key, map[string]string{"site_name": "meu-site", "test_content": "<h1>Versão 1</h1>"})
+	second := s.deploySite(t, key, map[string]string{"site_id": first.SiteID, "site_name": "meu-site", "test_content": "<h1>Versão 2</h1>"})
+	if first.Phase != JobReady || second.Phase != JobReady || first.DeployID == second.DeployID {
+		t.Fatalf("deploys iniciais inválidos: %+v / %+v", first, second)
+	}
+
+	rec := s.do(t, http.MethodPost, accountURL(testAccountA, "/sites/"+first.SiteID+"/rollback"), key, RollbackRequest{DeployID: "previous"})
+	expectStatus(t, rec, http.StatusOK)
+	var resp RollbackResponse
+	decode(t, rec, &resp)
+	if !resp.Success || resp.DeployID != first.DeployID || resp.SiteID != first.SiteID {
+		t.Fatalf("rollback retornou %+v, esperado o deploy %s", resp, first.DeployID)
+	}
+
+	site, _ := s.netlify.Site(first.SiteID)
+	if site.PublishedDeploy == nil || site.PublishedDeploy.ID != first.DeployID {
+		t.Fatalf("deploy publicado após o rollback: %+v", site.PublishedDeploy)
+	}
+}
+
+func TestRollbackErrors(t *testing.T) {
+	s := newTestServer(t)
+	key := s.createKey(t, testAccountA, RoleDeployer)
+
+	expectError(t, s.do(t, http.MethodPost, accountURL(testAccountA, "/sites/site-1/rollback"), key, map[string]string{}), http.StatusBadRequest, CodeInvalidRequest)
+	expectError(t, s.do(t, http.MethodPost, accountURL(testAccountA, "/sites/inexistente/rollback"), key, RollbackRequest{DeployID: "previous"}), http.StatusNotFound, CodeSiteNotFound)
+
+	// Com um único deploy não há versão anterior para restaurar
+	only := s.deploySite(t, key, map[string]string{"site_name": "meu-site", "test_content": "<h1>Olá</h1>"})
+	expectError(t, s.do(t, http.MethodPost, accountURL(testAccountA, "/sites/"+only.SiteID+"/rollback"), key, RollbackRequest{DeployID: "previous"}), http.StatusNotFound, CodeNoPreviousDeploy)
+	expectError(t, s.do(t, http.MethodPost, accountURL(testAccountA, "/sites/"+only.SiteID+"/rollback"), key, RollbackRequest{DeployID: "inexistente"}), http.StatusNotFound, CodeDeployNotFound)
+}
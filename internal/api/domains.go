@@ -0,0 +1,106 @@
+package api
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/kodestech/poc-netlify/internal/netlify"
+	"github.com/netlify/open-api/go/models"
+)
+
+// domainStatusTimeout limita o tempo das consultas DNS e à Netlify na verificação dos domínios
+const domainStatusTimeout = 30 * time.Second
+
+// DomainsStatusResponse representa o estado dos domínios personalizados de um site
+type DomainsStatusResponse struct {
+	Success bool                       `json:"success" example:"true" swagger:"description=Indica se a operação foi bem-sucedida"`
+	Message string                     `json:"message,omitempty" swagger:"description=Mensagem descritiva sobre o resultado da operação"`
+	Status  *netlify.SiteDomainsStatus `json:"status,omitempty" swagger:"description=Estado dos domínios e do certificado do site"`
+}
+
+// SSLProvisionResponse representa a resposta da solicitação de certificado TLS
+type SSLProvisionResponse struct {
+	Success     bool                   `json:"success" example:"true" swagger:"description=Indica se a operação foi bem-sucedida"`
+	Message     string                 `json:"message" example:"Certificado solicitado com sucesso" swagger:"description=Mensagem descritiva sobre o resultado da operação"`
+	SiteID      string                 `json:"site_id,omitempty" example:"a1b2c3d4" swagger:"description=ID do site na Netlify"`
+	Certificate *models.SniCertificate `json:"certificate,omitempty" swaggertype:"object" swagger:"description=Certificado retornado pela Netlify"`
+}
+
+// handleDomainsStatus verifica o estado dos domínios personalizados de um site
+// @Summary Estado dos domínios de um site
+// @Description Consulta o DNS (CNAME/A e TXT de verificação) e o certificado TLS de cada domínio do site, retornando a etapa de cada um (pending_dns, verified, cert_provisioning, live)
+// @Tags domains
+// @Produce json
+// @Param account path string true "ID da conta"
+// @Param id path string true "ID do site na Netlify"
+// @Success 200 {object} DomainsStatusResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Security ApiKeyAuth
+// @Router /api/accounts/{account}/sites/{id}/domains/status [get]
+func (s *Server) handleDomainsStatus(c *gin.Context) {
+	siteID := c.Param("id")
+	logWith(c, "site_id", siteID)
+
+	netlifyClient, err := s.newNetlifyClient(s.accountConfig(c))
+	if err != nil {
+		respondError(c, err, "netlify.client_failed")
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(c.Request.Context(), domainStatusTimeout)
+	defer cancel()
+
+	status, err := netlifyClient.DomainsStatus(ctx, siteID, s.resolver)
+	if err != nil {
+		slog.ErrorContext(ctx, "Erro ao verificar domínios", "error", err)
+		respondError(c, err, "domain.status_failed")
+		return
+	}
+
+	c.JSON(http.StatusOK, DomainsStatusResponse{
+		Success: true,
+		Status:  status,
+	})
+}
+
+// handleProvisionSSL solicita o certificado TLS de um site
+// @Summary Solicita o certificado TLS de um site
+// @Description Solicita à Netlify a emissão do certificado Let's Encrypt para os domínios do site. Acompanhe a emissão pelo estado dos domínios.
+// @Tags domains
+// @Produce json
+// @Param account path string true "ID da conta"
+// @Param id path string true "ID do site na Netlify"
+// @Success 200 {object} SSLProvisionResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Security ApiKeyAuth
+// @Router /api/accounts/{account}/sites/{id}/ssl/provision [post]
+func (s *Server) handleProvisionSSL(c *gin.Context) {
+	siteID := c.Param("id")
+	ctx := logWith(c, "site_id", siteID)
+
+	netlifyClient, err := s.newNetlifyClient(s.accountConfig(c))
+	if err != nil {
+		respondError(c, err, "netlify.client_failed")
+		return
+	}
+
+	cert, err := netlifyClient.ProvisionCertificate(ctx, siteID)
+	if err != nil {
+		slog.ErrorContext(ctx, "Erro ao solicitar certificado", "error", err)
+
+		respondError(c, err, "ssl.provision_failed")
+		return
+	}
+
+	c.JSON(http.StatusOK, SSLProvisionResponse{
+		Success:     true,
+		Message:     translate(c, "ssl.requested"),
+		SiteID:      siteID,
+		Certificate: cert,
+	})
+}
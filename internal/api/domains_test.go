@@ -0,0 +1,65 @@
+package api
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/kodestech/poc-netlify/internal/dns"
+	"github.com/kodestech/poc-netlify/internal/netlify"
+)
+
+func TestDomainLifecycle(t *testing.T) {
+	s := newTestServer(t)
+	key := s.createKey(t, testAccountA, RoleDomainAdmin)
+	site := s.netlify.AddSite("meu-site")
+	const domain = "www.exemplo.com"
+
+	// Sem domínio principal, o domínio adicionado vira o principal e recebe os registros DNS
+	rec := s.do(t, http.MethodPost, accountURL(testAccountA, "/domains/add"), key, DomainRequest{SiteID: site.ID, Domain: domain})
+	expectStatus(t, rec, http.StatusOK)
+	var added DomainResponse
+	decode(t, rec, &added)
+	if !added.Success || added.SiteID != site.ID || added.Domain != domain {
+		t.Fatalf("resposta da adição: %+v", added)
+	}
+	if got, _ := s.netlify.Site(site.ID); got.CustomDomain != domain {
+		t.Fatalf("domínio principal do site: %q, esperado %q", got.CustomDomain, domain)
+	}
+	provider := s.dns.(*dns.MemoryProvider)
+	if _, ok := provider.Lookup(domain, dns.TypeCNAME); !ok {
+		t.Fatalf("registro CNAME não criado: %+v", provider.Records())
+	}
+
+	// Um segundo domínio não substitui o principal por esta rota
+	expectError(t, s.do(t, http.MethodPost, accountURL(testAccountA, "/domains/add"), key, DomainRequest{SiteID: site.ID, Domain: "outro.exemplo.com"}), http.StatusConflict, CodePrimaryDomainSet)
+
+	// Com o certificado emitido, o domínio que aponta para a Netlify fica no ar
+	rec = s.do(t, http.MethodPost, accountURL(testAccountA, "/sites/"+site.ID+"/ssl/provision"), key, nil)
+	expectStatus(t, rec, http.StatusOK)
+
+	rec = s.do(t, http.MethodGet, accountURL(testAccountA, "/sites/"+site.ID+"/domains/status"), key, nil)
+	expectStatus(t, rec, http.StatusOK)
+	var status DomainsStatusResponse
+	decode(t, rec, &status)
+	if status.Status == nil || len(status.Status.Domains) != 1 {
+		t.Fatalf("estado dos domínios: %s", rec.Body.String())
+	}
+	if d := status.Status.Domains[0]; d.Domain != domain || !d.Primary || !d.PointsToNetlify || d.State != netlify.DomainLive {
+		t.Fatalf("estado do domínio: %+v", d)
+	}
+
+	// A remoção do domínio principal apaga os registros DNS criados na adição
+	expectStatus(t, s.do(t, http.MethodPost, accountURL(testAccountA, "/domains/remove-primary"), key, DomainRequest{SiteID: site.ID}), http.StatusOK)
+	if _, ok := provider.Lookup(domain, dns.TypeCNAME); ok {
+		t.Fatalf("registro CNAME não removido: %+v", provider.Records())
+	}
+}
+
+func TestDomainErrors(t *testing.T) {
+	s := newTestServer(t)
+	key := s.createKey(t, testAccountA, RoleDomainAdmin)
+
+	expectError(t, s.do(t, http.MethodPost, accountURL(testAccountA, "/domains/add"), key, DomainRequest{SiteID: "site-1"}), http.StatusBadRequest, CodeInvalidRequest)
+	expectError(t, s.do(t, http.MethodPost, accountURL(testAccountA, "/domains/add"), key, DomainRequest{SiteID: "inexistente", Domain: "www.exemplo.com"}), http.StatusNotFound, CodeSiteNotFound)
+	expectError(t, s.do(t, http.MethodGet, accountURL(testAccountA, "/sites/inexistente/domains/status"), key, nil), http.StatusNotFound, CodeSiteNotFound)
+}
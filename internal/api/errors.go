@@ -0,0 +1,197 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"regexp"
+
+	"github.com/gin-gonic/gin"
+	"github.com/kodestech/poc-netlify/internal/archive"
+	"github.com/kodestech/poc-netlify/internal/batch"
+	"github.com/kodestech/poc-netlify/internal/i18n"
+	"github.com/kodestech/poc-netlify/internal/netlify"
+	"github.com/kodestech/poc-netlify/internal/store"
+	"github.com/kodestech/poc-netlify/internal/tracing"
+)
+
+// ErrorCode identifica o tipo de erro nas respostas da API, para tratamento automático pelos clientes
+type ErrorCode string
+
+const (
+	CodeInvalidRequest      ErrorCode = "invalid_request"
+	CodeUnauthorized        ErrorCode = "unauthorized"
+	CodeForbidden           ErrorCode = "forbidden"
+	CodeNotFound            ErrorCode = "not_found"
+	CodeAccountNotFound     ErrorCode = "account_not_found"
+	CodeAccountExists       ErrorCode = "account_exists"
+	CodeJobNotFound         ErrorCode = "job_not_found"
+	CodeSiteNotFound        ErrorCode = "site_not_found"
+	CodeDeployNotFound      ErrorCode = "deploy_not_found"
+	CodeDomainExists        ErrorCode = "domain_exists"
+	CodeDomainNotFound      ErrorCode = "domain_not_found"
+	CodePrimaryDomainSet    ErrorCode = "primary_domain_set"
+	CodeDeployNotReady      ErrorCode = "deploy_not_ready"
+	CodeNoPreviousDeploy    ErrorCode = "no_previous_deploy"
+	CodeDeployNotDraft      ErrorCode = "deploy_not_draft"
+	CodeDeployFailed        ErrorCode = "deploy_failed"
+	CodeDeployRejected      ErrorCode = "deploy_rejected"
+	CodeDeployTimeout       ErrorCode = "deploy_timeout"
+	CodeRateLimited         ErrorCode = "rate_limited"
+	CodeNetlifyUnavailable  ErrorCode = "netlify_unavailable"
+	CodeNetlifyUnauthorized ErrorCode = "netlify_unauthorized"
+	CodeNetlifyRejected     ErrorCode = "netlify_rejected"
+	CodeInvalidArchive      ErrorCode = "invalid_archive"
+	CodeArchiveTooLarge     ErrorCode = "archive_too_large"
+	CodeUploadTooLarge      ErrorCode = "upload_too_large"
+	CodeNoSites             ErrorCode = "no_sites"
+	CodeBatchFailed         ErrorCode = "batch_failed"
+	CodeQueueFull           ErrorCode = "queue_full"
+	CodeTimeout             ErrorCode = "timeout"
+	CodeInternal            ErrorCode = "internal_error"
+)
+
+// requestIDHeader é o cabeçalho que carrega o ID da requisição, recebido do cliente ou gerado pela API
+const requestIDHeader = "X-Request-ID"
+
+// requestIDContextKey é a chave do ID da requisição no contexto do Gin
+const requestIDContextKey = "request_id"
+
+// validRequestID limita os IDs de requisição aceitos do cliente, evitando valores arbitrários nos logs
+var validRequestID = regexp.MustCompile(`^[A-Za-z0-9._-]{1,128}$`)
+
+// ErrorResponse é o envelope retornado por todas as rotas da API em caso de erro
+type ErrorResponse struct {
+	Success   bool      `json:"success" example:"false" swagger:"description=Sempre false em respostas de erro"`
+	Code      ErrorCode `json:"code" example:"site_not_found" swagger:"description=Código do erro, estável para tratamento automático"`
+	Message   string    `json:"message" example:"Erro ao obter site: site não encontrado" swagger:"description=Mensagem descritiva do erro, no idioma da requisição"`
+	Detail    string    `json:"detail,omitempty" example:"site não encontrado: a1b2c3d4" swagger:"description=Erro original, sem tradução, para diagnóstico (vazio nos erros internal_error)"`
+	RequestID string    `json:"request_id,omitempty" example:"3f2a9c1d7e8b4a60" swagger:"description=ID da requisição, também enviado no cabeçalho X-Request-ID"`
+}
+
+// errInvalidRequest indica um corpo ou parâmetro de requisição inválido
+var errInvalidRequest = errors.New("requisição inválida")
+
+// errorMapping associa um erro sentinela ao status HTTP e ao código retornados pela API
+type errorMapping struct {
+	err    error
+	status int
+	code   ErrorCode
+}
+
+// errorMappings é a tabela única de conversão de erros em respostas HTTP. A ordem importa:
+// o primeiro erro encontrado com errors.Is define a resposta.
+var errorMappings = []errorMapping{
+	{errInvalidRequest, http.StatusBadRequest, CodeInvalidRequest},
+	{netlify.ErrInvalidInput, http.StatusBadRequest, CodeInvalidRequest},
+	{store.ErrAccountNotFound, http.StatusNotFound, CodeAccountNotFound},
+	{netlify.ErrSiteNotFound, http.StatusNotFound, CodeSiteNotFound},
+	{netlify.ErrDeployNotFound, http.StatusNotFound, CodeDeployNotFound},
+	{netlify.ErrDomainNotFound, http.StatusNotFound, CodeDomainNotFound},
+	{netlify.ErrNoPreviousDeploy, http.StatusNotFound, CodeNoPreviousDeploy},
+	{netlify.ErrDomainExists, http.StatusConflict, CodeDomainExists},
+	{netlify.ErrPrimaryDomainSet, http.StatusConflict, CodePrimaryDomainSet},
+	{netlify.ErrDeployNotReady, http.StatusConflict, CodeDeployNotReady},
+	{netlify.ErrDeployNotDraft, http.StatusConflict, CodeDeployNotDraft},
+	{netlify.ErrDeployRejected, http.StatusUnprocessableEntity, CodeDeployRejected},
+	{netlify.ErrDeployFailed, http.StatusBadGateway, CodeDeployFailed},
+	{netlify.ErrDeployTimeout, http.StatusGatewayTimeout, CodeDeployTimeout},
+	{netlify.ErrRateLimited, http.StatusTooManyRequests, CodeRateLimited},
+	{netlify.ErrUnavailable, http.StatusServiceUnavailable, CodeNetlifyUnavailable},
+	{netlify.ErrUnauthorized, http.StatusBadGateway, CodeNetlifyUnauthorized},
+	{netlify.ErrRejected, http.StatusUnprocessableEntity, CodeNetlifyRejected},
+	{netlify.ErrNotFound, http.StatusNotFound, CodeNotFound},
+	{archive.ErrUnsupported, http.StatusBadRequest, CodeInvalidArchive},
+	{archive.ErrInvalid, http.StatusBadRequest, CodeInvalidArchive},
+	{archive.ErrTooLarge, http.StatusRequestEntityTooLarge, CodeArchiveTooLarge},
+	{errUploadTooLarge, http.StatusRequestEntityTooLarge, CodeUploadTooLarge},
+	{batch.ErrInvalidAccount, http.StatusBadRequest, CodeInvalidRequest},
+	{batch.ErrNoSites, http.StatusNotFound, CodeNoSites},
+	{batch.ErrNothingPublished, http.StatusBadGateway, CodeBatchFailed},
+	{ErrJobQueueFull, http.StatusServiceUnavailable, CodeQueueFull},
+	{context.DeadlineExceeded, http.StatusGatewayTimeout, CodeTimeout},
+}
+
+// errorStatus retorna o status HTTP e o código correspondentes a um erro.
+// Erros da Netlify ainda não classificados passam por netlify.Classify; os demais resultam em 500.
+func errorStatus(err error) (int, ErrorCode) {
+	err = netlify.Classify(err)
+	for _, m := range errorMappings {
+		if errors.Is(err, m.err) {
+			return m.status, m.code
+		}
+	}
+	return http.StatusInternalServerError, CodeInternal
+}
+
+// errorCode retorna apenas o código correspondente a um erro (ex: para o status de um job)
+func errorCode(err error) ErrorCode {
+	_, code := errorStatus(err)
+	return code
+}
+
+// respondError interrompe a requisição com o envelope de erro, derivando o status e o código de err.
+// A mensagem é a da chave key no idioma da requisição, seguida da descrição traduzida do código;
+// o texto original do erro vai no campo detail. Erros internos não são expostos ao cliente:
+// ficam apenas no log, com o ID da requisição para correlação.
+func respondError(c *gin.Context, err error, key string, args ...interface{}) {
+	status, code := errorStatus(err)
+	lang := requestLang(c)
+
+	message := i18n.T(lang, key, args...)
+	if code == CodeInternal {
+		slog.ErrorContext(c.Request.Context(), "Erro interno na requisição", "error", err)
+		writeError(c, status, code, message, "")
+		return
+	}
+	message += ": " + i18n.T(lang, "error."+string(code))
+	writeError(c, status, code, message, err.Error())
+}
+
+// respondErrorCode interrompe a requisição com o envelope de erro, usando o status, o código e a mensagem da chave key
+func respondErrorCode(c *gin.Context, status int, code ErrorCode, key string, args ...interface{}) {
+	writeError(c, status, code, i18n.T(requestLang(c), key, args...), "")
+}
+
+// respondInvalidRequest interrompe a requisição com um erro de parâmetros inválidos.
+// O erro de validação, quando informado, vai no campo detail.
+func respondInvalidRequest(c *gin.Context, detail error, key string, args ...interface{}) {
+	if detail != nil {
+		respondError(c, fmt.Errorf("%w: %v", errInvalidRequest, detail), key, args...)
+		return
+	}
+	respondErrorCode(c, http.StatusBadRequest, CodeInvalidRequest, key, args...)
+}
+
+// writeError grava o envelope de erro e interrompe a requisição
+func writeError(c *gin.Context, status int, code ErrorCode, message, detail string) {
+	c.AbortWithStatusJSON(status, ErrorResponse{
+		Success:   false,
+		Code:      code,
+		Message:   message,
+		Detail:    detail,
+		RequestID: requestID(c),
+	})
+}
+
+// assignRequestID define o ID da requisição, reaproveitando o cabeçalho X-Request-ID quando válido
+func assignRequestID(c *gin.Context) {
+	id := c.GetHeader(requestIDHeader)
+	if !validRequestID.MatchString(id) {
+		id, _ = newID()
+	}
+
+	c.Set(requestIDContextKey, id)
+	c.Header(requestIDHeader, id)
+	logWith(c, "request_id", id)
+	tracing.SetAttributes(c.Request.Context(), tracing.RequestID.String(id))
+	c.Next()
+
+}
+
+// requestID retorna o ID da requisição atual
+func requestID(c *gin.Context) string {
+	return c.GetString(requestIDContextKey)
+}
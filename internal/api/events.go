@@ -0,0 +1,162 @@
+package api
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-contrib/sse"
+	"github.com/gin-gonic/gin"
+)
+
+// Tipos de evento transmitidos em /deploys/{id}/events
+const (
+	EventPhase          = "phase"
+	EventMessage        = "message"
+	EventFileDownloaded = "file_downloaded"
+	EventFileHashed     = "file_hashed"
+	EventUploadTotal    = "upload_total"
+	EventFileUploaded   = "file_uploaded"
+	EventDeployState    = "deploy_state"
+	EventDone           = "done"
+)
+
+// maxJobEvents limita quantos eventos cada job guarda para clientes que se conectam depois
+const maxJobEvents = 1000
+
+// sseHeartbeat é o intervalo dos comentários enviados para manter a conexão SSE aberta
+const sseHeartbeat = 15 * time.Second
+
+// JobEvent é um evento de andamento de um deploy, enviado por Server-Sent Events
+type JobEvent struct {
+	ID         int       `json:"id" example:"12" swagger:"description=Sequência do evento no job (usada em Last-Event-ID)"`
+	Type       string    `json:"type" example:"file_uploaded" swagger:"description=Tipo do evento (phase, message, file_downloaded, file_hashed, upload_total, file_uploaded, deploy_state, done)"`
+	Time       time.Time `json:"time"`
+	Phase      JobPhase  `json:"phase,omitempty" example:"uploading" swagger:"description=Fase do job (eventos phase e done)"`
+	Message    string    `json:"message,omitempty" swagger:"description=Mensagem descritiva do job"`
+	Path       string    `json:"path,omitempty" example:"css/style.css" swagger:"description=Arquivo ao qual o evento se refere"`
+	Bytes      int64     `json:"bytes,omitempty" example:"2048" swagger:"description=Tamanho do arquivo em bytes"`
+	Done       int       `json:"done,omitempty" example:"3" swagger:"description=Arquivos já processados (hash ou upload)"`
+	Total      int       `json:"total,omitempty" example:"42" swagger:"description=Total de arquivos"`
+	BytesDone  int64     `json:"bytes_done,omitempty" example:"262144" swagger:"description=Bytes já enviados"`
+	BytesTotal int64     `json:"bytes_total,omitempty" example:"1048576" swagger:"description=Total de bytes a enviar"`
+	DeployID   string    `json:"deploy_id,omitempty" example:"5f1b2c3d4e5f6a7b8c9d0e1f" swagger:"description=ID do deploy na Netlify"`
+	From       string    `json:"from,omitempty" example:"uploading" swagger:"description=Estado anterior do deploy na Netlify"`
+	To         string    `json:"to,omitempty" example:"processing" swagger:"description=Novo estado do deploy na Netlify"`
+	DeployURL  string    `json:"deploy_url,omitempty" example:"https://test-site.netlify.app" swagger:"description=URL final do deploy (evento done)"`
+	Error      string    `json:"error,omitempty" swagger:"description=Erro que interrompeu o job (evento done)"`
+	ErrorCode  ErrorCode `json:"error_code,omitempty" example:"deploy_failed" swagger:"description=Código do erro que interrompeu o job (evento done)"`
+}
+
+// FileDownloaded registra um arquivo lido do S3 (aws.ManifestObserver)
+func (j *Job) FileDownloaded(path string, bytes int64) {
+	j.update(func(s *JobStatus) {
+		j.publishLocked(JobEvent{Type: EventFileDownloaded, Path: path, Bytes: bytes})
+	})
+}
+
+// FileHashed registra o cálculo do digest de um arquivo (netlify.DeployEvents e aws.ManifestObserver)
+func (j *Job) FileHashed(path string, hashed, total int) {
+	j.update(func(s *JobStatus) {
+		j.publishLocked(JobEvent{Type: EventFileHashed, Path: path, Done: hashed, Total: total})
+	})
+}
+
+// EventsSince retorna os eventos com ID maior que after, um canal fechado quando houver novos eventos
+// e se o job já terminou (nenhum evento novo será publicado)
+func (j *Job) EventsSince(after int) ([]JobEvent, <-chan struct{}, bool) {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	if j.changed == nil {
+		j.changed = make(chan struct{})
+	}
+
+	var events []JobEvent
+	for _, ev := range j.events {
+		if ev.ID > after {
+			events = append(events, ev)
+		}
+	}
+	return events, j.changed, j.done
+}
+
+// publishLocked adiciona um evento ao job e acorda os clientes conectados. Deve ser chamado com j.mu bloqueado.
+func (j *Job) publishLocked(ev JobEvent) {
+	j.eventID++
+	ev.ID = j.eventID
+	ev.Time = time.Now()
+
+	j.events = append(j.events, ev)
+	if len(j.events) > maxJobEvents {
+		j.events = j.events[len(j.events)-maxJobEvents:]
+	}
+	j.notifyLocked()
+}
+
+// notifyLocked fecha o canal de mudanças, acordando quem aguarda em EventsSince
+func (j *Job) notifyLocked() {
+	if j.changed != nil {
+		close(j.changed)
+	}
+	j.changed = make(chan struct{})
+}
+
+// handleDeployEvents transmite o andamento de um deploy via Server-Sent Events
+// @Summary Acompanha um deploy em tempo real
+// @Description Transmite via Server-Sent Events as fases do deploy, o hash e o upload de cada arquivo, as mudanças de estado na Netlify e a URL final. O ID pode ser o do job ou o do deploy na Netlify. O evento done encerra a transmissão; envie Last-Event-ID para retomar de onde parou.
+// @Tags deploy
+// @Produce text/event-stream
+// @Param account path string true "ID da conta"
+// @Param id path string true "ID do job ou do deploy na Netlify"
+// @Param Last-Event-ID header string false "ID do último evento recebido"
+// @Success 200 {object} JobEvent
+// @Failure 404 {object} ErrorResponse
+// @Security ApiKeyAuth
+// @Router /api/accounts/{account}/deploys/{id}/events [get]
+func (s *Server) handleDeployEvents(c *gin.Context) {
+	job, ok := s.jobs.Find(currentAccount(c).ID, c.Param("id"))
+	if !ok {
+		respondErrorCode(c, http.StatusNotFound, CodeJobNotFound, "deploy.not_found", c.Param("id"))
+		return
+	}
+
+	after, _ := strconv.Atoi(c.GetHeader("Last-Event-ID"))
+
+	c.Header("Content-Type", "text/event-stream")
+	c.Header("Cache-Control", "no-cache")
+	c.Header("Connection", "keep-alive")
+	c.Header("X-Accel-Buffering", "no")
+	c.Status(http.StatusOK)
+	c.Writer.Flush()
+
+	heartbeat := time.NewTicker(sseHeartbeat)
+	defer heartbeat.Stop()
+
+	for {
+		events, changed, done := job.EventsSince(after)
+		for _, ev := range events {
+			c.Render(-1, sse.Event{
+				Id:    strconv.Itoa(ev.ID),
+				Event: ev.Type,
+				Data:  ev,
+			})
+			after = ev.ID
+		}
+		c.Writer.Flush()
+
+		if done {
+			return
+		}
+
+		select {
+		case <-c.Request.Context().Done():
+			return
+		case <-changed:
+		case <-heartbeat.C:
+			fmt.Fprint(c.Writer, ": ping\n\n")
+			c.Writer.Flush()
+		}
+	}
+}
@@ -0,0 +1,56 @@
+package api
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/kodestech/poc-netlify/internal/i18n"
+	"github.com/kodestech/poc-netlify/internal/store"
+)
+
+// MessagesResponse representa o catálogo de mensagens da interface web em um idioma
+type MessagesResponse struct {
+	Language  i18n.Lang         `json:"language" example:"en" swagger:"description=Idioma das mensagens"`
+	Languages []i18n.Lang       `json:"languages" swagger:"description=Idiomas suportados"`
+	Messages  map[string]string `json:"messages" swagger:"description=Mensagens indexadas pela chave (ui.* e error.*)"`
+}
+
+// requestLang escolhe o idioma das mensagens da requisição: o cabeçalho Accept-Language,
+// o idioma configurado na conta da rota ou, por fim, o idioma padrão
+func requestLang(c *gin.Context) i18n.Lang {
+	if lang, ok := i18n.Negotiate(c.GetHeader("Accept-Language")); ok {
+		return lang
+	}
+	if value, ok := c.Get(accountContextKey); ok {
+		if lang, ok := i18n.Parse(value.(*store.Account).Language); ok {
+			return lang
+		}
+	}
+	return i18n.Default
+}
+
+// translate retorna a mensagem da chave no idioma da requisição
+func translate(c *gin.Context, key string, args ...interface{}) string {
+	return i18n.T(requestLang(c), key, args...)
+}
+
+// handleMessages retorna as mensagens da interface web
+// @Summary Catálogo de mensagens da interface web
+// @Description Retorna as mensagens da interface web e as descrições dos códigos de erro no idioma informado em lang ou negociado pelo cabeçalho Accept-Language
+// @Tags interface
+// @Produce json
+// @Param lang query string false "Idioma (pt-BR ou en)"
+// @Success 200 {object} MessagesResponse
+// @Router /api/i18n/messages [get]
+func (s *Server) handleMessages(c *gin.Context) {
+	lang, ok := i18n.Parse(c.Query("lang"))
+	if !ok {
+		lang = requestLang(c)
+	}
+
+	c.JSON(http.StatusOK, MessagesResponse{
+		Language:  lang,
+		Languages: i18n.Supported,
+		Messages:  i18n.Messages(lang, "ui.", "error."),
+	})
+}
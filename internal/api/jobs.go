@@ -0,0 +1,458 @@
+package api
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/kodestech/poc-netlify/internal/i18n"
+	"github.com/kodestech/poc-netlify/internal/logging"
+	"github.com/kodestech/poc-netlify/internal/metrics"
+	"github.com/kodestech/poc-netlify/internal/netlify"
+	"github.com/kodestech/poc-netlify/internal/tracing"
+	"github.com/netlify/open-api/go/models"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// JobPhase representa a fase de execução de um job de deploy
+type JobPhase string
+
+const (
+	JobQueued      JobPhase = "queued"
+	JobDownloading JobPhase = JobPhase(netlify.PhaseDownloading)
+	JobUploading   JobPhase = JobPhase(netlify.PhaseUploading)
+	JobProcessing  JobPhase = JobPhase(netlify.PhaseProcessing)
+	JobReady       JobPhase = JobPhase(netlify.PhaseReady)
+	JobError       JobPhase = JobPhase(netlify.PhaseError)
+)
+
+// jobRetention define por quanto tempo um job finalizado continua disponível para consulta
+const jobRetention = 24 * time.Hour
+
+// ErrJobQueueFull é retornado quando a fila de jobs não comporta novos deploys
+var ErrJobQueueFull = errors.New("fila de deploys cheia, tente novamente mais tarde")
+
+// errJobNotCompleted indica um job que terminou sem erro mas sem chamar Complete ou Succeed
+var errJobNotCompleted = errors.New("job finalizado sem concluir o deploy")
+
+// JobStatus representa o estado de um job de deploy
+type JobStatus struct {
+	ID             string         `json:"id" example:"9f86d081884c7d65" swagger:"description=ID do job"`
+	AccountID      string         `json:"account_id" example:"elizio" swagger:"description=Conta que criou o job"`
+	Phase          JobPhase       `json:"phase" example:"uploading" swagger:"description=Fase atual (queued, downloading, uploading, processing, ready, error)"`
+	Message        string         `json:"message,omitempty" example:"Deploy concluído com sucesso" swagger:"description=Mensagem descritiva sobre o job"`
+	Error          string         `json:"error,omitempty" example:"O job falhou: o deploy falhou na Netlify" swagger:"description=Erro que interrompeu o job, no idioma da requisição que criou o job"`
+	ErrorCode      ErrorCode      `json:"error_code,omitempty" example:"deploy_failed" swagger:"description=Código do erro que interrompeu o job (mesmos códigos das respostas de erro da API)"`
+	ErrorDetail    string         `json:"error_detail,omitempty" swagger:"description=Erro original, sem tradução, para diagnóstico (vazio nos erros internal_error)"`
+	FilesTotal     int            `json:"files_total" example:"42" swagger:"description=Total de arquivos a enviar para a Netlify"`
+	FilesUploaded  int            `json:"files_uploaded" example:"10" swagger:"description=Arquivos já enviados para a Netlify"`
+	BytesTotal     int64          `json:"bytes_total" example:"1048576" swagger:"description=Total de bytes a enviar para a Netlify"`
+	BytesUploaded  int64          `json:"bytes_uploaded" example:"262144" swagger:"description=Bytes já enviados para a Netlify"`
+	SiteID         string         `json:"site_id,omitempty" example:"a1b2c3d4" swagger:"description=ID do site na Netlify"`
+	SiteURL        string         `json:"site_url,omitempty" example:"https://test-site.netlify.app" swagger:"description=URL do site"`
+	DeployID       string         `json:"deploy_id,omitempty" example:"5f1b2c3d4e5f6a7b8c9d0e1f" swagger:"description=ID do deploy na Netlify"`
+	DeployState    string         `json:"deploy_state,omitempty" example:"processing" swagger:"description=Último estado do deploy informado pela Netlify (new, uploading, processing, ready, error, rejected)"`
+	DeployURL      string         `json:"deploy_url,omitempty" example:"https://test-site.netlify.app" swagger:"description=URL final do deploy (nos rascunhos, a URL de pré-visualização)"`
+	Draft          bool           `json:"draft,omitempty" example:"false" swagger:"description=Indica se o deploy é um rascunho aguardando publicação"`
+	Deploy         *models.Deploy `json:"deploy,omitempty" swaggertype:"object" swagger:"description=Deploy final retornado pela Netlify"`
+	Result         interface{}    `json:"result,omitempty" swaggertype:"object" swagger:"description=Resultado detalhado do job (ex: relatório do deploy em lote)"`
+	Retries        int            `json:"retries" example:"0" swagger:"description=Novas tentativas feitas nas chamadas à Netlify (429, 5xx ou falha de rede)"`
+	LastRetryError string         `json:"last_retry_error,omitempty" example:"status 429" swagger:"description=Último erro que provocou uma nova tentativa"`
+	CreatedAt      time.Time      `json:"created_at"`
+	UpdatedAt      time.Time      `json:"updated_at"`
+	FinishedAt     *time.Time     `json:"finished_at,omitempty"`
+}
+
+// JobResponse representa a resposta de criação de um job de deploy
+type JobResponse struct {
+	Success   bool   `json:"success" example:"true" swagger:"description=Indica se o job foi enfileirado"`
+	Message   string `json:"message" example:"Deploy enfileirado com sucesso" swagger:"description=Mensagem descritiva sobre o resultado da operação"`
+	JobID     string `json:"job_id,omitempty" example:"9f86d081884c7d65" swagger:"description=ID do job criado"`
+	StatusURL string `json:"status_url,omitempty" example:"/api/accounts/elizio/jobs/9f86d081884c7d65" swagger:"description=URL para acompanhar o job"`
+}
+
+// JobFunc executa o trabalho de um job, reportando o andamento em job
+type JobFunc func(ctx context.Context, job *Job) error
+
+// Job é um deploy executado em segundo plano pelo JobQueue.
+// Implementa netlify.DeployProgress para receber o andamento do deploy.
+type Job struct {
+	mu     sync.RWMutex
+	status JobStatus
+	run    JobFunc
+	lang   i18n.Lang
+	logCtx context.Context
+
+	// Eventos de andamento transmitidos por SSE (ver events.go)
+	events  []JobEvent
+	eventID int
+	changed chan struct{}
+	done    bool
+
+	// completed indica que o job foi concluído por Complete ou Succeed
+	completed bool
+}
+
+// Status retorna uma cópia do estado atual do job
+func (j *Job) Status() JobStatus {
+	j.mu.RLock()
+	defer j.mu.RUnlock()
+	return j.status
+}
+
+// ID retorna o identificador do job
+func (j *Job) ID() string {
+	j.mu.RLock()
+	defer j.mu.RUnlock()
+	return j.status.ID
+}
+
+// logContext retorna o contexto com os campos de log do job (request_id, job_id, site_id e deploy_id, quando conhecidos)
+func (j *Job) logContext() context.Context {
+	j.mu.RLock()
+	defer j.mu.RUnlock()
+	return j.logCtx
+}
+
+// setDeployIDLocked registra o ID do deploy no status e nos campos de log do job
+func (j *Job) setDeployIDLocked(deployID string) {
+	if deployID != "" && deployID != j.status.DeployID {
+		j.logCtx = logging.With(j.logCtx, "deploy_id", deployID)
+		tracing.SetAttributes(j.logCtx, tracing.DeployID.String(deployID))
+	}
+	j.status.DeployID = deployID
+}
+
+// translate retorna a mensagem da chave no idioma da requisição que criou o job
+func (j *Job) translate(key string, args ...interface{}) string {
+	return i18n.T(j.lang, key, args...)
+}
+
+// SetPhase atualiza a fase do job
+func (j *Job) SetPhase(phase netlify.DeployPhase) {
+	j.update(func(s *JobStatus) {
+		if s.Phase != JobPhase(phase) {
+			j.publishLocked(JobEvent{Type: EventPhase, Phase: JobPhase(phase)})
+		}
+		s.Phase = JobPhase(phase)
+	})
+}
+
+// SetUploadTotal registra quantos arquivos e bytes serão enviados para a Netlify
+func (j *Job) SetUploadTotal(files int, bytes int64) {
+	j.update(func(s *JobStatus) {
+		s.FilesTotal = files
+		s.BytesTotal = bytes
+		j.publishLocked(JobEvent{Type: EventUploadTotal, Total: files, BytesTotal: bytes})
+	})
+}
+
+// AddUploaded contabiliza um arquivo enviado para a Netlify
+func (j *Job) AddUploaded(name string, bytes int64) {
+	j.update(func(s *JobStatus) {
+		s.FilesUploaded++
+		s.BytesUploaded += bytes
+		j.publishLocked(JobEvent{
+			Type:       EventFileUploaded,
+			Path:       name,
+			Bytes:      bytes,
+			Done:       s.FilesUploaded,
+			Total:      s.FilesTotal,
+			BytesDone:  s.BytesUploaded,
+			BytesTotal: s.BytesTotal,
+		})
+	})
+}
+
+// SetMessage atualiza a mensagem descritiva do job
+func (j *Job) SetMessage(message string) {
+	j.update(func(s *JobStatus) {
+		s.Message = message
+		j.publishLocked(JobEvent{Type: EventMessage, Message: message})
+	})
+}
+
+// SetSite registra o site de destino do deploy
+func (j *Job) SetSite(site *models.Site) {
+	j.update(func(s *JobStatus) {
+		if site.ID != s.SiteID {
+			j.logCtx = logging.With(j.logCtx, "site_id", site.ID)
+			tracing.SetAttributes(j.logCtx, tracing.SiteID.String(site.ID))
+		}
+		s.SiteID = site.ID
+		s.SiteURL = site.URL
+	})
+}
+
+// SetDeployID registra o ID do deploy criado na Netlify
+func (j *Job) SetDeployID(deployID string) {
+	j.update(func(s *JobStatus) {
+		j.setDeployIDLocked(deployID)
+	})
+}
+
+// OnDeployState registra as mudanças de estado do deploy na Netlify (netlify.DeployStateFunc)
+func (j *Job) OnDeployState(from, to string, deploy *models.Deploy) {
+	j.update(func(s *JobStatus) {
+		j.setDeployIDLocked(deploy.ID)
+		s.DeployState = to
+		j.publishLocked(JobEvent{Type: EventDeployState, DeployID: deploy.ID, From: from, To: to})
+	})
+}
+
+// SetResult registra o resultado detalhado do job
+func (j *Job) SetResult(result interface{}) {
+	j.update(func(s *JobStatus) {
+		s.Result = result
+	})
+}
+
+// SetRetries registra as novas tentativas feitas pelo cliente Netlify do job
+func (j *Job) SetRetries(stats netlify.RetryStats) {
+	j.update(func(s *JobStatus) {
+		s.Retries = stats.Retries
+		s.LastRetryError = stats.LastError
+	})
+}
+
+// Complete marca o job como concluído com o deploy final
+func (j *Job) Complete(deploy *models.Deploy) {
+	j.update(func(s *JobStatus) {
+		j.completed = true
+		s.Phase = JobReady
+		s.Deploy = deploy
+		j.setDeployIDLocked(deploy.ID)
+
+		s.DeployState = deploy.State
+		s.Draft = deploy.Draft
+		if deploy.Draft {
+			// O rascunho não está em produção: a URL útil é a de pré-visualização
+			s.DeployURL = netlify.PreviewURL(deploy)
+			s.Message = j.translate("job.draft_ready")
+			return
+		}
+
+		s.DeployURL = deploy.SslURL
+		if s.DeployURL == "" {
+			s.DeployURL = deploy.URL
+		}
+		if s.Message == "" {
+			s.Message = j.translate("job.deploy_ready")
+		}
+	})
+}
+
+// Succeed marca como concluído um job que não termina em um único deploy (ex: deploy em lote)
+func (j *Job) Succeed(message string) {
+	j.update(func(s *JobStatus) {
+		j.completed = true
+		s.Phase = JobReady
+		s.Message = message
+	})
+}
+
+// fail marca o job como encerrado com erro. Como em respondError, a mensagem é traduzida a partir
+// do código do erro e o texto original vai em ErrorDetail, exceto nos erros internos.
+func (j *Job) fail(err error) {
+	code := errorCode(err)
+	j.update(func(s *JobStatus) {
+		s.Phase = JobError
+		s.ErrorCode = code
+		s.Error = j.translate("job.failed")
+		if code == CodeInternal {
+			return
+		}
+		s.Error += ": " + j.translate("error."+string(code))
+		s.ErrorDetail = err.Error()
+	})
+}
+
+// finish registra o horário de término do job. A fase não é alterada: o job só fica pronto
+// por Complete ou Succeed, e com erro por fail.
+func (j *Job) finish() {
+	j.update(func(s *JobStatus) {
+		now := time.Now()
+		s.FinishedAt = &now
+		j.publishLocked(JobEvent{
+			Type:      EventDone,
+			Phase:     s.Phase,
+			Message:   s.Message,
+			DeployID:  s.DeployID,
+			DeployURL: s.DeployURL,
+			Error:     s.Error,
+			ErrorCode: s.ErrorCode,
+		})
+		j.done = true
+		j.notifyLocked()
+	})
+}
+
+// isCompleted indica se o job foi concluído por Complete ou Succeed
+func (j *Job) isCompleted() bool {
+	j.mu.RLock()
+	defer j.mu.RUnlock()
+	return j.completed
+}
+
+func (j *Job) update(fn func(s *JobStatus)) {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	fn(&j.status)
+	j.status.UpdatedAt = time.Now()
+}
+
+// JobQueue executa jobs de deploy em um pool limitado de workers
+type JobQueue struct {
+	mu    sync.RWMutex
+	jobs  map[string]*Job
+	queue chan *Job
+}
+
+// NewJobQueue cria uma fila de jobs com o número de workers e a capacidade informados
+func NewJobQueue(workers, size int) *JobQueue {
+	q := &JobQueue{
+		jobs:  make(map[string]*Job),
+		queue: make(chan *Job, size),
+	}
+
+	for i := 0; i < workers; i++ {
+		go q.worker()
+	}
+
+	slog.Info("Fila de jobs iniciada", "workers", workers, "capacity", size)
+	return q
+}
+
+// Submit enfileira um novo job da conta informada, com mensagens no idioma lang.
+// ctx é o contexto da requisição que criou o job: os campos de log dele (ex: request_id) são
+// herdados pelos logs do job, mas o seu cancelamento não interrompe o job.
+// Retorna ErrJobQueueFull se a fila estiver cheia.
+func (q *JobQueue) Submit(ctx context.Context, accountID string, lang i18n.Lang, run JobFunc) (*Job, error) {
+	id, err := newID()
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	job := &Job{
+		status: JobStatus{
+			ID:        id,
+			AccountID: accountID,
+			Phase:     JobQueued,
+			CreatedAt: now,
+			UpdatedAt: now,
+		},
+		run:    run,
+		lang:   lang,
+		logCtx: logging.With(context.WithoutCancel(ctx), "job_id", id),
+	}
+
+	q.mu.Lock()
+	q.pruneLocked(now)
+	q.jobs[id] = job
+	q.mu.Unlock()
+
+	select {
+	case q.queue <- job:
+		metrics.JobsQueued.Inc()
+		slog.InfoContext(job.logCtx, "Job enfileirado")
+		return job, nil
+	default:
+		q.mu.Lock()
+		delete(q.jobs, id)
+		q.mu.Unlock()
+		return nil, ErrJobQueueFull
+	}
+}
+
+// Get retorna o job com o ID informado
+func (q *JobQueue) Get(id string) (*Job, bool) {
+	q.mu.RLock()
+	defer q.mu.RUnlock()
+	job, ok := q.jobs[id]
+	return job, ok
+}
+
+// Find retorna o job da conta pelo ID do job ou pelo ID do deploy criado na Netlify
+func (q *JobQueue) Find(accountID, id string) (*Job, bool) {
+	q.mu.RLock()
+	defer q.mu.RUnlock()
+
+	if job, ok := q.jobs[id]; ok && job.Status().AccountID == accountID {
+		return job, true
+	}
+	for _, job := range q.jobs {
+		status := job.Status()
+		if status.AccountID == accountID && status.DeployID == id {
+			return job, true
+		}
+	}
+	return nil, false
+}
+
+// worker consome a fila executando um job por vez
+func (q *JobQueue) worker() {
+	for job := range q.queue {
+		q.execute(job)
+	}
+}
+
+// execute roda um job, protegendo o worker contra panics
+func (q *JobQueue) execute(job *Job) {
+	// O span do job continua o trace da requisição que o criou; os logs do job passam a referenciá-lo
+	status := job.Status()
+	job.mu.Lock()
+	var span trace.Span
+	job.logCtx, span = tracing.Start(job.logCtx, "job.execute", tracing.JobID.String(status.ID), tracing.Account.String(status.AccountID))
+	job.mu.Unlock()
+
+	slog.InfoContext(job.logContext(), "Iniciando job")
+	metrics.JobsQueued.Dec()
+	metrics.JobsInFlight.Inc()
+	defer metrics.JobsInFlight.Dec()
+
+	var err error
+	defer func() {
+		if r := recover(); r != nil {
+			slog.ErrorContext(job.logContext(), "Panic no job", "panic", r)
+			err = errors.New("erro interno ao executar o deploy")
+			job.fail(err)
+		}
+		job.finish()
+
+		slog.InfoContext(job.logContext(), "Job finalizado", "phase", job.Status().Phase)
+		tracing.End(span, err)
+	}()
+
+	ctx := netlify.WithProgress(job.logContext(), job)
+	if err = job.run(ctx, job); err == nil && !job.isCompleted() {
+		err = errJobNotCompleted
+	}
+	if err != nil {
+		slog.ErrorContext(job.logContext(), "Erro no job", "error", err)
+		job.fail(err)
+	}
+}
+
+// pruneLocked remove jobs finalizados há mais tempo que jobRetention
+func (q *JobQueue) pruneLocked(now time.Time) {
+	for id, job := range q.jobs {
+		status := job.Status()
+		if status.FinishedAt != nil && now.Sub(*status.FinishedAt) > jobRetention {
+			delete(q.jobs, id)
+		}
+	}
+}
+
+// newID gera um identificador aleatório (jobs, chaves de API)
+func newID() (string, error) {
+	b := make([]byte, 8)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
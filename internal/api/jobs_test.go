@@ -0,0 +1,128 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/kodestech/poc-netlify/internal/i18n"
+	"github.com/kodestech/poc-netlify/internal/netlify"
+	"github.com/netlify/open-api/go/models"
+)
+
+// waitJob aguarda o fim do job e retorna o estado final
+func waitJob(t *testing.T, job *Job) JobStatus {
+	t.Helper()
+
+	deadline := time.Now().Add(5 * time.Second)
+	for time.Now().Before(deadline) {
+		if status := job.Status(); status.FinishedAt != nil {
+			return status
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+	t.Fatalf("job %s não terminou: %+v", job.ID(), job.Status())
+	return JobStatus{}
+}
+
+func TestJobQueueFull(t *testing.T) {
+	// Sem workers, nada é consumido e a fila enche no segundo job
+	q := NewJobQueue(0, 1)
+	run := func(ctx context.Context, job *Job) error { return nil }
+
+	first, err := q.Submit(context.Background(), "elizio", i18n.PortugueseBR, run)
+	if err != nil {
+		t.Fatalf("primeiro job rejeitado: %v", err)
+	}
+	if _, err := q.Submit(context.Background(), "elizio", i18n.PortugueseBR, run); !errors.Is(err, ErrJobQueueFull) {
+		t.Fatalf("segundo job retornou %v, esperado ErrJobQueueFull", err)
+	}
+
+	if len(q.jobs) != 1 {
+		t.Fatalf("fila com %d jobs registrados, esperado apenas o aceito", len(q.jobs))
+	}
+	if _, ok := q.Get(first.ID()); !ok {
+		t.Fatal("job aceito não encontrado")
+	}
+}
+
+func TestJobPanicRecovered(t *testing.T) {
+	q := NewJobQueue(1, 2)
+
+	job, err := q.Submit(context.Background(), "elizio", i18n.PortugueseBR, func(ctx context.Context, job *Job) error {
+		panic("falha inesperada")
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	status := waitJob(t, job)
+	if status.Phase != JobError || status.ErrorCode != CodeInternal || status.ErrorDetail != "" {
+		t.Fatalf("job com panic terminou como %+v", status)
+	}
+
+	// O worker continua consumindo a fila depois do panic
+	next, err := q.Submit(context.Background(), "elizio", i18n.PortugueseBR, func(ctx context.Context, job *Job) error {
+		job.Succeed("ok")
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if status := waitJob(t, next); status.Phase != JobReady {
+		t.Fatalf("job seguinte terminou como %+v", status)
+	}
+}
+
+func TestJobReadyOnlyWhenCompleted(t *testing.T) {
+	q := NewJobQueue(1, 3)
+
+	completed, _ := q.Submit(context.Background(), "elizio", i18n.PortugueseBR, func(ctx context.Context, job *Job) error {
+		job.Complete(&models.Deploy{ID: "d1", State: "ready", SslURL: "https://site.netlify.app"})
+		return nil
+	})
+	if status := waitJob(t, completed); status.Phase != JobReady || status.DeployID != "d1" || status.DeployURL != "https://site.netlify.app" {
+		t.Fatalf("job concluído terminou como %+v", status)
+	}
+
+	// Sem erro e sem Complete, o job não é dado como pronto
+	incomplete, _ := q.Submit(context.Background(), "elizio", i18n.PortugueseBR, func(ctx context.Context, job *Job) error {
+		job.SetPhase("uploading")
+		return nil
+	})
+	if status := waitJob(t, incomplete); status.Phase != JobError || status.ErrorCode != CodeInternal {
+		t.Fatalf("job sem Complete terminou como %+v", status)
+	}
+
+	failed, _ := q.Submit(context.Background(), "elizio", i18n.PortugueseBR, func(ctx context.Context, job *Job) error {
+		return fmt.Errorf("erro ao aguardar deploy: %w", netlify.ErrDeployRejected)
+	})
+	if status := waitJob(t, failed); status.Phase != JobError || status.ErrorCode != CodeDeployRejected || status.ErrorDetail == "" {
+		t.Fatalf("job com erro terminou como %+v", status)
+	}
+}
+
+func TestJobQueuePrune(t *testing.T) {
+	q := NewJobQueue(0, 2)
+
+	old := time.Now().Add(-jobRetention - time.Minute)
+	recent := time.Now().Add(-time.Minute)
+	q.jobs["antigo"] = &Job{status: JobStatus{ID: "antigo", Phase: JobReady, FinishedAt: &old}}
+	q.jobs["recente"] = &Job{status: JobStatus{ID: "recente", Phase: JobReady, FinishedAt: &recent}}
+	q.jobs["executando"] = &Job{status: JobStatus{ID: "executando", Phase: JobUploading, CreatedAt: old}}
+
+	// A limpeza acontece ao enfileirar um novo job
+	if _, err := q.Submit(context.Background(), "elizio", i18n.PortugueseBR, func(ctx context.Context, job *Job) error { return nil }); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, ok := q.Get("antigo"); ok {
+		t.Fatal("job finalizado há mais de jobRetention não foi removido")
+	}
+	for _, id := range []string{"recente", "executando"} {
+		if _, ok := q.Get(id); !ok {
+			t.Fatalf("job %s removido antes do prazo", id)
+		}
+	}
+}
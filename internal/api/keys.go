@@ -0,0 +1,189 @@
+package api
+
+import (
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/kodestech/poc-netlify/internal/store"
+)
+
+// APIKeyRequest representa os dados de criação de uma chave de API
+type APIKeyRequest struct {
+	Name      string `json:"name" example:"CI do site da Elizio" swagger:"description=Descrição da chave"`
+	AccountID string `json:"account_id" example:"elizio" swagger:"description=Conta em que a chave pode operar (vazio apenas para administradores globais)"`
+	Role      Role   `json:"role" binding:"required" example:"deployer" swagger:"description=Papel da chave (viewer, deployer, domain-admin, admin)"`
+}
+
+// APIKeyView representa uma chave de API nas respostas, sem o segredo
+type APIKeyView struct {
+	ID        string    `json:"id" example:"3f2a9c1d7e8b4a60" swagger:"description=ID da chave"`
+	Name      string    `json:"name,omitempty" example:"CI do site da Elizio" swagger:"description=Descrição da chave"`
+	AccountID string    `json:"account_id,omitempty" example:"elizio" swagger:"description=Conta em que a chave pode operar"`
+	Role      Role      `json:"role" example:"deployer" swagger:"description=Papel da chave"`
+	Prefix    string    `json:"prefix" example:"npk_1a2b3c4d" swagger:"description=Início do segredo, para identificação"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// APIKeyResponse representa a resposta das operações sobre uma chave de API
+type APIKeyResponse struct {
+	Success bool        `json:"success" example:"true" swagger:"description=Indica se a operação foi bem-sucedida"`
+	Message string      `json:"message,omitempty" example:"Chave criada com sucesso" swagger:"description=Mensagem descritiva sobre o resultado da operação"`
+	Key     *APIKeyView `json:"key,omitempty" swagger:"description=Chave criada"`
+	Secret  string      `json:"secret,omitempty" example:"npk_1a2b3c4d..." swagger:"description=Segredo da chave, exibido apenas na criação"`
+}
+
+// APIKeyListResponse representa a lista de chaves de API
+type APIKeyListResponse struct {
+	Success bool         `json:"success" example:"true" swagger:"description=Indica se a operação foi bem-sucedida"`
+	Keys    []APIKeyView `json:"keys" swagger:"description=Chaves cadastradas"`
+}
+
+// newAPIKeyView converte uma chave para a representação da API
+func newAPIKeyView(key *store.APIKey) *APIKeyView {
+	return &APIKeyView{
+		ID:        key.ID,
+		Name:      key.Name,
+		AccountID: key.AccountID,
+		Role:      Role(key.Role),
+		Prefix:    key.Prefix,
+		CreatedAt: key.CreatedAt,
+	}
+}
+
+// handleListAPIKeys lista as chaves de API
+// @Summary Lista as chaves de API
+// @Description Retorna as chaves de API cadastradas, sem os segredos
+// @Tags admin
+// @Produce json
+// @Security ApiKeyAuth
+// @Success 200 {object} APIKeyListResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/admin/keys [get]
+func (s *Server) handleListAPIKeys(c *gin.Context) {
+	keys, err := s.store.ListAPIKeys()
+	if err != nil {
+		slog.ErrorContext(c.Request.Context(), "Erro ao listar chaves de API", "error", err)
+		respondError(c, err, "key.list_failed")
+		return
+	}
+
+	views := make([]APIKeyView, 0, len(keys))
+	for i := range keys {
+		views = append(views, *newAPIKeyView(&keys[i]))
+	}
+
+	c.JSON(http.StatusOK, APIKeyListResponse{
+		Success: true,
+		Keys:    views,
+	})
+}
+
+// handleCreateAPIKey cria uma chave de API
+// @Summary Cria uma chave de API
+// @Description Cria uma chave vinculada a uma conta e a um papel. O segredo é retornado apenas nesta resposta; somente seu hash é armazenado.
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param request body APIKeyRequest true "Dados da chave"
+// @Success 201 {object} APIKeyResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/admin/keys [post]
+func (s *Server) handleCreateAPIKey(c *gin.Context) {
+	var req APIKeyRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondInvalidRequest(c, err, "request.invalid_body")
+		return
+	}
+
+	if !ValidRole(req.Role) {
+		respondInvalidRequest(c, nil, "key.invalid_role", req.Role)
+		return
+	}
+
+	// Apenas administradores podem ter chaves sem conta associada
+	if req.AccountID == "" && req.Role != RoleAdmin {
+		respondInvalidRequest(c, nil, "key.account_required")
+		return
+	}
+	if req.AccountID != "" {
+		if _, exists, err := s.store.GetAccount(req.AccountID); err != nil || !exists {
+			respondInvalidRequest(c, nil, "account.not_found", req.AccountID)
+			return
+		}
+	}
+
+	secret, err := generateAPIKey()
+	if err != nil {
+		respondError(c, err, "key.generate_failed")
+		return
+	}
+	id, err := newID()
+	if err != nil {
+		respondError(c, err, "key.generate_failed")
+		return
+	}
+
+	key := &store.APIKey{
+		ID:        id,
+		Name:      req.Name,
+		AccountID: req.AccountID,
+		Role:      string(req.Role),
+		Prefix:    secret[:len(apiKeyPrefix)+8],
+		Hash:      hashAPIKey(secret),
+	}
+	if err := s.store.SaveAPIKey(key); err != nil {
+		slog.ErrorContext(c.Request.Context(), "Erro ao salvar chave de API", "error", err)
+		respondError(c, err, "key.save_failed")
+		return
+	}
+
+	slog.InfoContext(c.Request.Context(), "Chave de API criada", "key_id", key.ID, "account", key.AccountID, "role", key.Role)
+	c.JSON(http.StatusCreated, APIKeyResponse{
+		Success: true,
+		Message: translate(c, "key.created"),
+		Key:     newAPIKeyView(key),
+		Secret:  secret,
+	})
+}
+
+// handleDeleteAPIKey revoga uma chave de API
+// @Summary Revoga uma chave de API
+// @Description Remove a chave de API, que deixa de ser aceita imediatamente
+// @Tags admin
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path string true "ID da chave"
+// @Success 200 {object} APIKeyResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/admin/keys/{id} [delete]
+func (s *Server) handleDeleteAPIKey(c *gin.Context) {
+	id := c.Param("id")
+	deleted, err := s.store.DeleteAPIKey(id)
+	if err != nil {
+		slog.ErrorContext(c.Request.Context(), "Erro ao remover chave de API", "key_id", id, "error", err)
+		respondError(c, err, "key.delete_failed")
+		return
+	}
+	if !deleted {
+		respondErrorCode(c, http.StatusNotFound, CodeNotFound, "key.not_found", id)
+		return
+	}
+
+	slog.InfoContext(c.Request.Context(), "Chave de API revogada", "key_id", id)
+
+	c.JSON(http.StatusOK, APIKeyResponse{
+		Success: true,
+		Message: translate(c, "key.revoked"),
+	})
+}
@@ -0,0 +1,140 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/kodestech/poc-netlify/internal/logging"
+)
+
+// maxLogsPerPage limita a quantidade de registros de log retornados por página
+const maxLogsPerPage = 1000
+
+// LogsResponse representa uma página dos logs da aplicação
+type LogsResponse struct {
+	Success    bool            `json:"success" example:"true" swagger:"description=Indica se a operação foi bem-sucedida"`
+	Entries    []logging.Entry `json:"entries" swagger:"description=Registros da página, do mais recente ao mais antigo"`
+	NextCursor string          `json:"next_cursor,omitempty" example:"MTc2MDY0NjcyMjk2MTkxODIzMi4x" swagger:"description=Cursor da próxima página (ausente na última página)"`
+}
+
+// logWith adiciona campos aos logs da requisição, incluindo o log de acesso, e retorna o contexto atualizado
+func logWith(c *gin.Context, args ...any) context.Context {
+	ctx := logging.With(c.Request.Context(), args...)
+	c.Request = c.Request.WithContext(ctx)
+	return ctx
+}
+
+// logRequest registra cada requisição com o status e a duração, no nível de acordo com o status
+func logRequest(c *gin.Context) {
+	start := time.Now()
+	c.Next()
+
+	status := c.Writer.Status()
+	level := slog.LevelInfo
+	switch {
+	case status >= http.StatusInternalServerError:
+		level = slog.LevelError
+	case status >= http.StatusBadRequest:
+		level = slog.LevelWarn
+	}
+
+	slog.LogAttrs(c.Request.Context(), level, "Requisição concluída",
+		slog.String("method", c.Request.Method),
+		slog.String("path", c.Request.URL.Path),
+		slog.Int("status", status),
+		slog.Duration("duration", time.Since(start)),
+		slog.String("client_ip", c.ClientIP()),
+	)
+}
+
+// recoverRequest registra um panic em um handler e responde erro interno. O dump da requisição
+// feito pelo Gin (com os cabeçalhos de autenticação) não é gravado.
+func recoverRequest(c *gin.Context, err any) {
+	slog.ErrorContext(c.Request.Context(), "Panic na requisição",
+		"error", err,
+		"method", c.Request.Method,
+		"path", c.Request.URL.Path,
+	)
+	respondErrorCode(c, http.StatusInternalServerError, CodeInternal, "server.internal_error")
+}
+
+// handleListLogs consulta os logs da aplicação
+// @Summary Consulta os logs da aplicação
+// @Description Retorna os registros de log (JSON), do mais recente ao mais antigo, com filtros por nível, período, requisição, conta, site e deploy e paginação por cursor. Inclui os arquivos rotacionados.
+// @Tags logs
+// @Produce json
+// @Param level query string false "Nível mínimo (debug, info, warn, error; padrão debug)"
+// @Param from query string false "Início do período (RFC 3339, ex: 2025-05-01T00:00:00Z)"
+// @Param to query string false "Fim do período (RFC 3339)"
+// @Param request_id query string false "ID da requisição"
+// @Param account query string false "ID da conta"
+// @Param site_id query string false "ID do site na Netlify"
+// @Param deploy_id query string false "ID do deploy na Netlify"
+// @Param cursor query string false "Cursor da próxima página (next_cursor da resposta anterior)"
+// @Param limit query int false "Registros por página (padrão 100, máximo 1000)"
+// @Success 200 {object} LogsResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Security ApiKeyAuth
+// @Router /api/logs [get]
+func (s *Server) handleListLogs(c *gin.Context) {
+	filter := logging.Filter{
+		Level:     slog.LevelDebug,
+		RequestID: c.Query("request_id"),
+		Account:   c.Query("account"),
+		SiteID:    c.Query("site_id"),
+		DeployID:  c.Query("deploy_id"),
+		Cursor:    c.Query("cursor"),
+	}
+
+	if value := c.Query("level"); value != "" {
+		level, err := logging.ParseLevel(value)
+		if err != nil {
+			respondInvalidRequest(c, err, "logs.invalid_level", value)
+			return
+		}
+		filter.Level = level
+	}
+
+	for param, t := range map[string]*time.Time{"from": &filter.From, "to": &filter.To} {
+		if value := c.Query(param); value != "" {
+			parsed, err := time.Parse(time.RFC3339, value)
+			if err != nil {
+				respondInvalidRequest(c, err, "logs.invalid_time", param)
+				return
+			}
+			*t = parsed
+		}
+	}
+
+	limit, err := strconv.Atoi(c.DefaultQuery("limit", "100"))
+	if err != nil || limit < 1 || limit > maxLogsPerPage {
+		respondInvalidRequest(c, nil, "logs.invalid_limit", maxLogsPerPage)
+		return
+	}
+	filter.Limit = limit
+
+	page, err := logging.Query(s.config.LogPath, filter)
+	if errors.Is(err, logging.ErrInvalidCursor) {
+		respondInvalidRequest(c, err, "logs.invalid_cursor")
+		return
+	}
+	if err != nil {
+		slog.ErrorContext(c.Request.Context(), "Erro ao consultar logs", "error", err)
+		respondError(c, err, "logs.read_failed")
+		return
+	}
+
+	c.JSON(http.StatusOK, LogsResponse{
+		Success:    true,
+		Entries:    page.Entries,
+		NextCursor: page.NextCursor,
+	})
+}
@@ -0,0 +1,26 @@
+package api
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/kodestech/poc-netlify/internal/metrics"
+)
+
+// unmatchedRoute é o rótulo das requisições que não correspondem a nenhuma rota
+const unmatchedRoute = "unmatched"
+
+// observeRequest contabiliza nas métricas a requisição e a sua latência, pelo modelo da rota
+// (ex: /api/accounts/:account/sites/:id), para que os IDs não multipliquem as séries
+func observeRequest(c *gin.Context) {
+	start := time.Now()
+	c.Next()
+
+	route := c.FullPath()
+	if route == "" {
+		route = unmatchedRoute
+	}
+	metrics.HTTPRequests.WithLabelValues(c.Request.Method, route, strconv.Itoa(c.Writer.Status())).Inc()
+	metrics.HTTPRequestDuration.WithLabelValues(c.Request.Method, route).Observe(time.Since(start).Seconds())
+}
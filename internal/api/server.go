@@ -5,47 +5,38 @@ import (
 	"encoding/base64"
 	"fmt"
 	"io"
-	"log"
+	"log/slog"
+	"mime/multipart"
 	"net/http"
 	"os"
 	"reflect"
 	"strconv"
-	"strings"
 	"time"
 
 	"github.com/gin-contrib/cors"
 	"github.com/gin-gonic/gin"
+	"github.com/kodestech/poc-netlify/internal/archive"
 	"github.com/kodestech/poc-netlify/internal/aws"
 	"github.com/kodestech/poc-netlify/internal/config"
+	"github.com/kodestech/poc-netlify/internal/dns"
+	"github.com/kodestech/poc-netlify/internal/logging"
+	"github.com/kodestech/poc-netlify/internal/metrics"
 	"github.com/kodestech/poc-netlify/internal/netlify"
+	"github.com/kodestech/poc-netlify/internal/store"
 	swaggerFiles "github.com/swaggo/files"
 	ginSwagger "github.com/swaggo/gin-swagger"
 	_ "github.com/kodestech/poc-netlify/docs"
-	"github.com/joho/godotenv"
 	"github.com/netlify/open-api/go/models"
 )
 
 // Server representa o servidor da API
 type Server struct {
-	config *config.Config
-	router *gin.Engine
-}
-
-// DeployRequest representa os parâmetros para um deploy (mantido para compatibilidade)
-type DeployRequest struct {
-	Username     string `json:"username"`
-	CustomDomain string `json:"custom_domain,omitempty"`
-	S3Path       string `json:"s3_path"`
-}
-
-// DeployResponse representa a resposta de uma operação de deploy (mantido para compatibilidade)
-type DeployResponse struct {
-	Success      bool   `json:"success"`
-	Message      string `json:"message"`
-	SiteURL      string `json:"site_url,omitempty"`
-	Subdomain    string `json:"subdomain,omitempty"`
-	CustomDomain string `json:"custom_domain,omitempty"`
-	DeployID     string `json:"deploy_id,omitempty"`
+	config   *config.Config
+	router   *gin.Engine
+	jobs     *JobQueue
+	store    *store.Store
+	dns      dns.Provider
+	resolver dns.Resolver
 }
 
 // TestDeployRequest encapsula os parâmetros para teste de deploy na Netlify
@@ -84,46 +75,51 @@ type DomainResponse struct {
 }
 
 // NewServer cria um novo servidor da API
-func NewServer(cfg *config.Config) *Server {
+func NewServer(cfg *config.Config, st *store.Store, dnsProvider dns.Provider) *Server {
 	// Configurar o modo do Gin
 	if os.Getenv("GIN_MODE") == "release" {
 		gin.SetMode(gin.ReleaseMode)
 	}
 
-	// Criar router Gin
-	router := gin.Default()
-
-	// Configurar CORS
-	router.Use(cors.New(cors.Config{
-		AllowOrigins:     []string{"*"},
-		AllowMethods:     []string{"GET", "POST", "PUT", "PATCH", "DELETE", "OPTIONS"},
-		AllowHeaders:     []string{"Origin", "Content-Type", "Accept", "Authorization"},
-		ExposeHeaders:    []string{"Content-Length"},
-		AllowCredentials: true,
-		MaxAge:           12 * time.Hour,
-	}))
-
-	// Adicionar middleware de registro
-	router.Use(func(c *gin.Context) {
-		// Registrar request
-		start := time.Now()
-		c.Next()
-		// Registrar response
-		log.Printf("[API] %s %s %d %s",
-			c.Request.Method,
-			c.Request.URL.Path,
-			c.Writer.Status(),
-			time.Since(start),
-		)
-		c.Next()
-	})
+	// Criar router Gin. O log de acesso padrão do Gin não é usado: as requisições são registradas
+	// por logRequest, sem query strings nem cabeçalhos e sem valores sensíveis
+	router := gin.New()
+	router.Use(gin.CustomRecoveryWithWriter(io.Discard, recoverRequest))
+
+	// Configurar CORS apenas para as origens autorizadas (sem origens, apenas a própria interface web acessa a API)
+	if len(cfg.CORSAllowedOrigins) > 0 {
+		router.Use(cors.New(cors.Config{
+			AllowOrigins:     cfg.CORSAllowedOrigins,
+			AllowMethods:     []string{"GET", "POST", "PUT", "PATCH", "DELETE", "OPTIONS"},
+			AllowHeaders:     []string{"Origin", "Content-Type", "Accept", "Authorization", "X-API-Key", requestIDHeader},
+			ExposeHeaders:    []string{"Content-Length", requestIDHeader},
+			AllowCredentials: true,
+			MaxAge:           12 * time.Hour,
+		}))
+	}
+
+	// Criar um span para cada requisição (OpenTelemetry), propagando o trace recebido no cabeçalho traceparent
+	router.Use(traceRequest(cfg.TracingServiceName))
+
+	// Identificar cada requisição (cabeçalho X-Request-ID), para correlacionar logs e respostas de erro
+	router.Use(assignRequestID)
+
+	// Registrar cada requisição nos logs, com o ID da requisição e os campos adicionados pelos handlers
+	router.Use(logRequest)
+
+	// Contabilizar as requisições e a latência por rota nas métricas (/metrics)
+	router.Use(observeRequest)
 
 	// Configurar Swagger
 	router.GET("/docs/swagger/*any", ginSwagger.WrapHandler(swaggerFiles.Handler))
 	// URL do Swagger UI: http://localhost:8080/docs/swagger/index.html
 	server := &Server{
-		config: cfg,
-		router: router,
+		config:   cfg,
+		router:   router,
+		jobs:     NewJobQueue(cfg.JobWorkers, cfg.JobQueueSize),
+		store:    st,
+		dns:      dnsProvider,
+		resolver: dns.NewResolver(dnsProvider, cfg.DNSResolver),
 	}
 
 	// Configurar rotas
@@ -132,6 +128,19 @@ func NewServer(cfg *config.Config) *Server {
 	return server
 }
 
+// newNetlifyClient cria um cliente Netlify que registra os deploys no histórico
+func (s *Server) newNetlifyClient(cfg *config.Config) (*netlify.Client, error) {
+	netlifyClient, err := netlify.NewClient(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	netlifyClient.SetRecorder(s.store)
+	netlifyClient.SetDNSProvider(s.dns)
+	netlifyClient.SetDomainRecorder(s.store)
+	return netlifyClient, nil
+}
+
 // setupRoutes configura as rotas da API
 func (s *Server) setupRoutes() {
 	// Grupo de rotas para a API
@@ -152,6 +161,128 @@ func (s *Server) setupRoutes() {
 			})
 		})
 
+		// Rota do catálogo de mensagens da interface web
+		// @Summary Catálogo de mensagens da interface web
+		// @Description Retorna as mensagens da interface web e as descrições dos códigos de erro no idioma informado em lang ou negociado pelo cabeçalho Accept-Language
+		// @Tags interface
+		// @Produce json
+		// @Param lang query string false "Idioma (pt-BR ou en)"
+		// @Success 200 {object} MessagesResponse
+		// @Router /api/i18n/messages [get]
+		apiGroup.GET("/i18n/messages", s.handleMessages)
+
+		// Demais rotas exigem uma chave de API (cabeçalho X-API-Key ou Authorization: Bearer)
+		authGroup := apiGroup.Group("", s.authenticate)
+
+		// Rotas de administração das chaves de API
+		// @Summary Lista as chaves de API
+		// @Description Retorna as chaves de API cadastradas, sem os segredos
+		// @Tags admin
+		// @Produce json
+		// @Security ApiKeyAuth
+		// @Success 200 {object} APIKeyListResponse
+		// @Failure 401 {object} ErrorResponse
+		// @Failure 403 {object} ErrorResponse
+		// @Failure 500 {object} ErrorResponse
+		// @Router /api/admin/keys [get]
+		adminGroup := authGroup.Group("/admin", requireGlobalAdmin)
+		adminGroup.GET("/keys", s.handleListAPIKeys)
+
+		// @Summary Cria uma chave de API
+		// @Description Cria uma chave vinculada a uma conta e a um papel. O segredo é retornado apenas nesta resposta; somente seu hash é armazenado.
+		// @Tags admin
+		// @Accept json
+		// @Produce json
+		// @Security ApiKeyAuth
+		// @Param request body APIKeyRequest true "Dados da chave"
+		// @Success 201 {object} APIKeyResponse
+		// @Failure 400 {object} ErrorResponse
+		// @Failure 401 {object} ErrorResponse
+		// @Failure 403 {object} ErrorResponse
+		// @Failure 500 {object} ErrorResponse
+		// @Router /api/admin/keys [post]
+		adminGroup.POST("/keys", s.handleCreateAPIKey)
+
+		// @Summary Revoga uma chave de API
+		// @Description Remove a chave de API, que deixa de ser aceita imediatamente
+		// @Tags admin
+		// @Produce json
+		// @Security ApiKeyAuth
+		// @Param id path string true "ID da chave"
+		// @Success 200 {object} APIKeyResponse
+		// @Failure 401 {object} ErrorResponse
+		// @Failure 403 {object} ErrorResponse
+		// @Failure 404 {object} ErrorResponse
+		// @Failure 500 {object} ErrorResponse
+		// @Router /api/admin/keys/{id} [delete]
+		adminGroup.DELETE("/keys/:id", s.handleDeleteAPIKey)
+
+		// Rotas de cadastro de contas
+		// @Summary Lista as contas
+		// @Description Retorna as contas cadastradas, sem expor os tokens da Netlify
+		// @Tags accounts
+		// @Produce json
+		// @Success 200 {object} AccountListResponse
+		// @Failure 500 {object} ErrorResponse
+		// @Security ApiKeyAuth
+		// @Router /api/accounts [get]
+		authGroup.GET("/accounts", requireGlobalAdmin, s.handleListAccounts)
+
+		// @Summary Cria uma conta
+		// @Description Cadastra uma conta com seu próprio token da Netlify, prefixo no S3, domínio base e idioma das mensagens
+		// @Tags accounts
+		// @Accept json
+		// @Produce json
+		// @Param request body AccountRequest true "Dados da conta"
+		// @Success 201 {object} AccountResponse
+		// @Failure 400 {object} ErrorResponse
+		// @Failure 409 {object} ErrorResponse
+		// @Failure 500 {object} ErrorResponse
+		// @Security ApiKeyAuth
+		// @Router /api/accounts [post]
+		authGroup.POST("/accounts", requireGlobalAdmin, s.handleCreateAccount)
+
+		// Grupo de rotas de uma conta: todas as operações usam o token e as configurações da conta
+		accountGroup := authGroup.Group("/accounts/:account", requireAccountAccess, s.loadAccount)
+
+		// @Summary Consulta uma conta
+		// @Description Retorna os dados de uma conta, sem expor o token da Netlify
+		// @Tags accounts
+		// @Produce json
+		// @Param account path string true "ID da conta"
+		// @Success 200 {object} AccountResponse
+		// @Failure 404 {object} ErrorResponse
+		// @Security ApiKeyAuth
+		// @Router /api/accounts/{account} [get]
+		accountGroup.GET("", requireRole(RoleViewer), s.handleGetAccount)
+
+		// @Summary Atualiza uma conta
+		// @Description Atualiza o nome, o token da Netlify, o prefixo no S3, o domínio base ou o idioma de uma conta. Campos vazios são mantidos.
+		// @Tags accounts
+		// @Accept json
+		// @Produce json
+		// @Param account path string true "ID da conta"
+		// @Param request body AccountRequest true "Dados a atualizar"
+		// @Success 200 {object} AccountResponse
+		// @Failure 400 {object} ErrorResponse
+		// @Failure 404 {object} ErrorResponse
+		// @Failure 500 {object} ErrorResponse
+		// @Security ApiKeyAuth
+		// @Router /api/accounts/{account} [put]
+		accountGroup.PUT("", requireRole(RoleAdmin), s.handleUpdateAccount)
+
+		// @Summary Remove uma conta
+		// @Description Remove a conta do cadastro local. Os sites da conta na Netlify não são alterados.
+		// @Tags accounts
+		// @Produce json
+		// @Param account path string true "ID da conta"
+		// @Success 200 {object} AccountResponse
+		// @Failure 404 {object} ErrorResponse
+		// @Failure 500 {object} ErrorResponse
+		// @Security ApiKeyAuth
+		// @Router /api/accounts/{account} [delete]
+		accountGroup.DELETE("", requireGlobalAdmin, s.handleDeleteAccount)
+
 		// As rotas de deploy foram removidas conforme solicitado
 
 		// Rota para deploy de site
@@ -160,18 +291,92 @@ func (s *Server) setupRoutes() {
 		// @Tags deploy
 		// @Accept multipart/form-data
 		// @Produce json
+		// @Param account path string true "ID da conta"
 		// @Param site_id formData string false "ID do site na Netlify para atualização (opcional)"
 		// @Param site_name formData string true "Nome do site para teste"
 		// @Param description formData string false "Descrição do site para teste"
 		// @Param cleanup_after formData bool false "Remover o site após o teste"
 		// @Param custom_domain formData string false "Domínio personalizado para o site (opcional)"
-		// @Param file formData file false "Arquivo HTML para deploy (opcional)"
-		// @Param folder_path formData string false "Caminho da pasta local para deploy (opcional)"
-		// @Success 200 {object} TestDeployResponse
-		// @Failure 400 {object} TestDeployResponse
-		// @Failure 500 {object} TestDeployResponse
-		// @Router /api/deploy/site [post]
-		apiGroup.POST("/deploy/site", s.handleTestDeploy)
+		// @Param file formData file false "Arquivo HTML, ou arquivo .zip, .tar ou .tar.gz com o site, para deploy (opcional)"
+		// @Param folder_path formData string false "Caminho da pasta do site, relativo à pasta da conta em web/accounts (opcional)"
+		// @Param files[] formData file false "Arquivos do site, com o caminho relativo no nome (ex: css/pop-up.css); têm prioridade sobre as demais origens (opcional)"
+		// @Param draft formData bool false "Criar o deploy como rascunho, sem publicá-lo em produção (a URL de pré-visualização fica em deploy_url do job)"
+		// @Param dry_run formData bool false "Apenas comparar a origem com o deploy publicado do site (exige site_id), sem criar o deploy"
+		// @Success 202 {object} JobResponse
+		// @Success 200 {object} DryRunResponse
+		// @Failure 400 {object} ErrorResponse
+		// @Failure 404 {object} ErrorResponse
+		// @Failure 500 {object} ErrorResponse
+		// @Failure 503 {object} ErrorResponse
+		// @Security ApiKeyAuth
+		// @Router /api/accounts/{account}/deploy/site [post]
+		accountGroup.POST("/deploy/site", requireRole(RoleDeployer), s.handleTestDeploy)
+
+		// Rota para deploy a partir de um caminho do bucket S3
+		// @Summary Realiza o deploy de um caminho do bucket S3
+		// @Description Enfileira o deploy dos arquivos de um caminho do bucket S3. Os arquivos são lidos diretamente do bucket e apenas os que a Netlify ainda não possui são enviados.
+		// @Tags deploy
+		// @Accept multipart/form-data
+		// @Produce json
+		// @Param account path string true "ID da conta"
+		// @Param site_id formData string false "ID do site na Netlify para atualização (opcional)"
+		// @Param site_name formData string true "Nome do site (usado como subdomínio)"
+		// @Param s3_path formData string true "Caminho no bucket S3"
+		// @Param custom_domain formData string false "Domínio personalizado para o site (opcional)"
+		// @Param draft formData bool false "Criar o deploy como rascunho, sem publicá-lo em produção (a URL de pré-visualização fica em deploy_url do job)"
+		// @Param dry_run formData bool false "Apenas comparar os arquivos do caminho S3 com o deploy publicado do site (exige site_id), sem criar o deploy"
+		// @Success 202 {object} JobResponse
+		// @Success 200 {object} DryRunResponse
+		// @Failure 400 {object} ErrorResponse
+		// @Failure 404 {object} ErrorResponse
+		// @Failure 503 {object} ErrorResponse
+		// @Security ApiKeyAuth
+		// @Router /api/accounts/{account}/deploy/s3 [post]
+		accountGroup.POST("/deploy/s3", requireRole(RoleDeployer), s.handleDeployFromS3)
+
+		// Rota para consultar o andamento de um job de deploy
+		// @Summary Consulta o andamento de um deploy
+		// @Description Retorna a fase, os contadores de progresso e a URL final de um job de deploy
+		// @Tags deploy
+		// @Produce json
+		// @Param account path string true "ID da conta"
+		// @Param id path string true "ID do job"
+		// @Success 200 {object} JobStatus
+		// @Failure 404 {object} ErrorResponse
+		// @Security ApiKeyAuth
+		// @Router /api/accounts/{account}/jobs/{id} [get]
+		accountGroup.GET("/jobs/:id", requireRole(RoleViewer), s.handleGetJob)
+
+		// Rota para acompanhar um deploy em tempo real (Server-Sent Events)
+		// @Summary Acompanha um deploy em tempo real
+		// @Description Transmite via Server-Sent Events as fases do deploy, o hash e o upload de cada arquivo, as mudanças de estado na Netlify e a URL final. O ID pode ser o do job ou o do deploy na Netlify. O evento done encerra a transmissão; envie Last-Event-ID para retomar de onde parou.
+		// @Tags deploy
+		// @Produce text/event-stream
+		// @Param account path string true "ID da conta"
+		// @Param id path string true "ID do job ou do deploy na Netlify"
+		// @Param Last-Event-ID header string false "ID do último evento recebido"
+		// @Success 200 {object} JobEvent
+		// @Failure 404 {object} ErrorResponse
+		// @Security ApiKeyAuth
+		// @Router /api/accounts/{account}/deploys/{id}/events [get]
+		accountGroup.GET("/deploys/:id/events", requireRole(RoleViewer), s.handleDeployEvents)
+
+		// Rota para publicar todos os sites de uma conta
+		// @Summary Publica todos os sites de uma conta
+		// @Description Percorre as pastas de sites da conta em web/accounts, cria os sites ausentes na Netlify e publica todos em paralelo. O relatório por site fica disponível no job.
+		// @Tags deploy
+		// @Accept json
+		// @Produce json
+		// @Param account path string true "ID da conta"
+		// @Param request body BatchDeployRequest false "Pasta da conta e opções do lote"
+		// @Success 202 {object} JobResponse
+		// @Failure 400 {object} ErrorResponse
+		// @Failure 403 {object} ErrorResponse
+		// @Failure 500 {object} ErrorResponse
+		// @Failure 503 {object} ErrorResponse
+		// @Security ApiKeyAuth
+		// @Router /api/accounts/{account}/batch/deploy [post]
+		accountGroup.POST("/batch/deploy", requireRole(RoleDeployer), s.handleBatchDeploy)
 
 		// Rota para adicionar domínio personalizado
 		// @Summary Adiciona um domínio personalizado a um site
@@ -179,12 +384,16 @@ func (s *Server) setupRoutes() {
 		// @Tags domains
 		// @Accept json
 		// @Produce json
+		// @Param account path string true "ID da conta"
 		// @Param request body DomainRequest true "Dados do domínio a ser adicionado"
 		// @Success 200 {object} DomainResponse
-		// @Failure 400 {object} DomainResponse
-		// @Failure 500 {object} DomainResponse
-		// @Router /api/domains/add [post]
-		apiGroup.POST("/domains/add", s.handleAddDomain)
+		// @Failure 400 {object} ErrorResponse
+		// @Failure 404 {object} ErrorResponse
+		// @Failure 409 {object} ErrorResponse
+		// @Failure 500 {object} ErrorResponse
+		// @Security ApiKeyAuth
+		// @Router /api/accounts/{account}/domains/add [post]
+		accountGroup.POST("/domains/add", requireRole(RoleDomainAdmin), s.handleAddDomain)
 
 		// Rota para remover domínio personalizado
 		// @Summary Remove um domínio personalizado de um site
@@ -192,12 +401,15 @@ func (s *Server) setupRoutes() {
 		// @Tags domains
 		// @Accept json
 		// @Produce json
+		// @Param account path string true "ID da conta"
 		// @Param request body DomainRequest true "Dados do domínio a ser removido"
 		// @Success 200 {object} DomainResponse
-		// @Failure 400 {object} DomainResponse
-		// @Failure 500 {object} DomainResponse
-		// @Router /api/domains/remove [post]
-		apiGroup.POST("/domains/remove", s.handleRemoveDomain)
+		// @Failure 400 {object} ErrorResponse
+		// @Failure 404 {object} ErrorResponse
+		// @Failure 500 {object} ErrorResponse
+		// @Security ApiKeyAuth
+		// @Router /api/accounts/{account}/domains/remove [post]
+		accountGroup.POST("/domains/remove", requireRole(RoleDomainAdmin), s.handleRemoveDomain)
 
 		// Rota para definir domínio como principal
 		// @Summary Define um domínio como o domínio principal
@@ -205,12 +417,15 @@ func (s *Server) setupRoutes() {
 		// @Tags domains
 		// @Accept json
 		// @Produce json
+		// @Param account path string true "ID da conta"
 		// @Param request body DomainRequest true "Dados do domínio a ser definido como principal"
 		// @Success 200 {object} DomainResponse
-		// @Failure 400 {object} DomainResponse
-		// @Failure 500 {object} DomainResponse
-		// @Router /api/domains/set-default [post]
-		apiGroup.POST("/domains/set-default", s.handleSetDefaultDomain)
+		// @Failure 400 {object} ErrorResponse
+		// @Failure 404 {object} ErrorResponse
+		// @Failure 500 {object} ErrorResponse
+		// @Security ApiKeyAuth
+		// @Router /api/accounts/{account}/domains/set-default [post]
+		accountGroup.POST("/domains/set-default", requireRole(RoleDomainAdmin), s.handleSetDefaultDomain)
 
 		// Rota para remover domínio principal
 		// @Summary Remove o domínio principal de um site
@@ -218,32 +433,80 @@ func (s *Server) setupRoutes() {
 		// @Tags domains
 		// @Accept json
 		// @Produce json
+		// @Param account path string true "ID da conta"
 		// @Param request body DomainRequest true "ID do site a ter o domínio principal removido"
 		// @Success 200 {object} DomainResponse
-		// @Failure 400 {object} DomainResponse
-		// @Failure 500 {object} DomainResponse
-		// @Router /api/domains/remove-primary [post]
-		apiGroup.POST("/domains/remove-primary", s.handleRemovePrimaryDomain)
+		// @Failure 400 {object} ErrorResponse
+		// @Failure 404 {object} ErrorResponse
+		// @Failure 500 {object} ErrorResponse
+		// @Security ApiKeyAuth
+		// @Router /api/accounts/{account}/domains/remove-primary [post]
+		accountGroup.POST("/domains/remove-primary", requireRole(RoleDomainAdmin), s.handleRemovePrimaryDomain)
+
+		// Rota para verificar o estado dos domínios de um site
+		// @Summary Estado dos domínios de um site
+		// @Description Consulta o DNS (CNAME/A e TXT de verificação) e o certificado TLS de cada domínio do site, retornando a etapa de cada um (pending_dns, verified, cert_provisioning, live)
+		// @Tags domains
+		// @Produce json
+		// @Param account path string true "ID da conta"
+		// @Param id path string true "ID do site na Netlify"
+		// @Success 200 {object} DomainsStatusResponse
+		// @Failure 404 {object} ErrorResponse
+		// @Failure 500 {object} ErrorResponse
+		// @Security ApiKeyAuth
+		// @Router /api/accounts/{account}/sites/{id}/domains/status [get]
+		accountGroup.GET("/sites/:id/domains/status", requireRole(RoleViewer), s.handleDomainsStatus)
+
+		// Rota para solicitar o certificado TLS de um site
+		// @Summary Solicita o certificado TLS de um site
+		// @Description Solicita à Netlify a emissão do certificado Let's Encrypt para os domínios do site. Acompanhe a emissão pelo estado dos domínios.
+		// @Tags domains
+		// @Produce json
+		// @Param account path string true "ID da conta"
+		// @Param id path string true "ID do site na Netlify"
+		// @Success 200 {object} SSLProvisionResponse
+		// @Failure 404 {object} ErrorResponse
+		// @Failure 500 {object} ErrorResponse
+		// @Security ApiKeyAuth
+		// @Router /api/accounts/{account}/sites/{id}/ssl/provision [post]
+		accountGroup.POST("/sites/:id/ssl/provision", requireRole(RoleDomainAdmin), s.handleProvisionSSL)
 
 		// Rota para testar conexão com a API da Netlify
 		// @Summary Testa a conexão com a API da Netlify
-		// @Description Testa a conexão com a API da Netlify e exibe informações sobre o token
+		// @Description Confirma que o token da Netlify da conta é aceito pela API da Netlify. Apenas o token da conta é testado; o valor nunca é exibido.
 		// @Tags netlify
 		// @Accept json
 		// @Produce json
+		// @Param account path string true "ID da conta"
 		// @Success 200 {object} map[string]interface{}
-		// @Failure 500 {object} map[string]interface{}
-		// @Router /api/test/netlify/connection [get]
-		apiGroup.GET("/test/netlify/connection", s.handleTestNetlifyConnection)
-
-		// Rota para exibir logs da aplicação
-		// @Summary Exibe os logs da aplicação
-		// @Description Retorna os logs da aplicação em formato texto
+		// @Failure 502 {object} ErrorResponse
+		// @Failure 500 {object} ErrorResponse
+		// @Security ApiKeyAuth
+		// @Router /api/accounts/{account}/test/netlify/connection [get]
+		accountGroup.GET("/test/netlify/connection", requireRole(RoleViewer), s.handleTestNetlifyConnection)
+
+		// Rota para consultar os logs da aplicação
+		// @Summary Consulta os logs da aplicação
+		// @Description Retorna os registros de log (JSON), do mais recente ao mais antigo, com filtros por nível, período, requisição, conta, site e deploy e paginação por cursor. Inclui os arquivos rotacionados.
 		// @Tags logs
-		// @Produce text
-		// @Success 200 {string} string "Logs da aplicação"
-		// @Router /api/test/logs [get]
-		apiGroup.GET("/test/logs", s.handleViewLogs)
+		// @Produce json
+		// @Param level query string false "Nível mínimo (debug, info, warn, error; padrão debug)"
+		// @Param from query string false "Início do período (RFC 3339, ex: 2025-05-01T00:00:00Z)"
+		// @Param to query string false "Fim do período (RFC 3339)"
+		// @Param request_id query string false "ID da requisição"
+		// @Param account query string false "ID da conta"
+		// @Param site_id query string false "ID do site na Netlify"
+		// @Param deploy_id query string false "ID do deploy na Netlify"
+		// @Param cursor query string false "Cursor da próxima página (next_cursor da resposta anterior)"
+		// @Param limit query int false "Registros por página (padrão 100, máximo 1000)"
+		// @Success 200 {object} LogsResponse
+		// @Failure 400 {object} ErrorResponse
+		// @Failure 401 {object} ErrorResponse
+		// @Failure 403 {object} ErrorResponse
+		// @Failure 500 {object} ErrorResponse
+		// @Security ApiKeyAuth
+		// @Router /api/logs [get]
+		authGroup.GET("/logs", requireGlobalAdmin, s.handleListLogs)
 
 		// Rota para listar sites da Netlify
 		// @Summary Lista todos os sites do usuário na Netlify
@@ -251,10 +514,107 @@ func (s *Server) setupRoutes() {
 		// @Tags netlify
 		// @Accept json
 		// @Produce json
+		// @Param account path string true "ID da conta"
 		// @Success 200 {object} map[string]interface{}
-		// @Failure 500 {object} map[string]interface{}
-		// @Router /api/sites [get]
-		apiGroup.GET("/sites", s.handleListSites)
+		// @Failure 500 {object} ErrorResponse
+		// @Security ApiKeyAuth
+		// @Router /api/accounts/{account}/sites [get]
+		accountGroup.GET("/sites", requireRole(RoleViewer), s.handleListSites)
+
+		// Rotas de gerenciamento de um site
+		// @Summary Consulta um site
+		// @Description Retorna todos os detalhes do site na Netlify e a exclusão agendada, quando o site é temporário
+		// @Tags sites
+		// @Produce json
+		// @Param account path string true "ID da conta"
+		// @Param id path string true "ID do site na Netlify"
+		// @Success 200 {object} SiteResponse
+		// @Failure 404 {object} ErrorResponse
+		// @Failure 500 {object} ErrorResponse
+		// @Security ApiKeyAuth
+		// @Router /api/accounts/{account}/sites/{id} [get]
+		accountGroup.GET("/sites/:id", requireRole(RoleViewer), s.handleGetSite)
+
+		// @Summary Renomeia um site
+		// @Description Altera o nome do site na Netlify, o que também altera seu subdomínio .netlify.app
+		// @Tags sites
+		// @Accept json
+		// @Produce json
+		// @Param account path string true "ID da conta"
+		// @Param id path string true "ID do site na Netlify"
+		// @Param request body RenameSiteRequest true "Novo nome do site"
+		// @Success 200 {object} SiteResponse
+		// @Failure 400 {object} ErrorResponse
+		// @Failure 404 {object} ErrorResponse
+		// @Failure 500 {object} ErrorResponse
+		// @Security ApiKeyAuth
+		// @Router /api/accounts/{account}/sites/{id} [patch]
+		accountGroup.PATCH("/sites/:id", requireRole(RoleDeployer), s.handleRenameSite)
+
+		// @Summary Exclui um site
+		// @Description Exclui o site da Netlify e cancela a exclusão agendada, se houver
+		// @Tags sites
+		// @Produce json
+		// @Param account path string true "ID da conta"
+		// @Param id path string true "ID do site na Netlify"
+		// @Success 200 {object} SiteResponse
+		// @Failure 404 {object} ErrorResponse
+		// @Failure 500 {object} ErrorResponse
+		// @Security ApiKeyAuth
+		// @Router /api/accounts/{account}/sites/{id} [delete]
+		accountGroup.DELETE("/sites/:id", requireRole(RoleAdmin), s.handleDeleteSite)
+
+		// Rota para consultar o histórico de deploys de um site
+		// @Summary Lista o histórico de deploys de um site
+		// @Description Retorna os deploys registrados para o site, do mais recente para o mais antigo, com paginação e filtro por estado
+		// @Tags deploy
+		// @Produce json
+		// @Param account path string true "ID da conta"
+		// @Param id path string true "ID do site na Netlify"
+		// @Param state query string false "Filtrar pelo estado do deploy (ex: ready, error)"
+		// @Param page query int false "Página (padrão 1)"
+		// @Param per_page query int false "Itens por página (padrão 20, máximo 100)"
+		// @Success 200 {object} DeployHistoryResponse
+		// @Failure 400 {object} ErrorResponse
+		// @Failure 500 {object} ErrorResponse
+		// @Security ApiKeyAuth
+		// @Router /api/accounts/{account}/sites/{id}/deploys [get]
+		accountGroup.GET("/sites/:id/deploys", requireRole(RoleViewer), s.handleListSiteDeploys)
+
+		// Rota para restaurar um deploy anterior de um site
+		// @Summary Restaura um deploy anterior de um site
+		// @Description Publica novamente um deploy pronto do site, informado pelo ID ou como "previous" para o deploy publicado antes do atual, e aguarda a publicação
+		// @Tags deploy
+		// @Accept json
+		// @Produce json
+		// @Param account path string true "ID da conta"
+		// @Param id path string true "ID do site na Netlify"
+		// @Param request body RollbackRequest true "Deploy a ser restaurado"
+		// @Success 200 {object} RollbackResponse
+		// @Failure 400 {object} ErrorResponse
+		// @Failure 404 {object} ErrorResponse
+		// @Failure 409 {object} ErrorResponse
+		// @Failure 500 {object} ErrorResponse
+		// @Security ApiKeyAuth
+		// @Router /api/accounts/{account}/sites/{id}/rollback [post]
+		accountGroup.POST("/sites/:id/rollback", requireRole(RoleDeployer), s.handleRollbackSite)
+
+		// Rota para publicar um deploy em rascunho
+		// @Summary Publica um rascunho em produção
+		// @Description Promove a produção um deploy criado com draft=true, depois de revisado na URL de pré-visualização, e aguarda a publicação
+		// @Tags deploy
+		// @Produce json
+		// @Param account path string true "ID da conta"
+		// @Param id path string true "ID do site na Netlify"
+		// @Param deployID path string true "ID do deploy em rascunho"
+		// @Success 200 {object} PublishResponse
+		// @Failure 400 {object} ErrorResponse
+		// @Failure 404 {object} ErrorResponse
+		// @Failure 409 {object} ErrorResponse
+		// @Failure 500 {object} ErrorResponse
+		// @Security ApiKeyAuth
+		// @Router /api/accounts/{account}/sites/{id}/deploys/{deployID}/publish [post]
+		accountGroup.POST("/sites/:id/deploys/:deployID/publish", requireRole(RoleDeployer), s.handlePublishDeploy)
 	}
 
 	// Servir arquivos estáticos para a interface web
@@ -270,99 +630,15 @@ func (s *Server) setupRoutes() {
 	s.router.GET("/", func(c *gin.Context) {
 		c.Redirect(http.StatusMovedPermanently, "/static")
 	})
-}
-
-// handleDeploy processa uma requisição de deploy (rota desativada)
-func (s *Server) handleDeploy(c *gin.Context) {
-	var req DeployRequest
-
-	// Fazer log da requisição recebida
-	log.Printf("[API] Recebida requisição de deploy: %+v", c.Request)
-	
-	// Fazer bind dos dados da requisição
-	if err := c.ShouldBindJSON(&req); err != nil {
-		log.Printf("[API] Erro ao processar JSON da requisição: %v", err)
-		c.JSON(http.StatusBadRequest, DeployResponse{
-			Success: false,
-			Message: fmt.Sprintf("Erro ao processar requisição: %v", err),
-		})
-		return
-	}
-
-	log.Printf("[API] Dados de deploy validados: username=%s, customDomain=%s, s3Path=%s", req.Username, req.CustomDomain, req.S3Path)
-
-	// Configurar o cliente da Netlify
-	netlifyClient, err := netlify.NewClient(s.config)
-	if err != nil {
-		log.Printf("[API] Erro ao criar cliente Netlify: %v", err)
-		c.JSON(http.StatusInternalServerError, DeployResponse{
-			Success: false,
-			Message: fmt.Sprintf("Erro interno do servidor: %v", err),
-		})
-		return
-	}
-	
-	// Configurar parâmetros de deploy no objeto config
-	if err := s.config.SetDeployParams(req.Username, req.CustomDomain, req.S3Path); err != nil {
-		log.Printf("[API] Erro ao configurar parâmetros de deploy: %v", err)
-		c.JSON(http.StatusBadRequest, DeployResponse{
-			Success: false,
-			Message: fmt.Sprintf("Erro nos parâmetros de deploy: %v", err),
-		})
-		return
-	}
-
-	// Verificar se o site já existe
-	site, exists, err := netlifyClient.VerifySite(c.Request.Context())
-	if err != nil {
-		log.Printf("[API] Erro ao verificar site na Netlify: %v", err)
-		c.JSON(http.StatusInternalServerError, DeployResponse{
-			Success: false,
-			Message: fmt.Sprintf("Erro ao verificar site na Netlify: %v", err),
-		})
-		return
-	}
-
-	// Se o site não existir, criá-lo
-	if !exists {
-		log.Printf("[API] Site não encontrado. Criando novo site para %s", req.Username)
-		site, err = netlifyClient.CreateSite(c.Request.Context())
-		if err != nil {
-			log.Printf("[API] Erro ao criar site na Netlify: %v", err)
-			c.JSON(http.StatusInternalServerError, DeployResponse{
-				Success: false,
-				Message: fmt.Sprintf("Erro ao criar site na Netlify: %v", err),
-			})
-			return
-		}
-		log.Printf("[API] Site criado com sucesso: %s", site.CustomDomain)
-	} else {
-		log.Printf("[API] Site existente encontrado: %s", site.CustomDomain)
-	}
 
-	// Configurar cliente S3
-	_, err = aws.NewS3Client(s.config)
-	if err != nil {
-		log.Printf("[API] Erro ao criar cliente S3: %v", err)
-		c.JSON(http.StatusInternalServerError, DeployResponse{
-			Success: false,
-			Message: fmt.Sprintf("Erro interno do servidor: %v", err),
-		})
-		return
-	}
-	
-	// TODO: Implementar o deploy efetivo dos arquivos
-	// Por enquanto, apenas retornamos sucesso
-	log.Printf("[API] Deploy iniciado com sucesso para %s.%s", req.Username, s.config.BaseDomain)
-
-	// Retornar resposta de sucesso
-	c.JSON(http.StatusAccepted, DeployResponse{
-		Success:      true,
-		Message:      "Deploy iniciado com sucesso",
-		Subdomain:    fmt.Sprintf("%s.%s", req.Username, s.config.BaseDomain),
-		CustomDomain: req.CustomDomain,
-		// Deploy ID e URL do site seriam definidos aqui em uma implementação real
-	})
+	// Métricas no formato do Prometheus
+	// @Summary Métricas da aplicação
+	// @Description Expõe, no formato de texto do Prometheus, as requisições e a latência da API por rota, os deploys por origem e resultado, os bytes enviados, as chamadas à Netlify por operação e status, os downloads do S3 e os jobs em execução
+	// @Tags status
+	// @Produce plain
+	// @Success 200 {string} string "Métricas"
+	// @Router /metrics [get]
+	s.router.GET("/metrics", gin.WrapH(metrics.Handler()))
 }
 
 // handleTestDeploy processa uma requisição de deploy de site na Netlify
@@ -371,20 +647,27 @@ func (s *Server) handleDeploy(c *gin.Context) {
 // @Tags deploy
 // @Accept multipart/form-data
 // @Produce json
+// @Param account path string true "ID da conta"
 // @Param site_id formData string false "ID do site na Netlify para atualização (opcional)"
 // @Param site_name formData string true "Nome do site para teste"
 // @Param description formData string false "Descrição do site para teste"
 // @Param cleanup_after formData bool false "Remover o site após o teste"
 // @Param custom_domain formData string false "Domínio personalizado para o site (opcional)"
-// @Param file formData file false "Arquivo HTML para deploy (opcional)"
-// @Param folder_path formData string false "Caminho da pasta local para deploy (opcional)"
-// @Success 200 {object} TestDeployResponse
-// @Failure 400 {object} TestDeployResponse
-// @Failure 500 {object} TestDeployResponse
-// @Router /api/deploy/site [post]
+// @Param file formData file false "Arquivo HTML, ou arquivo .zip, .tar ou .tar.gz com o site, para deploy (opcional)"
+// @Param folder_path formData string false "Caminho da pasta do site, relativo à pasta da conta em web/accounts (opcional)"
+// @Param files[] formData file false "Arquivos do site, com o caminho relativo no nome (ex: css/pop-up.css); têm prioridade sobre as demais origens (opcional)"
+// @Param draft formData bool false "Criar o deploy como rascunho, sem publicá-lo em produção (a URL de pré-visualização fica em deploy_url do job)"
+// @Param dry_run formData bool false "Apenas comparar a origem com o deploy publicado do site (exige site_id), sem criar o deploy"
+// @Success 202 {object} JobResponse
+// @Success 200 {object} DryRunResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Failure 503 {object} ErrorResponse
+// @Security ApiKeyAuth
+// @Router /api/accounts/{account}/deploy/site [post]
 func (s *Server) handleTestDeploy(c *gin.Context) {
 	// Processar upload de arquivo
-	log.Printf("[TEST-API] Recebida requisição para teste de deploy: %s", c.Request.URL.Path)
 	s.handleMultipartTestDeploy(c)
 }
 
@@ -394,12 +677,13 @@ func (s *Server) handleMultipartTestDeploy(c *gin.Context) {
 	siteID := c.PostForm("site_id")
 	siteName := c.PostForm("site_name")
 	if siteName == "" {
-		c.JSON(http.StatusBadRequest, gin.H{
-			"success": false,
-			"message": "Nome do site é obrigatório",
-		})
+		respondInvalidRequest(c, nil, "deploy.site_name_required")
 		return
 	}
+	ctx := c.Request.Context()
+	if siteID != "" {
+		ctx = logWith(c, "site_id", siteID)
+	}
 
 	description := c.PostForm("description")
 	cleanupAfterStr := c.PostForm("cleanup_after")
@@ -411,42 +695,90 @@ func (s *Server) handleMultipartTestDeploy(c *gin.Context) {
 	if cleanupAfterStr != "" {
 		cleanupAfter, _ = strconv.ParseBool(cleanupAfterStr)
 	}
+	draft, _ := strconv.ParseBool(c.PostForm("draft"))
+	dryRun, _ := strconv.ParseBool(c.PostForm("dry_run"))
+	if dryRun && siteID == "" {
+		respondInvalidRequest(c, nil, "deploy.dry_run_site_required")
+		return
+	}
 
 	folderPath := c.PostForm("folder_path")
 
-	// Verificar se o caminho da pasta foi fornecido e se existe
+	// Verificar se o caminho da pasta foi fornecido, se está dentro da pasta da conta e se existe
 	if folderPath != "" {
-		// Verificar se a pasta existe
-		if _, err := os.Stat(folderPath); os.IsNotExist(err) {
-			c.JSON(http.StatusBadRequest, gin.H{
-				"success": false,
-				"message": fmt.Sprintf("Pasta não encontrada: %s", folderPath),
-			})
+		resolved, err := accountFolder(s.config.AccountsPath, currentAccount(c).ID, folderPath)
+		if err != nil {
+			slog.WarnContext(ctx, "Pasta fora da pasta da conta", "folder_path", folderPath)
+			respondInvalidRequest(c, nil, "deploy.folder_invalid")
+			return
+		}
+		if _, err := os.Stat(resolved); os.IsNotExist(err) {
+			respondInvalidRequest(c, nil, "deploy.folder_not_found", folderPath)
 			return
 		}
+		folderPath = resolved
 	}
 
-	// Obter o arquivo enviado
-	file, err := c.FormFile("file")
-	if err != nil && folderPath == "" && testContent == "" {
+	// Gravar os vários arquivos do site enviados em files[] em uma pasta temporária, com os caminhos
+	// relativos; eles são publicados como pasta local, com prioridade sobre as demais origens
+	uploadDir, uploadRoot, uploaded, err := s.saveUploads(ctx, c)
+	if err != nil {
+		slog.WarnContext(ctx, "Erro ao processar arquivos enviados", "error", err)
+		respondError(c, err, "deploy.files_failed")
+		return
+	}
+	if uploadDir != "" {
+		folderPath = uploadRoot
+		testContent = ""
+	}
+
+	// Remover as pastas temporárias dos arquivos enviados se o deploy não for enfileirado
+	var archiveDir string
+	enqueued := false
+	defer func() {
+		if enqueued {
+			return
+		}
+		for _, dir := range []string{uploadDir, archiveDir} {
+			if dir != "" {
+				os.RemoveAll(dir)
+			}
+		}
+	}()
+
+	// Obter o arquivo enviado, ignorado quando há arquivos em files[]
+	var file *multipart.FileHeader
+	if uploadDir == "" {
+		file, _ = c.FormFile("file")
+	}
+	if file == nil && folderPath == "" && testContent == "" {
 		// Se não foi fornecido arquivo, pasta ou conteúdo de teste, continuamos com um conteúdo padrão
-		log.Printf("[TEST-API] Nenhum arquivo, pasta ou conteúdo fornecido para deploy, usando conteúdo padrão")
+		slog.InfoContext(ctx, "Nenhum arquivo, pasta ou conteúdo fornecido para deploy, usando conteúdo padrão")
 	}
 
 	// Variável para armazenar o conteúdo do arquivo em base64
 	var fileContentBase64 string
 
-	// Se um arquivo foi enviado, processar
-	if err == nil {
-		log.Printf("[TEST-API] Arquivo recebido: %s (%d bytes)", file.Filename, file.Size)
+	// Arquivos compactados são extraídos em uma pasta temporária e publicados como pasta local,
+	// com prioridade sobre o conteúdo de teste, assim como os demais arquivos enviados
+	if file != nil && archive.IsArchive(file.Filename) {
+		var root string
+		archiveDir, root, err = s.extractUpload(ctx, file)
+		if err != nil {
+			slog.WarnContext(ctx, "Erro ao extrair arquivo compactado", "file", file.Filename, "error", err)
+			respondError(c, err, "deploy.archive_failed", file.Filename)
+			return
+		}
+		folderPath = root
+		testContent = ""
+	} else if file != nil {
+		// Se um arquivo foi enviado, processar
+		slog.InfoContext(ctx, "Arquivo recebido", "file", file.Filename, "bytes", file.Size)
 
 		// Abrir o arquivo
 		src, err := file.Open()
 		if err != nil {
-			c.JSON(http.StatusInternalServerError, gin.H{
-				"success": false,
-				"message": fmt.Sprintf("Erro ao abrir arquivo: %v", err),
-			})
+			respondError(c, err, "deploy.open_file_failed")
 			return
 		}
 		defer src.Close()
@@ -454,30 +786,22 @@ func (s *Server) handleMultipartTestDeploy(c *gin.Context) {
 		// Ler o conteúdo do arquivo
 		fileContent, err := io.ReadAll(src)
 		if err != nil {
-			c.JSON(http.StatusInternalServerError, gin.H{
-				"success": false,
-				"message": fmt.Sprintf("Erro ao ler arquivo: %v", err),
-			})
+			respondError(c, err, "deploy.read_file_failed")
 			return
 		}
 
 		// Converter para base64
 		fileContentBase64 = base64.StdEncoding.EncodeToString(fileContent)
-		log.Printf("[TEST-API] Arquivo convertido para base64 (%d bytes)", len(fileContentBase64))
 	}
 
 	// Criar cliente Netlify
-	netlifyClient, err := netlify.NewClient(s.config)
+	netlifyClient, err := s.newNetlifyClient(s.accountConfig(c))
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{
-			"success": false,
-			"message": fmt.Sprintf("Erro ao criar cliente Netlify: %v", err),
-		})
+		respondError(c, err, "netlify.client_failed")
 		return
 	}
 
-	// Executar o teste de deploy
-	result, err := netlifyClient.ExecuteTestDeploy(context.Background(), netlify.TestDeployParams{
+	params := netlify.TestDeployParams{
 		SiteID:          siteID,
 		SiteName:        siteName,
 		Description:     description,
@@ -486,18 +810,118 @@ func (s *Server) handleMultipartTestDeploy(c *gin.Context) {
 		CustomDomain:    customDomain,
 		FileContent:     fileContentBase64,
 		FolderPath:      folderPath,
-	})
+		Draft:           draft,
+	}
+
+	// No dry-run, apenas comparar a origem com o deploy publicado, sem criar o site nem o deploy
+	if dryRun {
+		files, err := params.Manifest()
+		if err != nil {
+			respondError(c, err, "deploy.dry_run_failed")
+			return
+		}
 
+		ctx, cancel := context.WithTimeout(ctx, dryRunTimeout)
+		defer cancel()
+		respondDryRun(ctx, c, netlifyClient, siteID, files)
+		return
+	}
+
+	// Enfileirar o deploy para execução em segundo plano
+	job, err := s.jobs.Submit(ctx, currentAccount(c).ID, requestLang(c), func(ctx context.Context, job *Job) error {
+		switch {
+		case uploadDir != "":
+			defer os.RemoveAll(uploadDir)
+			ctx = netlify.WithDeploySource(ctx, store.SourceUpload, fmt.Sprintf("%d arquivos", uploaded))
+		case archiveDir != "":
+			defer os.RemoveAll(archiveDir)
+			ctx = netlify.WithDeploySource(ctx, store.SourceUpload, file.Filename)
+		}
+		return s.runTestDeploy(ctx, job, netlifyClient, params)
+	})
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{
-			"success": false,
-			"message": fmt.Sprintf("Erro ao executar teste de deploy: %v", err),
+		slog.ErrorContext(ctx, "Erro ao enfileirar deploy", "error", err)
+		respondError(c, err, "deploy.enqueue_failed")
+		return
+	}
+	enqueued = true
+
+	// Retornar o ID do job para acompanhamento
+	c.JSON(http.StatusAccepted, JobResponse{
+		Success:   true,
+		Message:   translate(c, "deploy.enqueued"),
+		JobID:     job.ID(),
+		StatusURL: accountPath(c, "/jobs/"+job.ID()),
+	})
+}
+
+// runTestDeploy executa o deploy de um job e aguarda a publicação na Netlify
+func (s *Server) runTestDeploy(ctx context.Context, job *Job, netlifyClient *netlify.Client, params netlify.TestDeployParams) error {
+	defer func() { job.SetRetries(netlifyClient.RetryStats()) }()
+
+	// Mesmo com erro no deploy, o resultado traz o site criado, que precisa ter a exclusão agendada
+	result, deployErr := netlifyClient.ExecuteTestDeploy(ctx, params)
+	if result == nil {
+		return fmt.Errorf("erro ao executar teste de deploy: %w", deployErr)
+	}
+
+	job.SetSite(&models.Site{ID: result.SiteID, URL: result.SiteURL})
+	job.SetMessage(job.translate("job.site_ready"))
+	ctx = logging.With(ctx, "site_id", result.SiteID)
+
+	// Agendar a exclusão do site temporário; o agendamento sobrevive a reinícios do servidor
+	if result.ExpiresAt != nil {
+		err := s.store.ScheduleSiteDeletion(&store.SiteExpiration{
+			SiteID:    result.SiteID,
+			SiteName:  params.SiteName,
+			AccountID: job.Status().AccountID,
+			ExpiresAt: *result.ExpiresAt,
 		})
+		if err != nil {
+			return fmt.Errorf("erro ao agendar exclusão do site temporário: %w", err)
+		}
+		slog.InfoContext(ctx, "Exclusão do site temporário agendada", "expires_at", result.ExpiresAt.Format(time.RFC3339))
+
+	}
+
+	if deployErr != nil {
+		return fmt.Errorf("erro ao executar teste de deploy: %w", deployErr)
+	}
+
+	if result.DeployID == "" {
+		return fmt.Errorf("a Netlify não retornou o ID do deploy do site %s", result.SiteID)
+	}
+	job.SetDeployID(result.DeployID)
+
+	// Aguardar a conclusão do deploy na Netlify
+	finalDeploy, err := netlifyClient.WaitForDeploy(ctx, result.DeployID, netlify.WithStateCallback(job.OnDeployState))
+	if err != nil {
+		return fmt.Errorf("erro ao aguardar deploy: %w", err)
+	}
+
+	job.Complete(finalDeploy)
+	return nil
+}
+
+// handleGetJob retorna o andamento de um job de deploy
+// @Summary Consulta o andamento de um deploy
+// @Description Retorna a fase, os contadores de progresso e a URL final de um job de deploy
+// @Tags deploy
+// @Produce json
+// @Param account path string true "ID da conta"
+// @Param id path string true "ID do job"
+// @Success 200 {object} JobStatus
+// @Failure 404 {object} ErrorResponse
+// @Security ApiKeyAuth
+// @Router /api/accounts/{account}/jobs/{id} [get]
+func (s *Server) handleGetJob(c *gin.Context) {
+	job, ok := s.jobs.Get(c.Param("id"))
+	if !ok || job.Status().AccountID != currentAccount(c).ID {
+		respondErrorCode(c, http.StatusNotFound, CodeJobNotFound, "job.not_found", c.Param("id"))
 		return
 	}
 
-	// Retornar resultado
-	c.JSON(http.StatusOK, result)
+	c.JSON(http.StatusOK, job.Status())
 }
 
 // handleAddDomain adiciona um domínio personalizado a um site
@@ -506,139 +930,67 @@ func (s *Server) handleMultipartTestDeploy(c *gin.Context) {
 // @Tags domains
 // @Accept json
 // @Produce json
+// @Param account path string true "ID da conta"
 // @Param request body DomainRequest true "Dados do domínio a ser adicionado"
 // @Success 200 {object} DomainResponse
-// @Failure 400 {object} DomainResponse
-// @Failure 500 {object} DomainResponse
-// @Router /api/domains/add [post]
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Security ApiKeyAuth
+// @Router /api/accounts/{account}/domains/add [post]
 func (s *Server) handleAddDomain(c *gin.Context) {
-	log.Printf("[handleAddDomain] Recebendo requisição para adicionar domínio")
-	
 	var req DomainRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		log.Printf("[handleAddDomain] Erro ao processar JSON: %v", err)
-		c.JSON(http.StatusBadRequest, DomainResponse{
-			Success: false,
-			Message: fmt.Sprintf("Erro ao processar requisição: %v", err),
-		})
+		respondInvalidRequest(c, err, "request.invalid_body")
 		return
 	}
 
-	log.Printf("[handleAddDomain] Requisição recebida: SiteID=%s, Domain=%s", req.SiteID, req.Domain)
-	
 	// Validar campos obrigatórios
 	if req.SiteID == "" || req.Domain == "" {
-		log.Printf("[handleAddDomain] Campos obrigatórios não informados")
-		c.JSON(http.StatusBadRequest, DomainResponse{
-			Success: false,
-			Message: "ID do site e domínio são obrigatórios",
-			SiteID:  req.SiteID,
-			Domain:  req.Domain,
-		})
+		respondInvalidRequest(c, nil, "domain.fields_required")
 		return
 	}
+	ctx := logWith(c, "site_id", req.SiteID, "domain", req.Domain)
 
 	// Criar cliente Netlify
-	log.Printf("[handleAddDomain] Criando cliente Netlify")
-	netlifyClient, err := netlify.NewClient(s.config)
+	netlifyClient, err := s.newNetlifyClient(s.accountConfig(c))
 	if err != nil {
-		log.Printf("[handleAddDomain] Erro ao criar cliente Netlify: %v", err)
-		c.JSON(http.StatusInternalServerError, DomainResponse{
-			Success: false,
-			Message: fmt.Sprintf("Erro ao criar cliente Netlify: %v", err),
-			SiteID:  req.SiteID,
-			Domain:  req.Domain,
-		})
+		slog.ErrorContext(ctx, "Erro ao criar cliente Netlify", "error", err)
+		respondError(c, err, "netlify.client_failed")
 		return
 	}
 
 	// Obter informações do site primeiro para verificar se tem domínio principal
-	sites, err := netlifyClient.ListSites(context.Background())
+	site, err := netlifyClient.GetSite(ctx, req.SiteID)
 	if err != nil {
-		log.Printf("[handleAddDomain] Erro ao listar sites: %v", err)
-		c.JSON(http.StatusInternalServerError, DomainResponse{
-			Success: false,
-			Message: fmt.Sprintf("Erro ao verificar sites: %v", err),
-			SiteID:  req.SiteID,
-			Domain:  req.Domain,
-		})
-		return
-	}
-
-	// Encontrar o site com o ID especificado
-	var siteFound bool
-	var customDomain string
-	for _, site := range sites {
-		if site.ID == req.SiteID {
-			siteFound = true
-			customDomain = site.CustomDomain
-			break
-		}
-	}
-
-	// Verificar se o site existe
-	if !siteFound {
-		log.Printf("[handleAddDomain] Site com ID %s não encontrado", req.SiteID)
-		c.JSON(http.StatusBadRequest, DomainResponse{
-			Success: false,
-			Message: fmt.Sprintf("Site com ID %s não encontrado", req.SiteID),
-			SiteID:  req.SiteID,
-			Domain:  req.Domain,
-		})
+		slog.ErrorContext(ctx, "Erro ao obter site", "error", err)
+		respondError(c, err, "site.check_failed")
 		return
 	}
 
 	// Verificar se o site já tem domínio principal configurado
-	if customDomain != "" {
-		log.Printf("[handleAddDomain] Site %s já tem domínio principal configurado: %s", req.SiteID, customDomain)
-		c.JSON(http.StatusBadRequest, DomainResponse{
-			Success: false,
-			Message: fmt.Sprintf("O site já tem um domínio principal configurado: %s. Remova o domínio principal antes de adicionar um alias.", customDomain),
-			SiteID:  req.SiteID,
-			Domain:  req.Domain,
-		})
+	if site.CustomDomain != "" {
+		slog.WarnContext(ctx, "Site já tem domínio principal configurado", "primary_domain", site.CustomDomain)
+		respondError(c, fmt.Errorf("%w: %s", netlify.ErrPrimaryDomainSet, site.CustomDomain), "domain.add_failed")
 		return
 	}
 
-	// Adicionar domínio
-	log.Printf("[handleAddDomain] Adicionando domínio %s ao site %s", req.Domain, req.SiteID)
-	
-	// Criar contexto com timeout
-	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	// Adicionar domínio, sem interromper a operação se o cliente desconectar
+	ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), 30*time.Second)
 	defer cancel()
-	
+
 	err = netlifyClient.AddCustomDomain(ctx, req.SiteID, req.Domain)
 	if err != nil {
-		log.Printf("[handleAddDomain] Erro ao adicionar domínio: %v", err)
-		
-		// Verificar mensagens de erro específicas
-		errMsg := err.Error()
-		if strings.Contains(errMsg, "domínio principal configurado") || 
-		   strings.Contains(errMsg, "domínio principal") || 
-		   strings.Contains(errMsg, "update domain aliases while primary") {
-		   
-			c.JSON(http.StatusBadRequest, DomainResponse{
-				Success: false,
-				Message: "O site já tem um domínio principal configurado. Remova o domínio principal antes de adicionar um alias.",
-				SiteID:  req.SiteID,
-				Domain:  req.Domain,
-			})
-			return
-		}
-		
-		c.JSON(http.StatusInternalServerError, DomainResponse{
-			Success: false,
-			Message: fmt.Sprintf("Erro ao adicionar domínio: %v", err),
-			SiteID:  req.SiteID,
-			Domain:  req.Domain,
-		})
+		slog.ErrorContext(ctx, "Erro ao adicionar domínio", "error", err)
+		respondError(c, err, "domain.add_failed")
 		return
 	}
 
-	log.Printf("[handleAddDomain] Domínio adicionado com sucesso")
+	slog.InfoContext(ctx, "Domínio adicionado")
 	c.JSON(http.StatusOK, DomainResponse{
 		Success: true,
-		Message: "Domínio adicionado com sucesso",
+		Message: translate(c, "domain.added"),
 		SiteID:  req.SiteID,
 		Domain:  req.Domain,
 	})
@@ -650,75 +1002,51 @@ func (s *Server) handleAddDomain(c *gin.Context) {
 // @Tags domains
 // @Accept json
 // @Produce json
+// @Param account path string true "ID da conta"
 // @Param request body DomainRequest true "Dados do domínio a ser removido"
 // @Success 200 {object} DomainResponse
-// @Failure 400 {object} DomainResponse
-// @Failure 500 {object} DomainResponse
-// @Router /api/domains/remove [post]
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Security ApiKeyAuth
+// @Router /api/accounts/{account}/domains/remove [post]
 func (s *Server) handleRemoveDomain(c *gin.Context) {
-	log.Printf("[handleRemoveDomain] Recebendo requisição para remover domínio")
-	
 	var req DomainRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		log.Printf("[handleRemoveDomain] Erro ao processar JSON: %v", err)
-		c.JSON(http.StatusBadRequest, DomainResponse{
-			Success: false,
-			Message: fmt.Sprintf("Erro ao processar requisição: %v", err),
-		})
+		respondInvalidRequest(c, err, "request.invalid_body")
 		return
 	}
 
-	log.Printf("[handleRemoveDomain] Requisição recebida: SiteID=%s, Domain=%s", req.SiteID, req.Domain)
-	
 	// Validar campos obrigatórios
 	if req.SiteID == "" || req.Domain == "" {
-		log.Printf("[handleRemoveDomain] Campos obrigatórios não informados")
-		c.JSON(http.StatusBadRequest, DomainResponse{
-			Success: false,
-			Message: "ID do site e domínio são obrigatórios",
-			SiteID:  req.SiteID,
-			Domain:  req.Domain,
-		})
+		respondInvalidRequest(c, nil, "domain.fields_required")
 		return
 	}
+	ctx := logWith(c, "site_id", req.SiteID, "domain", req.Domain)
 
 	// Criar cliente Netlify
-	log.Printf("[handleRemoveDomain] Criando cliente Netlify")
-	netlifyClient, err := netlify.NewClient(s.config)
+	netlifyClient, err := s.newNetlifyClient(s.accountConfig(c))
 	if err != nil {
-		log.Printf("[handleRemoveDomain] Erro ao criar cliente Netlify: %v", err)
-		c.JSON(http.StatusInternalServerError, DomainResponse{
-			Success: false,
-			Message: fmt.Sprintf("Erro ao criar cliente Netlify: %v", err),
-			SiteID:  req.SiteID,
-			Domain:  req.Domain,
-		})
+		slog.ErrorContext(ctx, "Erro ao criar cliente Netlify", "error", err)
+		respondError(c, err, "netlify.client_failed")
 		return
 	}
 
-	// Remover domínio
-	log.Printf("[handleRemoveDomain] Removendo domínio %s do site %s", req.Domain, req.SiteID)
-	
-	// Criar contexto com timeout
-	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	// Remover domínio, sem interromper a operação se o cliente desconectar
+	ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), 30*time.Second)
 	defer cancel()
-	
+
 	err = netlifyClient.RemoveCustomDomain(ctx, req.SiteID, req.Domain)
 	if err != nil {
-		log.Printf("[handleRemoveDomain] Erro ao remover domínio: %v", err)
-		c.JSON(http.StatusInternalServerError, DomainResponse{
-			Success: false,
-			Message: fmt.Sprintf("Erro ao remover domínio: %v", err),
-			SiteID:  req.SiteID,
-			Domain:  req.Domain,
-		})
+		slog.ErrorContext(ctx, "Erro ao remover domínio", "error", err)
+		respondError(c, err, "domain.remove_failed")
 		return
 	}
 
-	log.Printf("[handleRemoveDomain] Domínio removido com sucesso")
+	slog.InfoContext(ctx, "Domínio removido")
 	c.JSON(http.StatusOK, DomainResponse{
 		Success: true,
-		Message: "Domínio removido com sucesso",
+		Message: translate(c, "domain.removed"),
 		SiteID:  req.SiteID,
 		Domain:  req.Domain,
 	})
@@ -730,75 +1058,51 @@ func (s *Server) handleRemoveDomain(c *gin.Context) {
 // @Tags domains
 // @Accept json
 // @Produce json
+// @Param account path string true "ID da conta"
 // @Param request body DomainRequest true "Dados do domínio a ser definido como principal"
 // @Success 200 {object} DomainResponse
-// @Failure 400 {object} DomainResponse
-// @Failure 500 {object} DomainResponse
-// @Router /api/domains/set-default [post]
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Security ApiKeyAuth
+// @Router /api/accounts/{account}/domains/set-default [post]
 func (s *Server) handleSetDefaultDomain(c *gin.Context) {
-	log.Printf("[handleSetDefaultDomain] Recebendo requisição para definir domínio padrão")
-	
 	var req DomainRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		log.Printf("[handleSetDefaultDomain] Erro ao processar JSON: %v", err)
-		c.JSON(http.StatusBadRequest, DomainResponse{
-			Success: false,
-			Message: fmt.Sprintf("Erro ao processar requisição: %v", err),
-		})
+		respondInvalidRequest(c, err, "request.invalid_body")
 		return
 	}
 
-	log.Printf("[handleSetDefaultDomain] Requisição recebida: SiteID=%s, Domain=%s", req.SiteID, req.Domain)
-	
 	// Validar campos obrigatórios
 	if req.SiteID == "" || req.Domain == "" {
-		log.Printf("[handleSetDefaultDomain] Campos obrigatórios não informados")
-		c.JSON(http.StatusBadRequest, DomainResponse{
-			Success: false,
-			Message: "ID do site e domínio são obrigatórios",
-			SiteID:  req.SiteID,
-			Domain:  req.Domain,
-		})
+		respondInvalidRequest(c, nil, "domain.fields_required")
 		return
 	}
+	ctx := logWith(c, "site_id", req.SiteID, "domain", req.Domain)
 
 	// Criar cliente Netlify
-	log.Printf("[handleSetDefaultDomain] Criando cliente Netlify")
-	netlifyClient, err := netlify.NewClient(s.config)
+	netlifyClient, err := s.newNetlifyClient(s.accountConfig(c))
 	if err != nil {
-		log.Printf("[handleSetDefaultDomain] Erro ao criar cliente Netlify: %v", err)
-		c.JSON(http.StatusInternalServerError, DomainResponse{
-			Success: false,
-			Message: fmt.Sprintf("Erro ao criar cliente Netlify: %v", err),
-			SiteID:  req.SiteID,
-			Domain:  req.Domain,
-		})
+		slog.ErrorContext(ctx, "Erro ao criar cliente Netlify", "error", err)
+		respondError(c, err, "netlify.client_failed")
 		return
 	}
 
-	// Definir domínio padrão
-	log.Printf("[handleSetDefaultDomain] Definindo domínio %s como padrão para o site %s", req.Domain, req.SiteID)
-	
-	// Criar contexto com timeout
-	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	// Definir domínio padrão, sem interromper a operação se o cliente desconectar
+	ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), 30*time.Second)
 	defer cancel()
-	
+
 	err = netlifyClient.SetDefaultDomain(ctx, req.SiteID, req.Domain, "")
 	if err != nil {
-		log.Printf("[handleSetDefaultDomain] Erro ao definir domínio padrão: %v", err)
-		c.JSON(http.StatusInternalServerError, DomainResponse{
-			Success: false,
-			Message: fmt.Sprintf("Erro ao definir domínio padrão: %v", err),
-			SiteID:  req.SiteID,
-			Domain:  req.Domain,
-		})
+		slog.ErrorContext(ctx, "Erro ao definir domínio padrão", "error", err)
+		respondError(c, err, "domain.set_default_failed")
 		return
 	}
 
-	log.Printf("[handleSetDefaultDomain] Domínio definido como padrão com sucesso")
+	slog.InfoContext(ctx, "Domínio definido como padrão")
 	c.JSON(http.StatusOK, DomainResponse{
 		Success: true,
-		Message: "Domínio definido como padrão com sucesso",
+		Message: translate(c, "domain.default_set"),
 		SiteID:  req.SiteID,
 		Domain:  req.Domain,
 	})
@@ -810,207 +1114,111 @@ func (s *Server) handleSetDefaultDomain(c *gin.Context) {
 // @Tags domains
 // @Accept json
 // @Produce json
+// @Param account path string true "ID da conta"
 // @Param request body DomainRequest true "ID do site a ter o domínio principal removido"
 // @Success 200 {object} DomainResponse
-// @Failure 400 {object} DomainResponse
-// @Failure 500 {object} DomainResponse
-// @Router /api/domains/remove-primary [post]
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Security ApiKeyAuth
+// @Router /api/accounts/{account}/domains/remove-primary [post]
 func (s *Server) handleRemovePrimaryDomain(c *gin.Context) {
-	log.Printf("[handleRemovePrimaryDomain] Recebendo requisição para remover domínio principal")
-	
 	var req DomainRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		log.Printf("[handleRemovePrimaryDomain] Erro ao processar JSON: %v", err)
-		c.JSON(http.StatusBadRequest, DomainResponse{
-			Success: false,
-			Message: fmt.Sprintf("Erro ao processar requisição: %v", err),
-		})
+		respondInvalidRequest(c, err, "request.invalid_body")
 		return
 	}
 
-	log.Printf("[handleRemovePrimaryDomain] Requisição recebida: SiteID=%s", req.SiteID)
-	
 	// Validar campos obrigatórios
 	if req.SiteID == "" {
-		log.Printf("[handleRemovePrimaryDomain] Campo obrigatório não informado")
-		c.JSON(http.StatusBadRequest, DomainResponse{
-			Success: false,
-			Message: "ID do site é obrigatório",
-			SiteID:  req.SiteID,
-		})
+		respondInvalidRequest(c, nil, "domain.site_id_required")
 		return
 	}
+	ctx := logWith(c, "site_id", req.SiteID)
 
 	// Criar cliente Netlify
-	log.Printf("[handleRemovePrimaryDomain] Criando cliente Netlify")
-	netlifyClient, err := netlify.NewClient(s.config)
+	netlifyClient, err := s.newNetlifyClient(s.accountConfig(c))
 	if err != nil {
-		log.Printf("[handleRemovePrimaryDomain] Erro ao criar cliente Netlify: %v", err)
-		c.JSON(http.StatusInternalServerError, DomainResponse{
-			Success: false,
-			Message: fmt.Sprintf("Erro ao criar cliente Netlify: %v", err),
-			SiteID:  req.SiteID,
-		})
+		slog.ErrorContext(ctx, "Erro ao criar cliente Netlify", "error", err)
+		respondError(c, err, "netlify.client_failed")
 		return
 	}
 
-	// Remover domínio principal
-	log.Printf("[handleRemovePrimaryDomain] Removendo domínio principal do site %s", req.SiteID)
-	
-	// Criar contexto com timeout
-	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	// Remover domínio principal, sem interromper a operação se o cliente desconectar
+	ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), 30*time.Second)
 	defer cancel()
-	
+
 	err = netlifyClient.RemovePrimaryDomain(ctx, req.SiteID)
 	if err != nil {
-		log.Printf("[handleRemovePrimaryDomain] Erro ao remover domínio principal: %v", err)
-		c.JSON(http.StatusInternalServerError, DomainResponse{
-			Success: false,
-			Message: fmt.Sprintf("Erro ao remover domínio principal: %v", err),
-			SiteID:  req.SiteID,
-		})
+		slog.ErrorContext(ctx, "Erro ao remover domínio principal", "error", err)
+		respondError(c, err, "domain.remove_primary_failed")
 		return
 	}
 
-	log.Printf("[handleRemovePrimaryDomain] Domínio principal removido com sucesso")
+	slog.InfoContext(ctx, "Domínio principal removido")
 	c.JSON(http.StatusOK, DomainResponse{
 		Success: true,
-		Message: "Domínio principal removido com sucesso",
+		Message: translate(c, "domain.primary_removed"),
 		SiteID:  req.SiteID,
 	})
 }
 
-// handleTestNetlifyConnection testa a conexão com a API da Netlify
+// handleTestNetlifyConnection testa a conexão com a API da Netlify usando o token da conta
 // @Summary Testa a conexão com a API da Netlify
-// @Description Testa a conexão com a API da Netlify e exibe informações sobre o token
+// @Description Confirma que o token da Netlify da conta é aceito pela API da Netlify. Apenas o token da conta é testado; o valor nunca é exibido.
 // @Tags netlify
 // @Accept json
 // @Produce json
+// @Param account path string true "ID da conta"
 // @Success 200 {object} map[string]interface{}
-// @Failure 500 {object} map[string]interface{}
-// @Router /api/test/netlify/connection [get]
+// @Failure 502 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Security ApiKeyAuth
+// @Router /api/accounts/{account}/test/netlify/connection [get]
 func (s *Server) handleTestNetlifyConnection(c *gin.Context) {
-	log.Printf("[handleTestNetlifyConnection] Testando conexão com a API da Netlify")
-	
-	// Verificar variáveis de ambiente carregadas
-	log.Printf("[handleTestNetlifyConnection] Variáveis de ambiente carregadas:")
-	log.Printf("NETLIFY_TOKEN presente: %v", s.config.NetlifyToken != "")
-	log.Printf("BASE_DOMAIN: %s", s.config.BaseDomain)
-	
-	// Exibir valor original das variáveis de ambiente
-	originalToken := os.Getenv("NETLIFY_TOKEN")
-	maskTokenValue := "<vazio>"
-	if originalToken != "" {
-		if len(originalToken) > 10 {
-			maskTokenValue = originalToken[:10] + "..."
-		} else {
-			maskTokenValue = "<token muito curto>"
-		}
-	}
-	
-	log.Printf("[handleTestNetlifyConnection] Token original do ambiente: %s", maskTokenValue)
-	
-	// Recarregar .env para depuração
-	log.Printf("[handleTestNetlifyConnection] Tentando recarregar arquivo .env")
-	if err := godotenv.Load(); err != nil {
-		log.Printf("[handleTestNetlifyConnection] Erro ao carregar arquivo .env: %v", err)
-	} else {
-		log.Printf("[handleTestNetlifyConnection] Arquivo .env carregado com sucesso")
-		tokenFromEnv := os.Getenv("NETLIFY_TOKEN")
-		envTokenMasked := "<vazio>"
-		if tokenFromEnv != "" {
-			if len(tokenFromEnv) > 10 {
-				envTokenMasked = tokenFromEnv[:10] + "..."
-			} else {
-				envTokenMasked = "<token muito curto>"
-			}
-		}
-		log.Printf("[handleTestNetlifyConnection] Token do .env: %s", envTokenMasked)
-	}
-	
-	// Verificar todas as variáveis de ambiente
-	for _, env := range os.Environ() {
-		if strings.HasPrefix(env, "NETLIFY_") {
-			parts := strings.SplitN(env, "=", 2)
-			if len(parts) == 2 {
-				key := parts[0]
-				value := parts[1]
-				maskedValue := "<vazio>"
-				if value != "" {
-					if len(value) > 10 {
-						maskedValue = value[:10] + "..."
-					} else {
-						maskedValue = "<valor muito curto>"
-					}
-				}
-				log.Printf("[handleTestNetlifyConnection] Variável encontrada: %s = %s", key, maskedValue)
-			}
-		}
-	}
-	
-	// Retornar informações sobre as variáveis de ambiente
-	c.JSON(http.StatusOK, gin.H{
-		"success": true,
-		"message": "Informações sobre as variáveis de ambiente",
-		"environment": gin.H{
-			"netlify_token": gin.H{
-				"config_value": s.config.NetlifyToken != "",
-				"env_value": originalToken != "",
-				"env_reloaded": os.Getenv("NETLIFY_TOKEN") != "",
-			},
-			"base_domain": s.config.BaseDomain,
-		},
-	})
-}
+	ctx := c.Request.Context()
+	slog.InfoContext(ctx, "Testando conexão com a API da Netlify")
 
-// handleViewLogs exibe os logs da aplicação
-func (s *Server) handleViewLogs(c *gin.Context) {
-	log.Printf("[handleViewLogs] Exibindo logs da aplicação")
-	
-	// Verificar se o arquivo de logs existe
-	logFilePath := "netlify-deploy.log"
-	logContent, err := os.ReadFile(logFilePath)
+	cfg := s.accountConfig(c)
+	netlifyClient, err := s.newNetlifyClient(cfg)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{
-			"success": false,
-			"message": fmt.Sprintf("Erro ao ler arquivo de logs: %v", err),
-		})
+		slog.ErrorContext(ctx, "Erro ao criar cliente Netlify", "error", err)
+		respondError(c, err, "netlify.client_failed")
 		return
 	}
-	
-	// Converter o conteúdo do arquivo para string
-	logText := string(logContent)
-	
-	// Retornar os logs formatados como JSON
+
+	if err := netlifyClient.VerifyToken(ctx); err != nil {
+		slog.WarnContext(ctx, "Token da Netlify da conta recusado", "error", err)
+		respondError(c, err, "netlify.connection_failed")
+		return
+	}
+
+	slog.InfoContext(ctx, "Conexão com a API da Netlify confirmada")
 	c.JSON(http.StatusOK, gin.H{
-		"success": true,
-		"logs": logText,
+		"success":     true,
+		"message":     translate(c, "netlify.connection_ok"),
+		"account":     currentAccount(c).ID,
+		"base_domain": cfg.BaseDomain,
 	})
 }
 
 // handleListSites lista todos os sites do usuário na Netlify
 func (s *Server) handleListSites(c *gin.Context) {
-	log.Printf("[API] Recebida requisição para listar sites")
-	
+	ctx := c.Request.Context()
+
 	// Configurar o cliente da Netlify
-	netlifyClient, err := netlify.NewClient(s.config)
+	netlifyClient, err := s.newNetlifyClient(s.accountConfig(c))
 	if err != nil {
-		log.Printf("[API] Erro ao criar cliente Netlify: %v", err)
-		c.JSON(http.StatusInternalServerError, gin.H{
-			"success": false,
-			"message": fmt.Sprintf("Erro interno do servidor: %v", err),
-		})
+		slog.ErrorContext(ctx, "Erro ao criar cliente Netlify", "error", err)
+		respondError(c, err, "server.internal_error")
 		return
 	}
 	
 	// Listar sites
-	sites, err := netlifyClient.ListSites(context.Background())
+	sites, err := netlifyClient.ListSites(context.WithoutCancel(ctx))
 	if err != nil {
-		log.Printf("[API] Erro ao listar sites: %v", err)
-		c.JSON(http.StatusInternalServerError, gin.H{
-			"success": false,
-			"message": fmt.Sprintf("Erro ao listar sites: %v", err),
-		})
+		slog.ErrorContext(ctx, "Erro ao listar sites", "error", err)
+		respondError(c, err, "site.list_failed")
 		return
 	}
 	
@@ -1051,314 +1259,231 @@ func (s *Server) handleListSites(c *gin.Context) {
 	
 	c.JSON(http.StatusOK, gin.H{
 		"success": true,
-		"message": fmt.Sprintf("Encontrados %d sites", len(sites)),
+		"message": translate(c, "site.list_found", len(sites)),
 		"sites": sitesList,
 	})
 }
 
-// maskToken oculta parte do token para exibição segura
-func maskToken(token string) string {
-	if token == "" {
-		return "<token vazio>"
-	}
-	
-	if len(token) <= 10 {
-		return "<token muito curto>"
-	}
-	
-	return token[:10] + "..."
-}
-
-// processDeploy realiza o processo de deploy em segundo plano
-func (s *Server) processDeploy(req DeployRequest) {
-	ctx := context.Background()
-	log.Printf("Iniciando deploy para usuário: %s, caminho S3: %s", req.Username, req.S3Path)
+// processDeploy realiza o processo de deploy a partir do S3 em segundo plano, reportando o andamento em job
+func (s *Server) processDeploy(ctx context.Context, job *Job, cfg *config.Config, siteID string) error {
+	slog.InfoContext(ctx, "Iniciando deploy do S3", "username", cfg.Username, "s3_path", cfg.S3Path)
 
 	// Inicializar cliente Netlify
-	netlifyClient, err := netlify.NewClient(s.config)
+	netlifyClient, err := s.newNetlifyClient(cfg)
 	if err != nil {
-		log.Printf("Erro ao inicializar cliente Netlify: %v", err)
-		return
+		return fmt.Errorf("erro ao inicializar cliente Netlify: %w", err)
 	}
+	defer func() { job.SetRetries(netlifyClient.RetryStats()) }()
 
-	// Verificar se o site já existe
-	site, exists, err := netlifyClient.VerifySite(ctx)
-	if err != nil {
-		log.Printf("Erro ao verificar site: %v", err)
-		return
-	}
+	var site *models.Site
+	var exists bool
 
-	// Criar site se não existir
-	if !exists {
-		site, err = netlifyClient.CreateSite(ctx)
+	// Se o ID do site foi fornecido, usamos ele
+	if siteID != "" {
+		site, exists, err = netlifyClient.VerifySiteById(ctx, siteID)
 		if err != nil {
-			log.Printf("Erro ao criar site: %v", err)
-			return
+			return fmt.Errorf("erro ao verificar site na Netlify: %w", err)
+		}
+		if !exists {
+			return fmt.Errorf("site com ID %s não encontrado", siteID)
+		}
+	} else {
+		// Caso contrário, verificamos se existe um site com o subdomínio configurado
+		site, exists, err = netlifyClient.VerifySite(ctx)
+		if err != nil {
+			return fmt.Errorf("erro ao verificar site: %w", err)
+		}
+
+		// Criar site se não existir
+		if !exists {
+			site, err = netlifyClient.CreateSite(ctx)
+			if err != nil {
+				return fmt.Errorf("erro ao criar site: %w", err)
+			}
 		}
-	}
 
-	// Configurar DNS
-	if err := netlifyClient.ConfigureDNS(ctx, site); err != nil {
-		log.Printf("Aviso: erro ao configurar DNS: %v", err)
+		// Configurar DNS
+		if err := netlifyClient.ConfigureDNS(ctx, site); err != nil {
+			slog.WarnContext(ctx, "Erro ao configurar DNS", "site_id", site.ID, "error", err)
+		}
 	}
+	job.SetSite(site)
+	ctx = logging.With(ctx, "site_id", site.ID)
 
 	// Inicializar cliente S3
-	s3Client, err := aws.NewS3Client(s.config)
+	s3Client, err := aws.NewS3Client(cfg)
 	if err != nil {
-		log.Printf("Erro ao inicializar cliente S3: %v", err)
-		return
+		return fmt.Errorf("erro ao inicializar cliente S3: %w", err)
 	}
 
-	// Criar diretório temporário para os arquivos
-	tempDir, err := os.MkdirTemp("", "netlify-deploy-*")
+	s3Client.SetDigestCache(s.store)
+	s3Client.SetManifestObserver(job)
+
+	// Montar o manifesto dos arquivos do S3 sem baixá-los para o disco
+	job.SetPhase(netlify.PhaseDownloading)
+	ctx = netlify.WithDeploySource(ctx, store.SourceS3, cfg.S3Path)
+	objects, err := s3Client.BuildManifest(ctx)
 	if err != nil {
-		log.Printf("Erro ao criar diretório temporário: %v", err)
-		return
+		return fmt.Errorf("erro ao listar arquivos do S3: %w", err)
+	}
+	if len(objects) == 0 {
+		return fmt.Errorf("nenhum arquivo encontrado no caminho S3 especificado: %s", cfg.S3Path)
 	}
-	defer os.RemoveAll(tempDir) // Limpar diretório temporário ao finalizar
 
-	// Baixar arquivos do S3
-	if err := s3Client.DownloadFiles(ctx, tempDir); err != nil {
-		log.Printf("Erro ao baixar arquivos do S3: %v", err)
-		return
+	files := make([]netlify.RemoteFile, 0, len(objects))
+	for _, obj := range objects {
+		files = append(files, netlify.RemoteFile{Path: obj.Path, SHA1: obj.SHA1, Size: obj.Size})
 	}
 
-	// Realizar deploy dos arquivos
-	deploy, err := netlifyClient.DeploySite(ctx, site, tempDir)
+	// Realizar deploy enviando apenas os arquivos que a Netlify ainda não possui
+	deploy, err := netlifyClient.DeployRemoteFiles(ctx, site, files, func(ctx context.Context, f netlify.RemoteFile) (io.ReadCloser, error) {
+		return s3Client.OpenFile(ctx, f.Path)
+	})
 	if err != nil {
-		log.Printf("Erro ao iniciar deploy: %v", err)
-		return
+		return fmt.Errorf("erro ao iniciar deploy: %w", err)
 	}
 
-	log.Printf("Deploy iniciado com sucesso: ID %s", deploy.ID)
+	job.SetDeployID(deploy.ID)
+	ctx = logging.With(ctx, "deploy_id", deploy.ID)
 
 	// Aguardar conclusão do deploy
-	finalDeploy, err := netlifyClient.WaitForDeploy(ctx, deploy.ID)
+	finalDeploy, err := netlifyClient.WaitForDeploy(ctx, deploy.ID, netlify.WithStateCallback(job.OnDeployState))
 	if err != nil {
-		log.Printf("Erro ao aguardar deploy: %v", err)
-	} else {
-		log.Printf("Deploy concluído com sucesso! Site disponível em: %s", finalDeploy.URL)
-		log.Printf("URL do subdomínio: https://%s", s.config.NetlifySubdomain)
-		if s.config.CustomDomain != "" {
-			log.Printf("URL do domínio personalizado: https://%s", s.config.CustomDomain)
-		}
+		return fmt.Errorf("erro ao aguardar deploy: %w", err)
 	}
+
+	slog.InfoContext(ctx, "Deploy concluído com sucesso",
+		"url", finalDeploy.URL,
+		"subdomain", cfg.NetlifySubdomain,
+		"custom_domain", cfg.CustomDomain,
+	)
+
+	job.Complete(finalDeploy)
+	return nil
 }
 
-// handleDeployFromS3 processa uma requisição de deploy a partir do S3 (rota desativada)
+// handleDeployFromS3 processa uma requisição de deploy a partir do S3
+// @Summary Realiza o deploy de um caminho do bucket S3
+// @Description Enfileira o deploy dos arquivos de um caminho do bucket S3. Os arquivos são lidos diretamente do bucket e apenas os que a Netlify ainda não possui são enviados.
+// @Tags deploy
+// @Accept multipart/form-data
+// @Produce json
+// @Param account path string true "ID da conta"
+// @Param site_id formData string false "ID do site na Netlify para atualização (opcional)"
+// @Param site_name formData string true "Nome do site (usado como subdomínio)"
+// @Param s3_path formData string true "Caminho no bucket S3"
+// @Param custom_domain formData string false "Domínio personalizado para o site (opcional)"
+// @Param draft formData bool false "Criar o deploy como rascunho, sem publicá-lo em produção (a URL de pré-visualização fica em deploy_url do job)"
+// @Param dry_run formData bool false "Apenas comparar os arquivos do caminho S3 com o deploy publicado do site (exige site_id), sem criar o deploy"
+// @Success 202 {object} JobResponse
+// @Success 200 {object} DryRunResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 503 {object} ErrorResponse
+// @Security ApiKeyAuth
+// @Router /api/accounts/{account}/deploy/s3 [post]
 func (s *Server) handleDeployFromS3(c *gin.Context) {
-	log.Printf("[API] Recebida requisição para deploy a partir do S3: %s", c.Request.URL.Path)
-	
 	// Obter parâmetros do formulário
 	siteID := c.PostForm("site_id")
 	siteName := c.PostForm("site_name")
 	if siteName == "" {
-		c.JSON(http.StatusBadRequest, gin.H{
-			"success": false,
-			"message": "Nome do site é obrigatório",
-		})
+		respondInvalidRequest(c, nil, "deploy.site_name_required")
 		return
 	}
 
 	s3Path := c.PostForm("s3_path")
 	if s3Path == "" {
-		c.JSON(http.StatusBadRequest, gin.H{
-			"success": false,
-			"message": "Caminho no bucket S3 é obrigatório",
-		})
+		respondInvalidRequest(c, nil, "deploy.s3_path_required")
 		return
 	}
 
 	customDomain := c.PostForm("custom_domain")
-	// Variável description obtida mas não utilizada, comentada para evitar erro
-	// description := c.PostForm("description")
-
-	log.Printf("[API] Parâmetros de deploy do S3: siteID=%s, siteName=%s, s3Path=%s, customDomain=%s", 
-		siteID, siteName, s3Path, customDomain)
-
-	// Configurar o cliente da Netlify
-	netlifyClient, err := netlify.NewClient(s.config)
-	if err != nil {
-		log.Printf("[API] Erro ao criar cliente Netlify: %v", err)
-		c.JSON(http.StatusInternalServerError, gin.H{
-			"success": false,
-			"message": fmt.Sprintf("Erro interno do servidor: %v", err),
-		})
-		return
-	}
-	
-	// Configurar parâmetros de deploy no objeto config
-	// Usamos o siteName como username para manter a consistência
-	if err := s.config.SetDeployParams(siteName, customDomain, s3Path); err != nil {
-		log.Printf("[API] Erro ao configurar parâmetros de deploy: %v", err)
-		c.JSON(http.StatusBadRequest, gin.H{
-			"success": false,
-			"message": fmt.Sprintf("Erro nos parâmetros de deploy: %v", err),
-		})
+	draft, _ := strconv.ParseBool(c.PostForm("draft"))
+	dryRun, _ := strconv.ParseBool(c.PostForm("dry_run"))
+	if dryRun && siteID == "" {
+		respondInvalidRequest(c, nil, "deploy.dry_run_site_required")
 		return
 	}
 
-	// Configurar cliente S3
-	s3Client, err := aws.NewS3Client(s.config)
-	if err != nil {
-		log.Printf("[API] Erro ao criar cliente S3: %v", err)
-		c.JSON(http.StatusInternalServerError, gin.H{
-			"success": false,
-			"message": fmt.Sprintf("Erro ao criar cliente S3: %v", err),
-		})
-		return
+	ctx := c.Request.Context()
+	if siteID != "" {
+		ctx = logWith(c, "site_id", siteID)
 	}
+	slog.InfoContext(ctx, "Parâmetros de deploy do S3", "site_name", siteName, "s3_path", s3Path, "custom_domain", customDomain, "draft", draft, "dry_run", dryRun)
 
-	// Criar diretório temporário para os arquivos
-	tempDir, err := os.MkdirTemp("", "netlify-s3-deploy-*")
-	if err != nil {
-		log.Printf("[API] Erro ao criar diretório temporário: %v", err)
-		c.JSON(http.StatusInternalServerError, gin.H{
-			"success": false,
-			"message": fmt.Sprintf("Erro ao criar diretório temporário: %v", err),
-		})
+	// Configurar parâmetros de deploy em uma cópia da configuração da conta, já que o job roda em segundo plano
+	// Usamos o siteName como username para manter a consistência
+	cfg := s.accountConfig(c)
+	if err := cfg.SetDeployParams(siteName, customDomain, s3Path); err != nil {
+		slog.WarnContext(ctx, "Erro ao configurar parâmetros de deploy", "error", err)
+		respondInvalidRequest(c, err, "deploy.invalid_params")
 		return
 	}
-	// Adicionar defer para limpar o diretório temporário ao final
-	defer os.RemoveAll(tempDir)
 
-	log.Printf("[API] Baixando arquivos do S3 para diretório temporário: %s", tempDir)
-	
-	// Baixar arquivos do S3
-	if err := s3Client.DownloadFiles(c.Request.Context(), tempDir); err != nil {
-		log.Printf("[API] Erro ao baixar arquivos do S3: %v", err)
-		c.JSON(http.StatusInternalServerError, gin.H{
-			"success": false,
-			"message": fmt.Sprintf("Erro ao baixar arquivos do S3: %v", err),
-		})
+	if dryRun {
+		s.dryRunS3(ctx, c, cfg, siteID)
 		return
 	}
 
-	// Verificar se existem arquivos no diretório
-	files, err := os.ReadDir(tempDir)
+	// Enfileirar o deploy para execução em segundo plano
+	job, err := s.jobs.Submit(ctx, currentAccount(c).ID, requestLang(c), func(ctx context.Context, job *Job) error {
+		if draft {
+			ctx = netlify.WithDraft(ctx)
+		}
+		return s.processDeploy(ctx, job, cfg, siteID)
+	})
 	if err != nil {
-		log.Printf("[API] Erro ao ler diretório temporário: %v", err)
-		c.JSON(http.StatusInternalServerError, gin.H{
-			"success": false,
-			"message": fmt.Sprintf("Erro ao verificar arquivos baixados: %v", err),
-		})
-		return
-	}
-
-	if len(files) == 0 {
-		log.Printf("[API] Nenhum arquivo encontrado no caminho S3 especificado: %s", s3Path)
-		c.JSON(http.StatusBadRequest, gin.H{
-			"success": false,
-			"message": fmt.Sprintf("Nenhum arquivo encontrado no caminho S3 especificado: %s", s3Path),
-		})
+		slog.ErrorContext(ctx, "Erro ao enfileirar deploy", "error", err)
+		respondError(c, err, "deploy.enqueue_failed")
 		return
 	}
 
-	log.Printf("[API] %d arquivos/diretórios baixados do S3", len(files))
+	c.JSON(http.StatusAccepted, JobResponse{
+		Success:   true,
+		Message:   translate(c, "deploy.enqueued"),
+		JobID:     job.ID(),
+		StatusURL: accountPath(c, "/jobs/"+job.ID()),
+	})
+}
 
-	// Processar o deploy na Netlify
-	var site *models.Site
-	var exists bool
-	
-	// Se o ID do site foi fornecido, usamos ele
-	if siteID != "" {
-		// Verificar se o site existe com o ID fornecido
-		site, exists, err = netlifyClient.VerifySiteById(c.Request.Context(), siteID)
-		if err != nil {
-			log.Printf("[API] Erro ao verificar site na Netlify com ID %s: %v", siteID, err)
-			c.JSON(http.StatusInternalServerError, gin.H{
-				"success": false,
-				"message": fmt.Sprintf("Erro ao verificar site na Netlify: %v", err),
-			})
-			return
-		}
-		
-		if !exists {
-			log.Printf("[API] Site com ID %s não encontrado", siteID)
-			c.JSON(http.StatusBadRequest, gin.H{
-				"success": false,
-				"message": fmt.Sprintf("Site com ID %s não encontrado", siteID),
-			})
-			return
-		}
-	} else {
-		// Caso contrário, verificamos se existe um site com o nome fornecido
-		site, exists, err = netlifyClient.VerifySite(c.Request.Context())
-		if err != nil {
-			log.Printf("[API] Erro ao verificar site na Netlify: %v", err)
-			c.JSON(http.StatusInternalServerError, gin.H{
-				"success": false,
-				"message": fmt.Sprintf("Erro ao verificar site na Netlify: %v", err),
-			})
-			return
-		}
+// dryRunS3 monta o manifesto do caminho S3 e o compara com o deploy publicado do site, sem criar o deploy
+func (s *Server) dryRunS3(ctx context.Context, c *gin.Context, cfg *config.Config, siteID string) {
+	ctx, cancel := context.WithTimeout(ctx, dryRunTimeout)
+	defer cancel()
 
-		// Se o site não existir, criá-lo
-		if !exists {
-			log.Printf("[API] Site não encontrado. Criando novo site: %s", siteName)
-			site, err = netlifyClient.CreateSite(c.Request.Context())
-			if err != nil {
-				log.Printf("[API] Erro ao criar site na Netlify: %v", err)
-				c.JSON(http.StatusInternalServerError, gin.H{
-					"success": false,
-					"message": fmt.Sprintf("Erro ao criar site na Netlify: %v", err),
-				})
-				return
-			}
-			log.Printf("[API] Site criado com sucesso: %s (ID: %s)", site.Name, site.ID)
-		} else {
-			log.Printf("[API] Site existente encontrado: %s (ID: %s)", site.Name, site.ID)
-		}
+	netlifyClient, err := s.newNetlifyClient(cfg)
+	if err != nil {
+		slog.ErrorContext(ctx, "Erro ao criar cliente Netlify", "error", err)
+		respondError(c, err, "netlify.client_failed")
+		return
 	}
 
-	// Realizar deploy dos arquivos baixados do S3
-	log.Printf("[API] Iniciando deploy dos arquivos para o site %s (ID: %s)", site.Name, site.ID)
-	deploy, err := netlifyClient.DeploySite(c.Request.Context(), site, tempDir)
+	s3Client, err := aws.NewS3Client(cfg)
 	if err != nil {
-		log.Printf("[API] Erro ao iniciar deploy: %v", err)
-		c.JSON(http.StatusInternalServerError, gin.H{
-			"success": false,
-			"message": fmt.Sprintf("Erro ao iniciar deploy: %v", err),
-		})
+		slog.ErrorContext(ctx, "Erro ao inicializar cliente S3", "error", err)
+		respondError(c, err, "deploy.dry_run_failed")
 		return
 	}
+	s3Client.SetDigestCache(s.store)
 
-	log.Printf("[API] Deploy iniciado com sucesso: ID %s", deploy.ID)
-
-	// Aguardar a conclusão do deploy se o cliente ainda estiver conectado
-	finalDeploy, err := netlifyClient.WaitForDeploy(c.Request.Context(), deploy.ID)
+	objects, err := s3Client.BuildManifest(ctx)
 	if err != nil {
-		log.Printf("[API] Erro ao aguardar deploy: %v", err)
-		// Mesmo com erro, retornamos sucesso parcial pois o deploy foi iniciado
-		c.JSON(http.StatusOK, gin.H{
-			"success": true,
-			"message": fmt.Sprintf("Deploy iniciado, mas não foi possível aguardar a conclusão: %v", err),
-			"site_id": site.ID,
-			"site_url": site.URL,
-			"deploy_id": deploy.ID,
-			"test_success": false,
-		})
+		slog.ErrorContext(ctx, "Erro ao listar arquivos do S3", "error", err)
+		respondError(c, err, "deploy.dry_run_failed")
 		return
 	}
 
-	// Deploy concluído com sucesso
-	log.Printf("[API] Deploy concluído com sucesso! Site disponível em: %s", finalDeploy.URL)
-	
-	// Retornar resposta de sucesso
-	c.JSON(http.StatusOK, gin.H{
-		"success": true,
-		"message": "Deploy concluído com sucesso",
-		"site_id": site.ID,
-		"site_url": finalDeploy.URL,
-		"deploy_id": deploy.ID,
-		"created_at": time.Now().Format(time.RFC3339),
-		"test_success": true,
-	})
+	files := make([]netlify.RemoteFile, 0, len(objects))
+	for _, obj := range objects {
+		files = append(files, netlify.RemoteFile{Path: obj.Path, SHA1: obj.SHA1, Size: obj.Size})
+	}
+	respondDryRun(ctx, c, netlifyClient, siteID, files)
 }
 
 // Start inicia o servidor da API
 func (s *Server) Start() error {
 	addr := fmt.Sprintf(":%s", s.config.APIPort)
-	log.Printf("Iniciando servidor na porta %s", s.config.APIPort)
+	slog.Info("Iniciando servidor", "port", s.config.APIPort)
+
 	return s.router.Run(addr)
 }
@@ -0,0 +1,146 @@
+package api
+
+import (
+	"log/slog"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/kodestech/poc-netlify/internal/store"
+	"github.com/netlify/open-api/go/models"
+)
+
+// SiteResponse representa os detalhes de um site
+type SiteResponse struct {
+	Success    bool                  `json:"success" example:"true" swagger:"description=Indica se a operação foi bem-sucedida"`
+	Message    string                `json:"message,omitempty" example:"Site renomeado com sucesso" swagger:"description=Mensagem descritiva sobre o resultado da operação"`
+	Site       *models.Site          `json:"site,omitempty" swaggertype:"object" swagger:"description=Detalhes do site retornados pela Netlify"`
+	Expiration *store.SiteExpiration `json:"expiration,omitempty" swagger:"description=Exclusão agendada do site, quando temporário"`
+}
+
+// RenameSiteRequest representa uma requisição de alteração do nome de um site
+type RenameSiteRequest struct {
+	Name string `json:"name" binding:"required" example:"meu-novo-site" swagger:"description=Novo nome do site (define o subdomínio .netlify.app)"`
+}
+
+// handleGetSite retorna os detalhes de um site
+// @Summary Consulta um site
+// @Description Retorna todos os detalhes do site na Netlify e a exclusão agendada, quando o site é temporário
+// @Tags sites
+// @Produce json
+// @Param account path string true "ID da conta"
+// @Param id path string true "ID do site na Netlify"
+// @Success 200 {object} SiteResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Security ApiKeyAuth
+// @Router /api/accounts/{account}/sites/{id} [get]
+func (s *Server) handleGetSite(c *gin.Context) {
+	siteID := c.Param("id")
+	ctx := logWith(c, "site_id", siteID)
+
+	netlifyClient, err := s.newNetlifyClient(s.accountConfig(c))
+	if err != nil {
+		respondError(c, err, "netlify.client_failed")
+		return
+	}
+
+	site, err := netlifyClient.GetSite(ctx, siteID)
+	if err != nil {
+		slog.ErrorContext(ctx, "Erro ao obter site", "error", err)
+		respondError(c, err, "site.get_failed")
+		return
+	}
+
+	response := SiteResponse{
+		Success: true,
+		Site:    site,
+	}
+	if exp, ok, err := s.store.GetSiteExpiration(siteID); err == nil && ok && exp.AccountID == currentAccount(c).ID {
+		response.Expiration = exp
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// handleRenameSite altera o nome de um site
+// @Summary Renomeia um site
+// @Description Altera o nome do site na Netlify, o que também altera seu subdomínio .netlify.app
+// @Tags sites
+// @Accept json
+// @Produce json
+// @Param account path string true "ID da conta"
+// @Param id path string true "ID do site na Netlify"
+// @Param request body RenameSiteRequest true "Novo nome do site"
+// @Success 200 {object} SiteResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Security ApiKeyAuth
+// @Router /api/accounts/{account}/sites/{id} [patch]
+func (s *Server) handleRenameSite(c *gin.Context) {
+	siteID := c.Param("id")
+	ctx := logWith(c, "site_id", siteID)
+
+	var req RenameSiteRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondInvalidRequest(c, err, "request.invalid_body")
+		return
+	}
+
+	netlifyClient, err := s.newNetlifyClient(s.accountConfig(c))
+	if err != nil {
+		respondError(c, err, "netlify.client_failed")
+		return
+	}
+
+	site, err := netlifyClient.RenameSite(ctx, siteID, req.Name)
+	if err != nil {
+		slog.ErrorContext(ctx, "Erro ao renomear site", "error", err)
+		respondError(c, err, "site.rename_failed")
+		return
+	}
+
+	c.JSON(http.StatusOK, SiteResponse{
+		Success: true,
+		Message: translate(c, "site.renamed"),
+		Site:    site,
+	})
+}
+
+// handleDeleteSite exclui um site
+// @Summary Exclui um site
+// @Description Exclui o site da Netlify e cancela a exclusão agendada, se houver
+// @Tags sites
+// @Produce json
+// @Param account path string true "ID da conta"
+// @Param id path string true "ID do site na Netlify"
+// @Success 200 {object} SiteResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Security ApiKeyAuth
+// @Router /api/accounts/{account}/sites/{id} [delete]
+func (s *Server) handleDeleteSite(c *gin.Context) {
+	siteID := c.Param("id")
+	ctx := logWith(c, "site_id", siteID)
+
+	netlifyClient, err := s.newNetlifyClient(s.accountConfig(c))
+	if err != nil {
+		respondError(c, err, "netlify.client_failed")
+		return
+	}
+
+	if err := netlifyClient.DeleteSite(ctx, siteID); err != nil {
+		slog.ErrorContext(ctx, "Erro ao excluir site", "error", err)
+		respondError(c, err, "site.delete_failed")
+		return
+	}
+
+	if err := s.store.CancelSiteDeletion(siteID); err != nil {
+		slog.WarnContext(ctx, "Erro ao cancelar exclusão agendada do site", "error", err)
+	}
+
+	c.JSON(http.StatusOK, SiteResponse{
+		Success: true,
+		Message: translate(c, "site.deleted"),
+	})
+}
@@ -0,0 +1,20 @@
+package api
+
+import (
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"go.opentelemetry.io/contrib/instrumentation/github.com/gin-gonic/gin/otelgin"
+)
+
+// traceRequest cria um span para cada requisição à API, nomeado pelo modelo da rota. Os spans das
+// operações feitas pela requisição (Netlify, S3 e jobs de deploy) ficam no mesmo trace.
+func traceRequest(serviceName string) gin.HandlerFunc {
+	return otelgin.Middleware(serviceName, otelgin.WithGinFilter(tracedRoute))
+}
+
+// tracedRoute indica se a requisição gera um span; métricas, arquivos estáticos e a documentação não geram
+func tracedRoute(c *gin.Context) bool {
+	path := c.Request.URL.Path
+	return path != "/metrics" && !strings.HasPrefix(path, "/static/") && !strings.HasPrefix(path, "/docs/")
+}
@@ -0,0 +1,200 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"log/slog"
+	"mime"
+	"mime/multipart"
+	"net/http"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/kodestech/poc-netlify/internal/archive"
+)
+
+// uploadFilesField é o campo multipart com os vários arquivos de um site, cujos nomes trazem o caminho relativo
+const uploadFilesField = "files[]"
+
+// errUploadTooLarge indica que os arquivos enviados excedem a quantidade ou o tamanho permitidos
+var errUploadTooLarge = errors.New("arquivos enviados excedem os limites permitidos")
+
+// accountFolder resolve o caminho de uma pasta informada no deploy dentro da pasta da conta
+// (ACCOUNTS_PATH/<conta>). Caminhos absolutos, que saem da pasta da conta ou que apontam
+// para fora dela por links simbólicos são recusados.
+func accountFolder(accountsPath, accountID, folder string) (string, error) {
+	rel := filepath.Clean(filepath.FromSlash(folder))
+	if !filepath.IsLocal(rel) {
+		return "", fmt.Errorf("%w: pasta fora da pasta da conta '%s'", errInvalidRequest, folder)
+	}
+
+	base := filepath.Join(accountsPath, accountID)
+	target := filepath.Join(base, rel)
+
+	// Pastas inexistentes são tratadas por quem chama; as existentes não podem sair da conta por links
+	realBase, err := filepath.EvalSymlinks(base)
+	if err != nil {
+		return target, nil
+	}
+	realTarget, err := filepath.EvalSymlinks(target)
+	if err != nil {
+		return target, nil
+	}
+	if inside, err := filepath.Rel(realBase, realTarget); err != nil || !filepath.IsLocal(inside) {
+		return "", fmt.Errorf("%w: pasta fora da pasta da conta '%s'", errInvalidRequest, folder)
+	}
+	return target, nil
+}
+
+// extractUpload extrai um arquivo compactado enviado (.zip, .tar ou .tar.gz) em uma pasta temporária.
+// Retorna a pasta temporária, que deve ser removida pelo chamador, e a raiz do site dentro dela.
+func (s *Server) extractUpload(ctx context.Context, file *multipart.FileHeader) (dir, root string, err error) {
+	src, err := file.Open()
+	if err != nil {
+		return "", "", fmt.Errorf("erro ao abrir arquivo enviado: %w", err)
+	}
+	defer src.Close()
+
+	dir, err = os.MkdirTemp("", "netlify-upload")
+	if err != nil {
+		return "", "", fmt.Errorf("erro ao criar diretório temporário: %w", err)
+	}
+
+	root, err = archive.Extract(file.Filename, src, file.Size, dir, archive.Limits{
+		MaxFiles: s.config.ArchiveMaxFiles,
+		MaxBytes: s.config.ArchiveMaxBytes,
+	})
+	if err != nil {
+		os.RemoveAll(dir)
+		return "", "", err
+	}
+
+	slog.InfoContext(ctx, "Arquivo compactado extraído", "file", file.Filename, "bytes", file.Size, "root", root)
+	return dir, root, nil
+}
+
+// saveUploads grava as partes files[] (ou files) da requisição em uma pasta temporária, recriando os
+// caminhos relativos dos arquivos. Retorna a pasta temporária, que deve ser removida pelo chamador, a raiz
+// do site dentro dela e a quantidade de arquivos; dir é vazio quando nenhum arquivo foi enviado.
+// Quando todos os arquivos estão em uma única pasta, como nos envios de pasta do navegador
+// (webkitdirectory), essa pasta é a raiz do site.
+func (s *Server) saveUploads(ctx context.Context, c *gin.Context) (dir, root string, count int, err error) {
+	form, err := c.MultipartForm()
+	if errors.Is(err, http.ErrNotMultipart) {
+		// Requisições sem formulário multipart não têm arquivos enviados
+		return "", "", 0, nil
+	}
+	if err != nil {
+		return "", "", 0, fmt.Errorf("%w: formulário multipart inválido: %v", errInvalidRequest, err)
+	}
+	headers := append(form.File[uploadFilesField], form.File["files"]...)
+	if len(headers) == 0 {
+		return "", "", 0, nil
+	}
+
+	maxFiles, maxBytes := s.config.ArchiveMaxFiles, s.config.ArchiveMaxBytes
+	if maxFiles > 0 && len(headers) > maxFiles {
+		return "", "", 0, fmt.Errorf("%w: mais de %d arquivos", errUploadTooLarge, maxFiles)
+	}
+
+	// Validar todos os caminhos e os limites antes de gravar qualquer arquivo
+	names := make([]string, len(headers))
+	seen := make(map[string]bool, len(headers))
+	var total int64
+	for i, fh := range headers {
+		name, err := uploadPath(fh)
+		if err != nil {
+			return "", "", 0, err
+		}
+		if seen[name] {
+			return "", "", 0, fmt.Errorf("%w: arquivo %s enviado mais de uma vez", errInvalidRequest, name)
+		}
+		seen[name] = true
+		names[i] = name
+
+		total += fh.Size
+		if maxBytes > 0 && total > maxBytes {
+			return "", "", 0, fmt.Errorf("%w: mais de %d bytes", errUploadTooLarge, maxBytes)
+		}
+	}
+	for _, name := range names {
+		for parent := path.Dir(name); parent != "."; parent = path.Dir(parent) {
+			if seen[parent] {
+				return "", "", 0, fmt.Errorf("%w: %s enviado como arquivo e como pasta", errInvalidRequest, parent)
+			}
+		}
+	}
+
+	dir, err = os.MkdirTemp("", "netlify-upload")
+	if err != nil {
+		return "", "", 0, fmt.Errorf("erro ao criar diretório temporário: %w", err)
+	}
+	for i, fh := range headers {
+		if err := saveUpload(fh, filepath.Join(dir, filepath.FromSlash(names[i]))); err != nil {
+			os.RemoveAll(dir)
+			return "", "", 0, fmt.Errorf("erro ao gravar arquivo %s: %w", names[i], err)
+		}
+	}
+
+	root = filepath.Join(dir, commonRoot(names))
+	slog.InfoContext(ctx, "Arquivos enviados gravados", "files", len(names), "bytes", total)
+	return dir, root, len(names), nil
+}
+
+// uploadPath retorna o caminho relativo de um arquivo enviado. O Go mantém apenas o nome base em
+// FileHeader.Filename, então o caminho completo é lido do cabeçalho Content-Disposition da parte.
+func uploadPath(fh *multipart.FileHeader) (string, error) {
+	name := fh.Filename
+	if _, params, err := mime.ParseMediaType(fh.Header.Get("Content-Disposition")); err == nil && params["filename"] != "" {
+		name = params["filename"]
+	}
+
+	name = path.Clean(strings.TrimLeft(strings.ReplaceAll(name, "\\", "/"), "/"))
+	if !fs.ValidPath(name) || name == "." || strings.ContainsAny(name, "#?") {
+		return "", fmt.Errorf("%w: caminho de arquivo inválido '%s'", errInvalidRequest, fh.Filename)
+	}
+	return name, nil
+}
+
+// saveUpload grava o conteúdo de um arquivo enviado no caminho informado
+func saveUpload(fh *multipart.FileHeader, target string) error {
+	src, err := fh.Open()
+	if err != nil {
+		return err
+	}
+	defer src.Close()
+
+	if err := os.MkdirAll(filepath.Dir(target), 0755); err != nil {
+		return err
+	}
+	dst, err := os.Create(target)
+	if err != nil {
+		return err
+	}
+	defer dst.Close()
+
+	if _, err := io.Copy(dst, src); err != nil {
+		return err
+	}
+	return dst.Close()
+}
+
+// commonRoot retorna a pasta comum a todos os caminhos (ex: meu-site em meu-site/index.html),
+// ou "." quando algum arquivo está fora dela
+func commonRoot(names []string) string {
+	root := ""
+	for _, name := range names {
+		dir, _, found := strings.Cut(name, "/")
+		if !found || (root != "" && dir != root) {
+			return "."
+		}
+		root = dir
+	}
+	return root
+}
@@ -0,0 +1,261 @@
+// Package archive extrai arquivos .zip, .tar e .tar.gz enviados como origem de um deploy,
+// recusando caminhos fora do diretório de destino e limitando a quantidade e o tamanho dos arquivos.
+package archive
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"compress/gzip"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+var (
+	// ErrUnsupported indica um arquivo com extensão diferente de .zip, .tar, .tar.gz ou .tgz
+	ErrUnsupported = errors.New("formato de arquivo compactado não suportado")
+	// ErrInvalid indica um arquivo compactado corrompido ou com caminhos inseguros
+	ErrInvalid = errors.New("arquivo compactado inválido")
+	// ErrTooLarge indica que o conteúdo extraído excede os limites de quantidade ou tamanho
+	ErrTooLarge = errors.New("arquivo compactado excede os limites permitidos")
+)
+
+// Limits restringe o conteúdo extraído de um arquivo compactado. Valores zero desativam o limite.
+type Limits struct {
+	// MaxFiles é a quantidade máxima de arquivos extraídos
+	MaxFiles int
+	// MaxBytes é o tamanho total máximo dos arquivos extraídos, em bytes
+	MaxBytes int64
+}
+
+// File é o conteúdo de um arquivo compactado: os uploads multipart e os arquivos do disco atendem a interface
+type File interface {
+	io.Reader
+	io.ReaderAt
+}
+
+// format identifica o tipo de um arquivo compactado
+type format int
+
+const (
+	formatUnknown format = iota
+	formatZip
+	formatTar
+	formatTarGz
+)
+
+// formatOf identifica o tipo do arquivo compactado pela extensão do nome
+func formatOf(name string) format {
+	name = strings.ToLower(name)
+	switch {
+	case strings.HasSuffix(name, ".zip"):
+		return formatZip
+	case strings.HasSuffix(name, ".tar.gz"), strings.HasSuffix(name, ".tgz"):
+		return formatTarGz
+	case strings.HasSuffix(name, ".tar"):
+		return formatTar
+	}
+	return formatUnknown
+}
+
+// IsArchive indica se o nome do arquivo tem uma extensão de arquivo compactado suportada
+func IsArchive(name string) bool {
+	return formatOf(name) != formatUnknown
+}
+
+// Extract extrai o arquivo compactado name (de tamanho size) em dir e retorna a raiz do site:
+// o próprio dir ou, quando todo o conteúdo está em uma única pasta, essa pasta
+func Extract(name string, r File, size int64, dir string, limits Limits) (string, error) {
+	x := &extractor{dir: dir, limits: limits}
+
+	var err error
+	switch formatOf(name) {
+	case formatZip:
+		err = x.zip(r, size)
+	case formatTar:
+		err = x.tar(r)
+	case formatTarGz:
+		var gz *gzip.Reader
+		gz, err = gzip.NewReader(r)
+		if err != nil {
+			return "", fmt.Errorf("%w: %v", ErrInvalid, err)
+		}
+		defer gz.Close()
+		err = x.tar(gz)
+	default:
+		return "", fmt.Errorf("%w: %s", ErrUnsupported, name)
+	}
+	if err != nil {
+		return "", err
+	}
+
+	return singleRoot(dir)
+}
+
+// ExtractFile extrai um arquivo compactado do disco; ver Extract
+func ExtractFile(path, dir string, limits Limits) (string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return "", fmt.Errorf("erro ao abrir arquivo compactado: %w", err)
+	}
+	defer f.Close()
+
+	info, err := f.Stat()
+	if err != nil {
+		return "", fmt.Errorf("erro ao abrir arquivo compactado: %w", err)
+	}
+	return Extract(filepath.Base(path), f, info.Size(), dir, limits)
+}
+
+// extractor grava as entradas de um arquivo compactado em dir, contabilizando os limites
+type extractor struct {
+	dir    string
+	limits Limits
+	files  int
+	bytes  int64
+}
+
+// zip extrai as entradas de um arquivo .zip
+func (x *extractor) zip(r io.ReaderAt, size int64) error {
+	zr, err := zip.NewReader(r, size)
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalid, err)
+	}
+
+	for _, f := range zr.File {
+		if f.FileInfo().IsDir() {
+			if err := x.mkdir(f.Name); err != nil {
+				return err
+			}
+			continue
+		}
+		// Links simbólicos e arquivos especiais são ignorados
+		if !f.Mode().IsRegular() {
+			continue
+		}
+
+		src, err := f.Open()
+		if err != nil {
+			return fmt.Errorf("%w: %s: %v", ErrInvalid, f.Name, err)
+		}
+		err = x.write(f.Name, src)
+		src.Close()
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// tar extrai as entradas de um arquivo .tar (já descompactado, no caso de .tar.gz)
+func (x *extractor) tar(r io.Reader) error {
+	tr := tar.NewReader(r)
+	for {
+		header, err := tr.Next()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("%w: %v", ErrInvalid, err)
+		}
+
+		switch header.Typeflag {
+		case tar.TypeDir:
+			if err := x.mkdir(header.Name); err != nil {
+				return err
+			}
+		case tar.TypeReg:
+			if err := x.write(header.Name, tr); err != nil {
+				return err
+			}
+		}
+		// Links, dispositivos e demais tipos são ignorados
+	}
+}
+
+// target valida o caminho de uma entrada e retorna o caminho correspondente dentro de dir
+func (x *extractor) target(name string) (string, error) {
+	name = strings.TrimPrefix(filepath.ToSlash(name), "./")
+	if name == "" || !filepath.IsLocal(filepath.FromSlash(name)) {
+		return "", fmt.Errorf("%w: caminho inseguro %q", ErrInvalid, name)
+	}
+	return filepath.Join(x.dir, filepath.FromSlash(name)), nil
+}
+
+// mkdir cria a pasta de uma entrada do arquivo compactado
+func (x *extractor) mkdir(name string) error {
+	target, err := x.target(strings.TrimSuffix(name, "/"))
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(target, 0755); err != nil {
+		return fmt.Errorf("erro ao criar diretório %s: %w", name, err)
+	}
+	return nil
+}
+
+// write grava o conteúdo de uma entrada, interrompendo a extração se os limites forem excedidos
+func (x *extractor) write(name string, src io.Reader) error {
+	target, err := x.target(name)
+	if err != nil {
+		return err
+	}
+
+	x.files++
+	if x.limits.MaxFiles > 0 && x.files > x.limits.MaxFiles {
+		return fmt.Errorf("%w: mais de %d arquivos", ErrTooLarge, x.limits.MaxFiles)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(target), 0755); err != nil {
+		return fmt.Errorf("erro ao criar diretório para %s: %w", name, err)
+	}
+	dst, err := os.Create(target)
+	if err != nil {
+		return fmt.Errorf("erro ao criar arquivo %s: %w", name, err)
+	}
+	defer dst.Close()
+
+	// O tamanho declarado no cabeçalho não é confiável: contar os bytes realmente extraídos
+	if x.limits.MaxBytes > 0 {
+		src = io.LimitReader(src, x.limits.MaxBytes-x.bytes+1)
+	}
+	n, err := io.Copy(dst, src)
+	x.bytes += n
+	if err != nil {
+		return fmt.Errorf("%w: %s: %v", ErrInvalid, name, err)
+	}
+	if x.limits.MaxBytes > 0 && x.bytes > x.limits.MaxBytes {
+		return fmt.Errorf("%w: mais de %d bytes", ErrTooLarge, x.limits.MaxBytes)
+	}
+	return dst.Close()
+}
+
+// singleRoot retorna a única pasta de dir quando todo o conteúdo está dentro dela
+// (ex: site/index.html), desconsiderando a pasta __MACOSX criada pelo macOS
+func singleRoot(dir string) (string, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return "", fmt.Errorf("erro ao ler arquivos extraídos: %w", err)
+	}
+
+	var root os.DirEntry
+	for _, entry := range entries {
+		if entry.Name() == "__MACOSX" {
+			continue
+		}
+		if root != nil {
+			return dir, nil
+		}
+		root = entry
+	}
+	if root == nil {
+		return "", fmt.Errorf("%w: nenhum arquivo encontrado", ErrInvalid)
+	}
+	if !root.IsDir() {
+		return dir, nil
+	}
+	return filepath.Join(dir, root.Name()), nil
+}
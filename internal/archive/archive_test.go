@@ -0,0 +1,376 @@
+package archive_test
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"bytes"
+	"compress/gzip"
+	"errors"
+	"hash/crc32"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/kodestech/poc-netlify/internal/archive"
+)
+
+// entry é uma entrada dos arquivos compactados montados nos testes
+type entry struct {
+	name string
+	body string
+	// link, quando definido, cria a entrada como link simbólico para esse destino
+	link string
+}
+
+// zipArchive monta um arquivo .zip com as entradas informadas
+func zipArchive(t *testing.T, entries ...entry) []byte {
+	t.Helper()
+
+	var buf bytes.Buffer
+	zw := zip.NewWriter(&buf)
+	for _, e := range entries {
+		header := &zip.FileHeader{Name: e.name, Method: zip.Deflate}
+		body := e.body
+		if e.link != "" {
+			header.SetMode(os.ModeSymlink | 0777)
+			body = e.link
+		}
+		w, err := zw.CreateHeader(header)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := w.Write([]byte(body)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := zw.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
+
+// tarArchive monta um arquivo .tar (ou .tar.gz) com as entradas informadas
+func tarArchive(t *testing.T, compress bool, entries ...entry) []byte {
+	t.Helper()
+
+	var buf bytes.Buffer
+	var gz *gzip.Writer
+	var tw *tar.Writer
+	if compress {
+		gz = gzip.NewWriter(&buf)
+		tw = tar.NewWriter(gz)
+	} else {
+		tw = tar.NewWriter(&buf)
+	}
+
+	for _, e := range entries {
+		header := &tar.Header{Name: e.name, Mode: 0644, Size: int64(len(e.body)), Typeflag: tar.TypeReg}
+		switch {
+		case e.link != "":
+			header = &tar.Header{Name: e.name, Mode: 0777, Typeflag: tar.TypeSymlink, Linkname: e.link}
+		case strings.HasSuffix(e.name, "/"):
+			header = &tar.Header{Name: e.name, Mode: 0755, Typeflag: tar.TypeDir}
+		}
+		if err := tw.WriteHeader(header); err != nil {
+			t.Fatal(err)
+		}
+		if header.Typeflag == tar.TypeReg {
+			if _, err := tw.Write([]byte(e.body)); err != nil {
+				t.Fatal(err)
+			}
+		}
+	}
+	if err := tw.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if gz != nil {
+		if err := gz.Close(); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return buf.Bytes()
+}
+
+// extract extrai o arquivo em uma pasta nova dentro de um diretório temporário, para que
+// arquivos gravados fora da pasta de destino possam ser detectados
+func extract(t *testing.T, name string, data []byte, limits archive.Limits) (base, dir, root string, err error) {
+	t.Helper()
+
+	base = t.TempDir()
+	dir = filepath.Join(base, "site")
+	if err := os.Mkdir(dir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	root, err = archive.Extract(name, bytes.NewReader(data), int64(len(data)), dir, limits)
+	return base, dir, root, err
+}
+
+// readFile lê um arquivo extraído, falhando o teste se ele não existir
+func readFile(t *testing.T, path string) string {
+	t.Helper()
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("arquivo não extraído: %v", err)
+	}
+	return string(data)
+}
+
+func TestExtractFormats(t *testing.T) {
+	entries := []entry{
+		{name: "index.html", body: "<h1>Olá</h1>"},
+		{name: "css/", body: ""},
+		{name: "css/style.css", body: "body{}"},
+		{name: "./img/logo.svg", body: "<svg/>"},
+	}
+	for _, tc := range []struct {
+		name string
+		data []byte
+	}{
+		{"site.zip", zipArchive(t, entries...)},
+		{"site.tar", tarArchive(t, false, entries...)},
+		{"site.tar.gz", tarArchive(t, true, entries...)},
+		{"SITE.TGZ", tarArchive(t, true, entries...)},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			_, dir, root, err := extract(t, tc.name, tc.data, archive.Limits{})
+			if err != nil {
+				t.Fatalf("erro na extração: %v", err)
+			}
+			if root != dir {
+				t.Fatalf("raiz %q, esperado %q", root, dir)
+			}
+			if got := readFile(t, filepath.Join(dir, "index.html")); got != "<h1>Olá</h1>" {
+				t.Fatalf("index.html com conteúdo %q", got)
+			}
+			readFile(t, filepath.Join(dir, "css", "style.css"))
+			readFile(t, filepath.Join(dir, "img", "logo.svg"))
+		})
+	}
+}
+
+func TestExtractFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "site.zip")
+	if err := os.WriteFile(path, zipArchive(t, entry{name: "index.html", body: "ok"}), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	dir := t.TempDir()
+	root, err := archive.ExtractFile(path, dir, archive.Limits{})
+	if err != nil {
+		t.Fatalf("erro na extração: %v", err)
+	}
+	if got := readFile(t, filepath.Join(root, "index.html")); got != "ok" {
+		t.Fatalf("index.html com conteúdo %q", got)
+	}
+}
+
+func TestExtractRejectsUnsafePaths(t *testing.T) {
+	for _, name := range []string{"../x", "a/../../x", "/x", "/tmp/x", "./../x"} {
+		for _, format := range []string{"zip", "tar"} {
+			t.Run(format+" "+name, func(t *testing.T) {
+				entries := []entry{{name: "index.html", body: "ok"}, {name: name, body: "fora"}}
+				data := tarArchive(t, false, entries...)
+				if format == "zip" {
+					data = zipArchive(t, entries...)
+				}
+
+				base, _, _, err := extract(t, "site."+format, data, archive.Limits{})
+				if !errors.Is(err, archive.ErrInvalid) {
+					t.Fatalf("erro %v, esperado ErrInvalid", err)
+				}
+				// A entrada insegura não é gravada em lugar nenhum, nem dentro nem fora da pasta de destino
+				filepath.WalkDir(base, func(path string, d os.DirEntry, err error) error {
+					if err == nil && d.Name() == "x" {
+						t.Errorf("entrada insegura gravada em %s", path)
+					}
+					return err
+				})
+			})
+		}
+	}
+}
+
+func TestExtractSkipsSymlinks(t *testing.T) {
+	entries := []entry{
+		{name: "index.html", body: "ok"},
+		{name: "passwd", link: "/etc/passwd"},
+		{name: "up", link: "../../"},
+	}
+	for _, tc := range []struct {
+		name string
+		data []byte
+	}{
+		{"site.zip", zipArchive(t, entries...)},
+		{"site.tar.gz", tarArchive(t, true, entries...)},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			_, dir, _, err := extract(t, tc.name, tc.data, archive.Limits{})
+			if err != nil {
+				t.Fatalf("erro na extração: %v", err)
+			}
+			readFile(t, filepath.Join(dir, "index.html"))
+			for _, name := range []string{"passwd", "up"} {
+				if _, err := os.Lstat(filepath.Join(dir, name)); !os.IsNotExist(err) {
+					t.Fatalf("link simbólico %s extraído", name)
+				}
+			}
+		})
+	}
+}
+
+func TestExtractMaxFiles(t *testing.T) {
+	entries := []entry{{name: "a.html", body: "a"}, {name: "b.html", body: "b"}, {name: "c.html", body: "c"}}
+
+	if _, _, _, err := extract(t, "site.zip", zipArchive(t, entries...), archive.Limits{MaxFiles: 3}); err != nil {
+		t.Fatalf("erro com a quantidade no limite: %v", err)
+	}
+	for _, name := range []string{"site.zip", "site.tar"} {
+		data := zipArchive(t, entries...)
+		if name == "site.tar" {
+			data = tarArchive(t, false, entries...)
+		}
+		if _, _, _, err := extract(t, name, data, archive.Limits{MaxFiles: 2}); !errors.Is(err, archive.ErrTooLarge) {
+			t.Fatalf("%s: erro %v, esperado ErrTooLarge", name, err)
+		}
+	}
+}
+
+func TestExtractMaxBytes(t *testing.T) {
+	entries := []entry{{name: "a.html", body: strings.Repeat("a", 60)}, {name: "b.html", body: strings.Repeat("b", 40)}}
+
+	if _, _, _, err := extract(t, "site.tar.gz", tarArchive(t, true, entries...), archive.Limits{MaxBytes: 100}); err != nil {
+		t.Fatalf("erro com o tamanho no limite: %v", err)
+	}
+	for _, name := range []string{"site.zip", "site.tar.gz"} {
+		data := zipArchive(t, entries...)
+		if name == "site.tar.gz" {
+			data = tarArchive(t, true, entries...)
+		}
+		if _, _, _, err := extract(t, name, data, archive.Limits{MaxBytes: 99}); !errors.Is(err, archive.ErrTooLarge) {
+			t.Fatalf("%s: erro %v, esperado ErrTooLarge", name, err)
+		}
+	}
+}
+
+func TestExtractUnderReportedSize(t *testing.T) {
+	// Entrada cujo cabeçalho declara 10 bytes, mas que contém 1 MB (ex: zip bomb)
+	body := bytes.Repeat([]byte("x"), 1<<20)
+	var buf bytes.Buffer
+	zw := zip.NewWriter(&buf)
+	w, err := zw.CreateRaw(&zip.FileHeader{
+		Name:               "index.html",
+		Method:             zip.Store,
+		CRC32:              crc32.ChecksumIEEE(body),
+		CompressedSize64:   uint64(len(body)),
+		UncompressedSize64: 10,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := w.Write(body); err != nil {
+		t.Fatal(err)
+	}
+	if err := zw.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, limits := range []archive.Limits{{}, {MaxBytes: 1024}} {
+		_, dir, _, err := extract(t, "site.zip", buf.Bytes(), limits)
+		if !errors.Is(err, archive.ErrInvalid) && !errors.Is(err, archive.ErrTooLarge) {
+			t.Fatalf("limites %+v: erro %v, esperado ErrInvalid ou ErrTooLarge", limits, err)
+		}
+		// Os bytes gravados nunca passam do limite, independentemente do tamanho declarado
+		if info, err := os.Stat(filepath.Join(dir, "index.html")); err == nil && limits.MaxBytes > 0 && info.Size() > limits.MaxBytes+1 {
+			t.Fatalf("%d bytes gravados com limite de %d", info.Size(), limits.MaxBytes)
+		}
+	}
+}
+
+func TestExtractSingleRoot(t *testing.T) {
+	for _, tc := range []struct {
+		name    string
+		entries []entry
+		root    string
+	}{
+		{
+			name: "pasta única",
+			entries: []entry{
+				{name: "meu-site/index.html", body: "ok"},
+				{name: "meu-site/css/style.css", body: "body{}"},
+			},
+			root: "meu-site",
+		},
+		{
+			name: "pasta única com __MACOSX",
+			entries: []entry{
+				{name: "meu-site/index.html", body: "ok"},
+				{name: "__MACOSX/meu-site/._index.html", body: "metadados"},
+			},
+			root: "meu-site",
+		},
+		{
+			name: "várias pastas",
+			entries: []entry{
+				{name: "a/index.html", body: "ok"},
+				{name: "b/index.html", body: "ok"},
+			},
+			root: "",
+		},
+		{
+			name:    "arquivo na raiz",
+			entries: []entry{{name: "index.html", body: "ok"}},
+			root:    "",
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			_, dir, root, err := extract(t, "site.zip", zipArchive(t, tc.entries...), archive.Limits{})
+			if err != nil {
+				t.Fatalf("erro na extração: %v", err)
+			}
+			if want := filepath.Join(dir, tc.root); root != want {
+				t.Fatalf("raiz %q, esperado %q", root, want)
+			}
+		})
+	}
+}
+
+func TestExtractEmpty(t *testing.T) {
+	entries := []entry{{name: "__MACOSX/._x", body: "metadados"}}
+	if _, _, _, err := extract(t, "site.zip", zipArchive(t, entries...), archive.Limits{}); !errors.Is(err, archive.ErrInvalid) {
+		t.Fatalf("erro %v, esperado ErrInvalid", err)
+	}
+}
+
+func TestExtractInvalid(t *testing.T) {
+	for _, name := range []string{"site.zip", "site.tar.gz"} {
+		if _, _, _, err := extract(t, name, []byte("isto não é um arquivo compactado"), archive.Limits{}); !errors.Is(err, archive.ErrInvalid) {
+			t.Fatalf("%s: erro %v, esperado ErrInvalid", name, err)
+		}
+	}
+}
+
+func TestUnsupportedExtensions(t *testing.T) {
+	for name, want := range map[string]bool{
+		"site.zip":    true,
+		"SITE.ZIP":    true,
+		"site.tar":    true,
+		"site.tar.gz": true,
+		"site.tgz":    true,
+		"site.rar":    false,
+		"site.7z":     false,
+		"site.gz":     false,
+		"site.tar.xz": false,
+		"zip":         false,
+	} {
+		if got := archive.IsArchive(name); got != want {
+			t.Errorf("IsArchive(%q) = %v, esperado %v", name, got, want)
+		}
+	}
+
+	data := zipArchive(t, entry{name: "index.html", body: "ok"})
+	if _, _, _, err := extract(t, "site.rar", data, archive.Limits{}); !errors.Is(err, archive.ErrUnsupported) {
+		t.Fatalf("erro %v, esperado ErrUnsupported", err)
+	}
+}
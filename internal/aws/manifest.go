@@ -0,0 +1,302 @@
+package aws
+
+import (
+	"context"
+	"crypto/sha1"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"log/slog"
+	"strings"
+	"sync"
+	"sync/atomic"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	"github.com/aws/aws-sdk-go-v2/service/s3/types"
+	"github.com/kodestech/poc-netlify/internal/metrics"
+	"github.com/kodestech/poc-netlify/internal/tracing"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// manifestConcurrency limita quantos objetos têm o digest calculado ao mesmo tempo
+const manifestConcurrency = 8
+
+// ObjectFile descreve um objeto do bucket que fará parte de um deploy
+type ObjectFile struct {
+	// Key é a chave completa do objeto no bucket
+	Key string
+	// Path é o caminho do arquivo relativo ao prefixo configurado (ex: img/logo.png)
+	Path string
+	// SHA1 é o digest hexadecimal do conteúdo do objeto
+	SHA1 string
+	// Size é o tamanho do objeto em bytes
+	Size int64
+}
+
+// DigestCache guarda os SHA1 já calculados dos objetos, evitando baixá-los novamente
+type DigestCache interface {
+	GetObjectDigest(key, etag string) (string, bool)
+	SaveObjectDigest(key, etag, sha1 string) error
+}
+
+// ManifestObserver acompanha a montagem do manifesto, objeto a objeto
+type ManifestObserver interface {
+	// FileDownloaded informa que um objeto foi lido do bucket para calcular o digest
+	FileDownloaded(path string, bytes int64)
+	// FileHashed informa que o digest de um objeto foi obtido (hashed de total)
+	FileHashed(path string, hashed, total int)
+}
+
+// SetManifestObserver define quem recebe o andamento da montagem do manifesto
+func (c *S3Client) SetManifestObserver(observer ManifestObserver) {
+	c.observer = observer
+}
+
+// SetDigestCache define onde os SHA1 calculados dos objetos serão guardados
+func (c *S3Client) SetDigestCache(cache DigestCache) {
+	c.digests = cache
+}
+
+// BuildManifest lista os objetos do caminho configurado e obtém o SHA1 de cada um sem gravá-los em disco.
+// O digest vem do cache, do checksum SHA1 armazenado no S3 ou, em último caso, da leitura em streaming do objeto.
+func (c *S3Client) BuildManifest(ctx context.Context) (files []ObjectFile, err error) {
+	prefix := c.prefix()
+	ctx, span := tracing.Start(ctx, "s3.BuildManifest", tracing.S3Bucket.String(c.config.S3BucketName), tracing.S3Key.String(prefix))
+	defer func() { tracing.End(span, err) }()
+
+	slog.InfoContext(ctx, "Montando manifesto do S3", "bucket", c.config.S3BucketName, "prefix", prefix)
+
+	var objects []types.Object
+	paginator := s3.NewListObjectsV2Paginator(c.client, &s3.ListObjectsV2Input{
+		Bucket: aws.String(c.config.S3BucketName),
+		Prefix: aws.String(prefix),
+	})
+	for paginator.HasMorePages() {
+		page, err := paginator.NextPage(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("erro ao listar objetos do S3: %w", err)
+		}
+
+		for _, obj := range page.Contents {
+			key := aws.ToString(obj.Key)
+			path := strings.TrimPrefix(key, prefix)
+
+			// Pular diretórios e arquivos ocultos, como o deploy de pastas locais
+			if strings.HasSuffix(key, "/") || ignoreObject(path) {
+				continue
+			}
+
+			objects = append(objects, obj)
+			files = append(files, ObjectFile{
+				Key:  key,
+				Path: path,
+				Size: aws.ToInt64(obj.Size),
+			})
+		}
+	}
+
+	// Calcular os digests em paralelo; o primeiro erro cancela os downloads em andamento
+	// e interrompe o agendamento dos objetos restantes
+	digestCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	var wg sync.WaitGroup
+	var once sync.Once
+	var digestErr error
+	var hashed atomic.Int64
+	sem := make(chan struct{}, manifestConcurrency)
+schedule:
+	for i := range files {
+		select {
+		case sem <- struct{}{}:
+		case <-digestCtx.Done():
+			break schedule
+		}
+		if digestCtx.Err() != nil {
+			<-sem
+			break
+		}
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			defer func() { <-sem }()
+
+			sum, downloaded, err := c.objectDigest(digestCtx, objects[i])
+			if err != nil {
+				once.Do(func() {
+					digestErr = fmt.Errorf("erro ao calcular digest de %s: %w", files[i].Key, err)
+					cancel()
+				})
+				return
+			}
+			files[i].SHA1 = sum
+
+			if c.observer != nil {
+				if downloaded {
+					c.observer.FileDownloaded(files[i].Path, files[i].Size)
+				}
+				c.observer.FileHashed(files[i].Path, int(hashed.Add(1)), len(files))
+			}
+		}(i)
+	}
+	wg.Wait()
+	if digestErr != nil {
+		return nil, digestErr
+	}
+	// O contexto do chamador foi cancelado antes de todos os objetos serem agendados
+	if err := ctx.Err(); err != nil {
+		return nil, fmt.Errorf("montagem do manifesto interrompida: %w", err)
+	}
+
+	span.SetAttributes(tracing.Files.Int(len(files)))
+	slog.InfoContext(ctx, "Manifesto do S3 montado", "files", len(files), "prefix", prefix)
+	return files, nil
+}
+
+// OpenFile abre um arquivo do caminho configurado para leitura em streaming
+func (c *S3Client) OpenFile(ctx context.Context, path string) (io.ReadCloser, error) {
+	return c.getObject(ctx, c.prefix()+path)
+}
+
+// getObject abre um objeto do bucket para leitura, contabilizando nas métricas o objeto e os bytes lidos.
+// O span do download é encerrado quando o corpo é fechado.
+func (c *S3Client) getObject(ctx context.Context, key string) (io.ReadCloser, error) {
+	ctx, span := tracing.StartClient(ctx, "s3.GetObject", tracing.S3Bucket.String(c.config.S3BucketName), tracing.S3Key.String(key))
+
+	resp, err := c.client.GetObject(ctx, &s3.GetObjectInput{
+		Bucket: aws.String(c.config.S3BucketName),
+		Key:    aws.String(key),
+	})
+	if err != nil {
+		err = fmt.Errorf("erro ao obter objeto do S3: %w", err)
+		tracing.End(span, err)
+		return nil, err
+	}
+	metrics.S3Objects.Inc()
+	return &countingBody{ReadCloser: resp.Body, span: span}, nil
+}
+
+// countingBody contabiliza nas métricas os bytes lidos do corpo de um objeto
+type countingBody struct {
+	io.ReadCloser
+	span  trace.Span
+	bytes int64
+}
+
+func (b *countingBody) Read(p []byte) (int, error) {
+	n, err := b.ReadCloser.Read(p)
+	b.bytes += int64(n)
+	metrics.S3Bytes.Add(float64(n))
+	return n, err
+}
+
+// Close fecha o corpo e encerra o span do download com o total de bytes lidos
+func (b *countingBody) Close() error {
+	err := b.ReadCloser.Close()
+	b.span.SetAttributes(tracing.Bytes.Int64(b.bytes))
+	tracing.End(b.span, nil)
+	return err
+}
+
+// objectDigest obtém o SHA1 hexadecimal de um objeto, indicando se foi preciso baixá-lo
+func (c *S3Client) objectDigest(ctx context.Context, obj types.Object) (string, bool, error) {
+	key := aws.ToString(obj.Key)
+	etag := aws.ToString(obj.ETag)
+	cacheKey := c.config.S3BucketName + "/" + key
+
+	if c.digests != nil {
+		if sum, ok := c.digests.GetObjectDigest(cacheKey, etag); ok {
+			return sum, false, nil
+		}
+	}
+
+	sum, err := c.storedChecksum(ctx, obj)
+	if err != nil {
+		return "", false, err
+	}
+	downloaded := false
+	if sum == "" {
+		sum, err = c.streamDigest(ctx, key)
+		if err != nil {
+			return "", false, err
+		}
+		downloaded = true
+	}
+
+	if c.digests != nil {
+		if err := c.digests.SaveObjectDigest(cacheKey, etag, sum); err != nil {
+			slog.WarnContext(ctx, "Erro ao guardar digest no cache", "key", key, "error", err)
+		}
+	}
+	return sum, downloaded, nil
+}
+
+// storedChecksum retorna o checksum SHA1 guardado pelo S3, quando o objeto foi enviado com ele
+func (c *S3Client) storedChecksum(ctx context.Context, obj types.Object) (string, error) {
+	hasSHA1 := false
+	for _, algorithm := range obj.ChecksumAlgorithm {
+		if algorithm == types.ChecksumAlgorithmSha1 {
+			hasSHA1 = true
+		}
+	}
+	if !hasSHA1 {
+		return "", nil
+	}
+
+	ctx, span := tracing.StartClient(ctx, "s3.HeadObject", tracing.S3Bucket.String(c.config.S3BucketName), tracing.S3Key.String(aws.ToString(obj.Key)))
+	resp, err := c.client.HeadObject(ctx, &s3.HeadObjectInput{
+		Bucket:       aws.String(c.config.S3BucketName),
+		Key:          obj.Key,
+		ChecksumMode: types.ChecksumModeEnabled,
+	})
+	tracing.End(span, err)
+	if err != nil {
+		return "", fmt.Errorf("erro ao consultar objeto do S3: %w", err)
+	}
+
+	// Checksums de uploads multipart ("<digest>-<partes>") não correspondem ao SHA1 do arquivo
+	checksum := aws.ToString(resp.ChecksumSHA1)
+	if checksum == "" || strings.Contains(checksum, "-") {
+		return "", nil
+	}
+
+	raw, err := base64.StdEncoding.DecodeString(checksum)
+	if err != nil {
+		return "", nil
+	}
+	return hex.EncodeToString(raw), nil
+}
+
+// streamDigest calcula o SHA1 de um objeto lendo seu conteúdo sem gravá-lo em disco
+func (c *S3Client) streamDigest(ctx context.Context, key string) (string, error) {
+	body, err := c.getObject(ctx, key)
+	if err != nil {
+		return "", err
+	}
+	defer body.Close()
+
+	h := sha1.New()
+	if _, err := io.Copy(h, body); err != nil {
+		return "", fmt.Errorf("erro ao ler conteúdo do objeto: %w", err)
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// prefix retorna o caminho configurado no bucket, terminado em "/"
+func (c *S3Client) prefix() string {
+	prefix := c.config.S3Path
+	if prefix != "" && !strings.HasSuffix(prefix, "/") {
+		prefix += "/"
+	}
+	return prefix
+}
+
+// ignoreObject indica se um arquivo oculto deve ficar fora do deploy (exceto .well-known)
+func ignoreObject(path string) bool {
+	if strings.HasPrefix(path, ".") || strings.Contains(path, "/.") || strings.HasPrefix(path, "__MACOS") {
+		return !strings.HasPrefix(path, ".well-known/")
+	}
+	return false
+}
@@ -4,7 +4,7 @@ import (
 	"context"
 	"fmt"
 	"io"
-	"log"
+	"log/slog"
 	"os"
 	"path/filepath"
 	"strings"
@@ -13,12 +13,15 @@ import (
 	awsconfig "github.com/aws/aws-sdk-go-v2/config"
 	"github.com/aws/aws-sdk-go-v2/service/s3"
 	"github.com/kodestech/poc-netlify/internal/config"
+	"github.com/kodestech/poc-netlify/internal/tracing"
 )
 
 // S3Client encapsula a integração com o AWS S3
 type S3Client struct {
-	client *s3.Client
-	config *config.Config
+	client   *s3.Client
+	config   *config.Config
+	digests  DigestCache
+	observer ManifestObserver
 }
 
 // NewS3Client cria um novo cliente S3
@@ -27,7 +30,7 @@ func NewS3Client(cfg *config.Config) (*S3Client, error) {
 
 	// Verificar se estamos usando um endpoint personalizado (MinIO)
 	if cfg.S3Endpoint != "" {
-		log.Printf("Usando endpoint S3 personalizado: %s", cfg.S3Endpoint)
+		slog.Debug("Usando endpoint S3 personalizado", "endpoint", cfg.S3Endpoint)
 		
 		// Configurar cliente para MinIO
 		customResolver := aws.EndpointResolverWithOptionsFunc(func(service, region string, options ...interface{}) (aws.Endpoint, error) {
@@ -86,8 +89,11 @@ func NewS3Client(cfg *config.Config) (*S3Client, error) {
 }
 
 // DownloadFiles baixa os arquivos do bucket S3 para um diretório local
-func (c *S3Client) DownloadFiles(ctx context.Context, localDir string) error {
-	log.Printf("Baixando arquivos do bucket %s, caminho %s para %s", c.config.S3BucketName, c.config.S3Path, localDir)
+func (c *S3Client) DownloadFiles(ctx context.Context, localDir string) (err error) {
+	ctx, span := tracing.Start(ctx, "s3.DownloadFiles", tracing.S3Bucket.String(c.config.S3BucketName), tracing.S3Key.String(c.config.S3Path))
+	defer func() { tracing.End(span, err) }()
+
+	slog.InfoContext(ctx, "Baixando arquivos do S3", "bucket", c.config.S3BucketName, "s3_path", c.config.S3Path, "dir", localDir)
 
 	// Criar diretório local se não existir
 	if err := os.MkdirAll(localDir, 0755); err != nil {
@@ -139,25 +145,23 @@ func (c *S3Client) DownloadFiles(ctx context.Context, localDir string) error {
 			}
 
 			fileCount++
-			log.Printf("Arquivo baixado: %s", key)
+			slog.DebugContext(ctx, "Arquivo baixado", "key", key)
 		}
 	}
 
-	log.Printf("Download concluído: %d arquivos baixados para %s", fileCount, localDir)
+	span.SetAttributes(tracing.Files.Int(fileCount))
+	slog.InfoContext(ctx, "Download do S3 concluído", "files", fileCount, "dir", localDir)
 	return nil
 }
 
 // downloadFile baixa um único arquivo do S3
 func (c *S3Client) downloadFile(ctx context.Context, s3Key, localPath string) error {
 	// Obter o objeto do S3
-	resp, err := c.client.GetObject(ctx, &s3.GetObjectInput{
-		Bucket: aws.String(c.config.S3BucketName),
-		Key:    aws.String(s3Key),
-	})
+	body, err := c.getObject(ctx, s3Key)
 	if err != nil {
-		return fmt.Errorf("erro ao obter objeto do S3: %w", err)
+		return err
 	}
-	defer resp.Body.Close()
+	defer body.Close()
 
 	// Criar arquivo local
 	file, err := os.Create(localPath)
@@ -167,7 +171,7 @@ func (c *S3Client) downloadFile(ctx context.Context, s3Key, localPath string) er
 	defer file.Close()
 
 	// Copiar conteúdo do S3 para o arquivo local
-	_, err = io.Copy(file, resp.Body)
+	_, err = io.Copy(file, body)
 	if err != nil {
 		return fmt.Errorf("erro ao copiar conteúdo do arquivo: %w", err)
 	}
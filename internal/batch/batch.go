@@ -0,0 +1,210 @@
+package batch
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/kodestech/poc-netlify/internal/logging"
+	"github.com/kodestech/poc-netlify/internal/netlify"
+)
+
+// DefaultNamePattern é o padrão usado para nomear os sites quando nenhum é informado
+const DefaultNamePattern = "<account>-<site>"
+
+var (
+	// ErrInvalidAccount indica um nome de pasta de conta inválido
+	ErrInvalidAccount = errors.New("nome de conta inválido")
+	// ErrNoSites indica que a pasta da conta não tem nenhuma pasta de site
+	ErrNoSites = errors.New("nenhuma pasta de site encontrada")
+	// ErrNothingPublished indica que nenhum site do lote foi publicado
+	ErrNothingPublished = errors.New("nenhum site do lote foi publicado")
+)
+
+// invalidSiteNameChars encontra caracteres não aceitos em nomes de sites da Netlify
+var invalidSiteNameChars = regexp.MustCompile(`[^a-z0-9-]+`)
+
+// Options contém os parâmetros de um deploy em lote
+type Options struct {
+	// AccountsDir é o diretório que contém as pastas das contas (ex: web/accounts)
+	AccountsDir string
+	// Account é o nome da pasta da conta a ser publicada
+	Account string
+	// NamePattern define o nome do site na Netlify; aceita <account> e <site>
+	NamePattern string
+	// Concurrency limita quantos sites são publicados ao mesmo tempo
+	Concurrency int
+	// OnSiteDone é chamado sempre que o deploy de um site termina (opcional)
+	OnSiteDone func(result SiteResult)
+}
+
+// SiteResult contém o resultado do deploy de um site do lote
+type SiteResult struct {
+	Folder     string `json:"folder" example:"bolo-brigadeiro" swagger:"description=Pasta do site dentro da conta"`
+	SiteName   string `json:"site_name" example:"elizio-bolo-brigadeiro" swagger:"description=Nome do site na Netlify"`
+	SiteID     string `json:"site_id,omitempty" example:"a1b2c3d4" swagger:"description=ID do site na Netlify"`
+	SiteURL    string `json:"site_url,omitempty" example:"https://elizio-bolo-brigadeiro.netlify.app" swagger:"description=URL do site"`
+	DeployID   string `json:"deploy_id,omitempty" example:"5f1b2c3d4e5f6a7b8c9d0e1f" swagger:"description=ID do deploy na Netlify"`
+	State      string `json:"state,omitempty" example:"ready" swagger:"description=Estado final do deploy"`
+	Success    bool   `json:"success" example:"true" swagger:"description=Indica se o deploy do site foi concluído"`
+	Error      string `json:"error,omitempty" swagger:"description=Erro ocorrido no deploy do site"`
+	DurationMs int64  `json:"duration_ms" example:"22000" swagger:"description=Duração do deploy do site em milissegundos"`
+}
+
+// Report contém o resultado de um deploy em lote
+type Report struct {
+	Account    string       `json:"account" example:"elizio" swagger:"description=Conta publicada"`
+	Total      int          `json:"total" example:"2" swagger:"description=Quantidade de sites encontrados"`
+	Succeeded  int          `json:"succeeded" example:"2" swagger:"description=Quantidade de sites publicados com sucesso"`
+	Failed     int          `json:"failed" example:"0" swagger:"description=Quantidade de sites com erro"`
+	Sites      []SiteResult `json:"sites" swagger:"description=Resultado de cada site"`
+	StartedAt  time.Time    `json:"started_at"`
+	FinishedAt time.Time    `json:"finished_at"`
+}
+
+// SiteName monta o nome do site na Netlify a partir do padrão, da conta e da pasta do site
+func SiteName(pattern, account, site string) string {
+	if pattern == "" {
+		pattern = DefaultNamePattern
+	}
+
+	name := strings.NewReplacer("<account>", account, "<site>", site).Replace(pattern)
+	name = invalidSiteNameChars.ReplaceAllString(strings.ToLower(name), "-")
+	return strings.Trim(name, "-")
+}
+
+// ListSites retorna as pastas de sites de uma conta, em ordem alfabética
+func ListSites(accountsDir, account string) ([]string, error) {
+	if account == "" || account != filepath.Base(account) || account == "." || account == ".." {
+		return nil, fmt.Errorf("%w: %q", ErrInvalidAccount, account)
+	}
+
+	entries, err := os.ReadDir(filepath.Join(accountsDir, account))
+	if err != nil {
+		return nil, fmt.Errorf("erro ao ler pasta da conta: %w", err)
+	}
+
+	var sites []string
+	for _, entry := range entries {
+		if entry.IsDir() && !strings.HasPrefix(entry.Name(), ".") {
+			sites = append(sites, entry.Name())
+		}
+	}
+
+	return sites, nil
+}
+
+// DeployAccount publica todas as pastas de sites de uma conta, criando os sites que não existem
+func DeployAccount(ctx context.Context, client *netlify.Client, opts Options) (*Report, error) {
+	sites, err := ListSites(opts.AccountsDir, opts.Account)
+	if err != nil {
+		return nil, err
+	}
+	if len(sites) == 0 {
+		return nil, fmt.Errorf("%w: conta %s", ErrNoSites, opts.Account)
+	}
+
+	concurrency := opts.Concurrency
+	if concurrency <= 0 {
+		concurrency = 1
+	}
+
+	ctx = logging.With(ctx, "account_id", opts.Account)
+	slog.InfoContext(ctx, "Iniciando deploy em lote", "sites", len(sites), "concurrency", concurrency)
+
+	report := &Report{
+		Account:   opts.Account,
+		Total:     len(sites),
+		Sites:     make([]SiteResult, len(sites)),
+		StartedAt: time.Now(),
+	}
+
+	// O andamento de cada site não é repassado ao contexto do lote
+	siteCtx := netlify.WithProgress(ctx, nil)
+
+	var wg sync.WaitGroup
+	var mu sync.Mutex
+	sem := make(chan struct{}, concurrency)
+	for i, folder := range sites {
+		wg.Add(1)
+		go func(i int, folder string) {
+			defer wg.Done()
+			sem <- struct{}{}
+			defer func() { <-sem }()
+
+			result := deploySite(siteCtx, client, opts, folder)
+
+			mu.Lock()
+			report.Sites[i] = result
+			if result.Success {
+				report.Succeeded++
+			} else {
+				report.Failed++
+			}
+			mu.Unlock()
+
+			if opts.OnSiteDone != nil {
+				opts.OnSiteDone(result)
+			}
+		}(i, folder)
+	}
+	wg.Wait()
+
+	report.FinishedAt = time.Now()
+	level := slog.LevelInfo
+	if report.Failed > 0 {
+		level = slog.LevelWarn
+	}
+	slog.Log(ctx, level, "Deploy em lote finalizado", "succeeded", report.Succeeded, "failed", report.Failed)
+	return report, nil
+}
+
+// deploySite cria (se necessário) e publica o site de uma pasta da conta
+func deploySite(ctx context.Context, client *netlify.Client, opts Options, folder string) SiteResult {
+	startedAt := time.Now()
+	result := SiteResult{
+		Folder:   folder,
+		SiteName: SiteName(opts.NamePattern, opts.Account, folder),
+	}
+	ctx = logging.With(ctx, "site_name", result.SiteName, "folder", folder)
+
+	fail := func(err error) SiteResult {
+		slog.ErrorContext(ctx, "Erro no deploy do site", "deploy_id", result.DeployID, "error", err)
+		result.Error = err.Error()
+		result.DurationMs = time.Since(startedAt).Milliseconds()
+		return result
+	}
+
+	site, err := client.CreateOrGetSite(ctx, result.SiteName, "")
+	if err != nil {
+		return fail(err)
+	}
+	result.SiteID = site.ID
+	result.SiteURL = site.URL
+	ctx = logging.With(ctx, "site_id", site.ID)
+
+	folderPath := filepath.Join(opts.AccountsDir, opts.Account, folder)
+	deploy, err := client.DeployLocalFolder(ctx, site, folderPath)
+	if err != nil {
+		return fail(err)
+	}
+	result.DeployID = deploy.ID
+
+	deploy, err = client.WaitForDeploy(ctx, deploy.ID)
+	if err != nil {
+		return fail(err)
+	}
+
+	result.State = deploy.State
+	result.Success = true
+	result.DurationMs = time.Since(startedAt).Milliseconds()
+	slog.InfoContext(ctx, "Site publicado com sucesso", "deploy_id", deploy.ID)
+	return result
+}
@@ -1,9 +1,13 @@
 package config
 
 import (
+	"encoding/base64"
 	"fmt"
 	"os"
+	"path"
+	"strconv"
 	"strings"
+	"time"
 
 	"github.com/joho/godotenv"
 )
@@ -11,8 +15,16 @@ import (
 // Config contém todas as configurações necessárias para a aplicação
 type Config struct {
 	// Netlify
-	NetlifyToken string
-	BaseDomain   string
+	NetlifyToken      string
+	NetlifyAPIURL     string
+	NetlifyMaxRetries int
+	BaseDomain        string
+
+	// Conta padrão, criada a partir de NETLIFY_TOKEN quando definido
+	DefaultAccount string
+
+	// Conta em nome da qual a configuração opera (definida por ForAccount)
+	AccountID string
 
 	// AWS
 	AWSAccessKeyID     string
@@ -22,9 +34,55 @@ type Config struct {
 	S3Endpoint         string
 
 	// API
-	APIPort string
+	APIPort            string
+	AdminAPIKey        string
+	CORSAllowedOrigins []string
+
+	// Jobs de deploy
+	JobWorkers        int
+	JobQueueSize      int
+	DeployWaitTimeout time.Duration
+
+	// Sites temporários (cleanup_after)
+	TestSiteTTL     time.Duration
+	CleanupInterval time.Duration
+
+	// Limites dos arquivos compactados (.zip, .tar, .tar.gz) e dos arquivos enviados em files[] para deploy
+	ArchiveMaxFiles int
+	ArchiveMaxBytes int64
+
+	// Armazenamento local e chave AES-256 que cifra os tokens das contas no banco
+	DataPath          string
+	DataEncryptionKey []byte
+
+	// Logs (JSON) com rotação por tamanho
+	LogPath       string
+	LogLevel      string
+	LogMaxSizeMB  int
+	LogMaxBackups int
+
+	// Tracing (OpenTelemetry): stdout, otlp ou vazio para desativar
+	TracingExporter    string
+	TracingServiceName string
+
+	// DNS (memory, route53 ou rfc2136; vazio desativa a criação automática de registros)
+	DNSProvider          string
+	DNSRecordTTL         int
+	DNSResolver          string
+	Route53HostedZoneID  string
+	RFC2136Server        string
+	RFC2136Zone          string
+	RFC2136TSIGKey       string
+	RFC2136TSIGSecret    string
+	RFC2136TSIGAlgorithm string
+
+	// Deploy em lote
+	AccountsPath     string
+	BatchNamePattern string
+	BatchConcurrency int
 
 	// Aplicação
+	S3Prefix         string
 	Username         string
 	CustomDomain     string
 	S3Path           string
@@ -38,6 +96,7 @@ func LoadConfig() (*Config, error) {
 
 	config := &Config{
 		NetlifyToken:       os.Getenv("NETLIFY_TOKEN"),
+		NetlifyAPIURL:      os.Getenv("NETLIFY_API_URL"),
 		BaseDomain:         os.Getenv("BASE_DOMAIN"),
 		AWSAccessKeyID:     os.Getenv("AWS_ACCESS_KEY_ID"),
 		AWSSecretAccessKey: os.Getenv("AWS_SECRET_ACCESS_KEY"),
@@ -45,6 +104,24 @@ func LoadConfig() (*Config, error) {
 		S3BucketName:       os.Getenv("S3_BUCKET_NAME"),
 		S3Endpoint:         os.Getenv("S3_ENDPOINT"),
 		APIPort:            os.Getenv("API_PORT"),
+		AdminAPIKey:        os.Getenv("ADMIN_API_KEY"),
+		DataPath:           os.Getenv("DATA_PATH"),
+		LogPath:            os.Getenv("LOG_FILE"),
+		LogLevel:           os.Getenv("LOG_LEVEL"),
+		TracingExporter:    strings.ToLower(os.Getenv("TRACING_EXPORTER")),
+		TracingServiceName: os.Getenv("OTEL_SERVICE_NAME"),
+		DefaultAccount:     os.Getenv("DEFAULT_ACCOUNT"),
+		AccountsPath:       os.Getenv("ACCOUNTS_PATH"),
+		BatchNamePattern:   os.Getenv("BATCH_NAME_PATTERN"),
+
+		DNSProvider:          strings.ToLower(os.Getenv("DNS_PROVIDER")),
+		DNSResolver:          os.Getenv("DNS_RESOLVER"),
+		Route53HostedZoneID:  os.Getenv("ROUTE53_HOSTED_ZONE_ID"),
+		RFC2136Server:        os.Getenv("RFC2136_SERVER"),
+		RFC2136Zone:          os.Getenv("RFC2136_ZONE"),
+		RFC2136TSIGKey:       os.Getenv("RFC2136_TSIG_KEY"),
+		RFC2136TSIGSecret:    os.Getenv("RFC2136_TSIG_SECRET"),
+		RFC2136TSIGAlgorithm: os.Getenv("RFC2136_TSIG_ALGORITHM"),
 	}
 
 	// Definir valores padrão
@@ -52,16 +129,90 @@ func LoadConfig() (*Config, error) {
 		config.APIPort = "8080"
 	}
 
+	// Origens autorizadas a chamar a API pelo navegador (separadas por vírgula)
+	for _, origin := range strings.Split(os.Getenv("CORS_ALLOWED_ORIGINS"), ",") {
+		if origin = strings.TrimSpace(origin); origin != "" {
+			config.CORSAllowedOrigins = append(config.CORSAllowedOrigins, origin)
+		}
+	}
+
+	// Definir o arquivo padrão do banco de dados local
+	if config.DataPath == "" {
+		config.DataPath = "netlify-deploy.db"
+	}
+
+	// Ler a chave que cifra os tokens das contas no banco (32 bytes em base64, ex: openssl rand -base64 32).
+	// Ela é exigida ao abrir o banco (servidor); a CLI não a utiliza.
+	if key := os.Getenv("DATA_ENCRYPTION_KEY"); key != "" {
+		decoded, err := base64.StdEncoding.DecodeString(key)
+		if err != nil || len(decoded) != 32 {
+			return nil, fmt.Errorf("DATA_ENCRYPTION_KEY inválida: use 32 bytes em base64 (ex: openssl rand -base64 32)")
+		}
+		config.DataEncryptionKey = decoded
+	}
+
+	// Definir o arquivo, o nível e a rotação dos logs
+	if config.LogPath == "" {
+		config.LogPath = "netlify-deploy.log"
+	}
+	if config.LogLevel == "" {
+		config.LogLevel = "info"
+	}
+	config.LogMaxSizeMB = intFromEnv("LOG_MAX_SIZE_MB", 10)
+	config.LogMaxBackups = intFromEnv("LOG_MAX_BACKUPS", 5)
+
+	// Definir o nome do serviço nos traces
+	if config.TracingServiceName == "" {
+		config.TracingServiceName = "netlify-deploy"
+	}
+
+	// Definir o ID da conta padrão
+	if config.DefaultAccount == "" {
+		config.DefaultAccount = "default"
+	}
+
+	// Definir a pasta das contas e o padrão de nomes dos sites do deploy em lote
+	if config.AccountsPath == "" {
+		config.AccountsPath = "web/accounts"
+	}
+	if config.BatchNamePattern == "" {
+		config.BatchNamePattern = "<account>-<site>"
+	}
+	config.BatchConcurrency = intFromEnv("BATCH_CONCURRENCY", 4)
+
+	// Definir limites do pool de jobs de deploy
+	config.JobWorkers = intFromEnv("JOB_WORKERS", 4)
+	config.JobQueueSize = intFromEnv("JOB_QUEUE_SIZE", 100)
+
+	// Definir a validade dos sites temporários e a frequência da limpeza
+	config.TestSiteTTL = durationFromEnv("TEST_SITE_TTL", 24*time.Hour)
+	config.CleanupInterval = durationFromEnv("CLEANUP_INTERVAL", time.Minute)
+
+	// Definir quanto tempo aguardar um deploy ficar pronto na Netlify
+	config.DeployWaitTimeout = durationFromEnv("DEPLOY_WAIT_TIMEOUT", 15*time.Minute)
+
+	// Definir os limites do conteúdo extraído dos arquivos compactados enviados para deploy
+	config.ArchiveMaxFiles = intFromEnv("ARCHIVE_MAX_FILES", 10000)
+	config.ArchiveMaxBytes = int64(intFromEnv("ARCHIVE_MAX_SIZE_MB", 500)) << 20
+
+	// Definir o TTL dos registros DNS criados automaticamente
+	config.DNSRecordTTL = intFromEnv("DNS_TTL", 300)
+
+	// Definir o endereço da API da Netlify (pode apontar para um servidor falso em testes)
+	if config.NetlifyAPIURL == "" {
+		config.NetlifyAPIURL = "https://api.netlify.com/api/v1"
+	}
+
+	// Definir quantas vezes repetir chamadas à Netlify que falham com 429 ou 5xx
+	config.NetlifyMaxRetries = intFromEnv("NETLIFY_MAX_RETRIES", 4)
+
 	// Definir valor padrão para o domínio base
 	if config.BaseDomain == "" {
 		config.BaseDomain = "sites.kodestech.com.br"
 	}
 
 	// Validar configurações obrigatórias
-	if config.NetlifyToken == "" {
-		return nil, fmt.Errorf("NETLIFY_TOKEN não definido")
-	}
-
+	// O NETLIFY_TOKEN é opcional: cada conta possui seu próprio token
 	if config.AWSAccessKeyID == "" || config.AWSSecretAccessKey == "" {
 		return nil, fmt.Errorf("credenciais AWS não definidas")
 	}
@@ -86,12 +237,59 @@ func (c *Config) SetDeployParams(username, customDomain, s3Path string) error {
 		return fmt.Errorf("nome de usuário não pode ser vazio")
 	}
 
+	if strings.Contains(s3Path, "..") {
+		return fmt.Errorf("caminho S3 inválido: %s", s3Path)
+	}
+
 	c.Username = username
 	c.CustomDomain = customDomain
 	c.S3Path = s3Path
+	if c.S3Prefix != "" {
+		c.S3Path = path.Join(c.S3Prefix, s3Path)
+	}
 
 	// Gerar subdomínio da Netlify
 	c.NetlifySubdomain = strings.ToLower(username) + "." + c.BaseDomain
 
 	return nil
 }
+
+// ForAccount retorna uma cópia da configuração com os dados de uma conta.
+// A configuração original não é alterada.
+func (c *Config) ForAccount(accountID, netlifyToken, baseDomain, s3Prefix string) *Config {
+	cfg := *c
+	cfg.AccountID = accountID
+	cfg.NetlifyToken = netlifyToken
+	if baseDomain != "" {
+		cfg.BaseDomain = baseDomain
+	}
+	cfg.S3Prefix = strings.Trim(s3Prefix, "/")
+	return &cfg
+}
+
+// Secrets retorna os valores da configuração que nunca devem aparecer nos logs
+func (c *Config) Secrets() []string {
+	secrets := []string{c.NetlifyToken, c.AWSAccessKeyID, c.AWSSecretAccessKey, c.AdminAPIKey, c.RFC2136TSIGSecret}
+	if len(c.DataEncryptionKey) > 0 {
+		secrets = append(secrets, base64.StdEncoding.EncodeToString(c.DataEncryptionKey))
+	}
+	return secrets
+}
+
+// intFromEnv lê uma variável de ambiente inteira e positiva, retornando o valor padrão caso ausente ou inválida
+func intFromEnv(key string, def int) int {
+	value, err := strconv.Atoi(os.Getenv(key))
+	if err != nil || value <= 0 {
+		return def
+	}
+	return value
+}
+
+// durationFromEnv lê uma variável de ambiente com uma duração positiva (ex: 24h, 30m), retornando o valor padrão caso ausente ou inválida
+func durationFromEnv(key string, def time.Duration) time.Duration {
+	value, err := time.ParseDuration(os.Getenv(key))
+	if err != nil || value <= 0 {
+		return def
+	}
+	return value
+}
@@ -0,0 +1,105 @@
+package dns
+
+import (
+	"context"
+	"log/slog"
+	"net"
+	"sort"
+	"sync"
+)
+
+// MemoryProvider mantém os registros DNS em memória, para uso local e testes
+type MemoryProvider struct {
+	mu      sync.RWMutex
+	records map[string]Record
+}
+
+// NewMemoryProvider cria um provedor DNS em memória vazio
+func NewMemoryProvider() *MemoryProvider {
+	return &MemoryProvider{
+		records: make(map[string]Record),
+	}
+}
+
+// CreateRecord cria ou substitui o registro
+func (p *MemoryProvider) CreateRecord(ctx context.Context, record Record) error {
+	record.Name = normalizeName(record.Name)
+	record.TTL = ttlOrDefault(record.TTL)
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.records[memoryKey(record.Name, record.Type)] = record
+	slog.InfoContext(ctx, "Registro DNS criado em memória", "type", record.Type, "name", record.Name, "value", record.Value)
+	return nil
+}
+
+// DeleteRecord remove o registro com o nome e o tipo informados
+func (p *MemoryProvider) DeleteRecord(ctx context.Context, name string, recordType RecordType) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	delete(p.records, memoryKey(normalizeName(name), recordType))
+	return nil
+}
+
+// Lookup retorna o registro com o nome e o tipo informados
+func (p *MemoryProvider) Lookup(name string, recordType RecordType) (Record, bool) {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	record, ok := p.records[memoryKey(normalizeName(name), recordType)]
+	return record, ok
+}
+
+// Records retorna todos os registros ordenados por nome e tipo
+func (p *MemoryProvider) Records() []Record {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+
+	records := make([]Record, 0, len(p.records))
+	for _, record := range p.records {
+		records = append(records, record)
+	}
+	sort.Slice(records, func(i, j int) bool {
+		if records[i].Name != records[j].Name {
+			return records[i].Name < records[j].Name
+		}
+		return records[i].Type < records[j].Type
+	})
+	return records
+}
+
+func memoryKey(name string, recordType RecordType) string {
+	return string(recordType) + " " + name
+}
+
+// LookupCNAME retorna o destino do CNAME do host, ou o próprio host se não houver CNAME
+func (p *MemoryProvider) LookupCNAME(ctx context.Context, host string) (string, error) {
+	if record, ok := p.Lookup(host, TypeCNAME); ok {
+		return normalizeName(record.Value) + ".", nil
+	}
+	if _, ok := p.Lookup(host, TypeALIAS); ok {
+		return normalizeName(host) + ".", nil
+	}
+	if _, ok := p.Lookup(host, TypeA); ok {
+		return normalizeName(host) + ".", nil
+	}
+	return "", &net.DNSError{Err: "no such host", Name: host, IsNotFound: true}
+}
+
+// LookupHost retorna os endereços do host. Registros CNAME e ALIAS retornam o próprio destino,
+// já que os nomes externos não são resolvidos em memória.
+func (p *MemoryProvider) LookupHost(ctx context.Context, host string) ([]string, error) {
+	for _, recordType := range []RecordType{TypeA, TypeALIAS, TypeCNAME} {
+		if record, ok := p.Lookup(host, recordType); ok {
+			return []string{record.Value}, nil
+		}
+	}
+	return nil, &net.DNSError{Err: "no such host", Name: host, IsNotFound: true}
+}
+
+// LookupTXT retorna os valores TXT do nome
+func (p *MemoryProvider) LookupTXT(ctx context.Context, name string) ([]string, error) {
+	if record, ok := p.Lookup(name, TypeTXT); ok {
+		return []string{record.Value}, nil
+	}
+	return nil, &net.DNSError{Err: "no such host", Name: name, IsNotFound: true}
+}
@@ -0,0 +1,90 @@
+package dns
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/kodestech/poc-netlify/internal/config"
+)
+
+// RecordType identifica o tipo de um registro DNS
+type RecordType string
+
+const (
+	TypeA     RecordType = "A"
+	TypeALIAS RecordType = "ALIAS"
+	TypeCNAME RecordType = "CNAME"
+	TypeTXT   RecordType = "TXT"
+)
+
+// DefaultTTL é o TTL usado quando o registro não informa um valor
+const DefaultTTL = 300
+
+// ErrUnsupportedRecord é retornado quando o provedor não suporta o tipo de registro (ex: ALIAS no Route53)
+var ErrUnsupportedRecord = errors.New("tipo de registro não suportado pelo provedor DNS")
+
+// Record representa um registro DNS
+type Record struct {
+	Name  string     `json:"name" example:"meu-site.exemplo.com"`
+	Type  RecordType `json:"type" example:"CNAME"`
+	Value string     `json:"value" example:"meu-site.netlify.app"`
+	TTL   int        `json:"ttl" example:"300"`
+}
+
+// Provider cria e remove registros DNS em um provedor
+type Provider interface {
+	// CreateRecord cria o registro, substituindo os registros de mesmo nome e tipo
+	CreateRecord(ctx context.Context, record Record) error
+	// DeleteRecord remove os registros com o nome e o tipo informados. Não falha se não existirem.
+	DeleteRecord(ctx context.Context, name string, recordType RecordType) error
+}
+
+// NewProvider cria o provedor DNS definido em DNS_PROVIDER. Retorna nil se nenhum estiver configurado.
+func NewProvider(cfg *config.Config) (Provider, error) {
+	switch cfg.DNSProvider {
+	case "":
+		return nil, nil
+	case "memory":
+		return NewMemoryProvider(), nil
+	case "route53":
+		provider, err := NewRoute53Provider(cfg)
+		if err != nil {
+			return nil, err
+		}
+		return provider, nil
+	case "rfc2136":
+		provider, err := NewRFC2136Provider(cfg)
+		if err != nil {
+			return nil, err
+		}
+		return provider, nil
+	default:
+		return nil, fmt.Errorf("provedor DNS desconhecido: %s", cfg.DNSProvider)
+	}
+}
+
+// RandomTXTValue gera um valor aleatório para o registro TXT de verificação de domínio
+func RandomTXTValue() (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", fmt.Errorf("erro ao gerar valor do registro TXT: %w", err)
+	}
+	return hex.EncodeToString(b), nil
+}
+
+// normalizeName remove o ponto final e converte o nome para minúsculas
+func normalizeName(name string) string {
+	return strings.ToLower(strings.TrimSuffix(name, "."))
+}
+
+// ttlOrDefault retorna o TTL do registro ou DefaultTTL
+func ttlOrDefault(ttl int) int {
+	if ttl <= 0 {
+		return DefaultTTL
+	}
+	return ttl
+}
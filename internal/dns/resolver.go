@@ -0,0 +1,33 @@
+package dns
+
+import (
+	"context"
+	"net"
+	"time"
+)
+
+// Resolver consulta registros DNS. É satisfeita por *net.Resolver e pelo MemoryProvider.
+type Resolver interface {
+	LookupCNAME(ctx context.Context, host string) (string, error)
+	LookupHost(ctx context.Context, host string) ([]string, error)
+	LookupTXT(ctx context.Context, name string) ([]string, error)
+}
+
+// NewResolver cria o resolver usado para verificar os domínios. Se o provedor DNS também
+// resolver nomes (ex: memory), ele é usado; se server for informado (host:porta), as consultas
+// vão direto para esse servidor; caso contrário, usa o resolver do sistema.
+func NewResolver(provider Provider, server string) Resolver {
+	if resolver, ok := provider.(Resolver); ok {
+		return resolver
+	}
+	if server == "" {
+		return net.DefaultResolver
+	}
+	return &net.Resolver{
+		PreferGo: true,
+		Dial: func(ctx context.Context, network, _ string) (net.Conn, error) {
+			d := net.Dialer{Timeout: 5 * time.Second}
+			return d.DialContext(ctx, network, server)
+		},
+	}
+}
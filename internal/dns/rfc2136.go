@@ -0,0 +1,119 @@
+package dns
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"strconv"
+	"time"
+
+	"github.com/kodestech/poc-netlify/internal/config"
+	mdns "github.com/miekg/dns"
+)
+
+// RFC2136Provider gerencia os registros DNS via atualizações dinâmicas (RFC 2136),
+// útil com BIND, Knot ou PowerDNS locais
+type RFC2136Provider struct {
+	server     string
+	zone       string
+	tsigKey    string
+	tsigSecret string
+	tsigAlg    string
+}
+
+// NewRFC2136Provider cria um provedor DNS para o servidor RFC2136_SERVER e a zona RFC2136_ZONE
+func NewRFC2136Provider(cfg *config.Config) (*RFC2136Provider, error) {
+	if cfg.RFC2136Server == "" || cfg.RFC2136Zone == "" {
+		return nil, fmt.Errorf("RFC2136_SERVER e RFC2136_ZONE são obrigatórios")
+	}
+
+	p := &RFC2136Provider{
+		server: cfg.RFC2136Server,
+		zone:   mdns.Fqdn(normalizeName(cfg.RFC2136Zone)),
+	}
+	if cfg.RFC2136TSIGKey != "" {
+		p.tsigKey = mdns.Fqdn(cfg.RFC2136TSIGKey)
+		p.tsigSecret = cfg.RFC2136TSIGSecret
+		p.tsigAlg = mdns.Fqdn(cfg.RFC2136TSIGAlgorithm)
+		if cfg.RFC2136TSIGAlgorithm == "" {
+			p.tsigAlg = mdns.HmacSHA256
+		}
+	}
+	return p, nil
+}
+
+// CreateRecord substitui os registros de mesmo nome e tipo pelo registro informado.
+// Registros ALIAS não fazem parte do protocolo DNS e não são suportados.
+func (p *RFC2136Provider) CreateRecord(ctx context.Context, record Record) error {
+	if record.Type == TypeALIAS {
+		return ErrUnsupportedRecord
+	}
+
+	value := record.Value
+	switch record.Type {
+	case TypeTXT:
+		value = strconv.Quote(value)
+	case TypeCNAME:
+		value = mdns.Fqdn(value)
+	}
+
+	rr, err := mdns.NewRR(fmt.Sprintf("%s %d IN %s %s", mdns.Fqdn(normalizeName(record.Name)), ttlOrDefault(record.TTL), record.Type, value))
+	if err != nil {
+		return fmt.Errorf("erro ao montar registro %s %s: %w", record.Type, record.Name, err)
+	}
+
+	msg := new(mdns.Msg)
+	msg.SetUpdate(p.zone)
+	msg.RemoveRRset([]mdns.RR{rrHeader(record.Name, record.Type)})
+	msg.Insert([]mdns.RR{rr})
+	if err := p.exchange(ctx, msg); err != nil {
+		return fmt.Errorf("erro ao criar registro %s %s: %w", record.Type, record.Name, err)
+	}
+
+	slog.InfoContext(ctx, "Registro DNS criado via RFC 2136", "type", record.Type, "name", record.Name, "value", record.Value)
+	return nil
+}
+
+// DeleteRecord remove os registros com o nome e o tipo informados
+func (p *RFC2136Provider) DeleteRecord(ctx context.Context, name string, recordType RecordType) error {
+	if recordType == TypeALIAS {
+		return nil
+	}
+
+	msg := new(mdns.Msg)
+	msg.SetUpdate(p.zone)
+	msg.RemoveRRset([]mdns.RR{rrHeader(name, recordType)})
+	if err := p.exchange(ctx, msg); err != nil {
+		return fmt.Errorf("erro ao excluir registro %s %s: %w", recordType, name, err)
+	}
+
+	slog.InfoContext(ctx, "Registro DNS excluído via RFC 2136", "type", recordType, "name", name)
+	return nil
+}
+
+// exchange envia a atualização ao servidor, assinando-a com TSIG quando configurado
+func (p *RFC2136Provider) exchange(ctx context.Context, msg *mdns.Msg) error {
+	client := &mdns.Client{Net: "tcp", Timeout: 10 * time.Second}
+	if p.tsigKey != "" {
+		client.TsigSecret = map[string]string{p.tsigKey: p.tsigSecret}
+		msg.SetTsig(p.tsigKey, p.tsigAlg, 300, time.Now().Unix())
+	}
+
+	resp, _, err := client.ExchangeContext(ctx, msg, p.server)
+	if err != nil {
+		return err
+	}
+	if resp.Rcode != mdns.RcodeSuccess {
+		return fmt.Errorf("servidor DNS respondeu %s", mdns.RcodeToString[resp.Rcode])
+	}
+	return nil
+}
+
+// rrHeader monta o cabeçalho usado para remover um conjunto de registros
+func rrHeader(name string, recordType RecordType) mdns.RR {
+	return &mdns.ANY{Hdr: mdns.RR_Header{
+		Name:   mdns.Fqdn(normalizeName(name)),
+		Rrtype: mdns.StringToType[string(recordType)],
+		Class:  mdns.ClassINET,
+	}}
+}
@@ -0,0 +1,122 @@
+package dns
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"strconv"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	awsconfig "github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/service/route53"
+	"github.com/aws/aws-sdk-go-v2/service/route53/types"
+	"github.com/kodestech/poc-netlify/internal/config"
+)
+
+// Route53Provider gerencia os registros DNS em uma hosted zone do AWS Route53
+type Route53Provider struct {
+	client *route53.Client
+	zoneID string
+}
+
+// NewRoute53Provider cria um provedor DNS para a hosted zone ROUTE53_HOSTED_ZONE_ID,
+// usando as mesmas credenciais AWS do S3
+func NewRoute53Provider(cfg *config.Config) (*Route53Provider, error) {
+	if cfg.Route53HostedZoneID == "" {
+		return nil, fmt.Errorf("ROUTE53_HOSTED_ZONE_ID não definido")
+	}
+
+	// O Route53 é um serviço global; a região é usada apenas para assinar as requisições
+	region := cfg.AWSRegion
+	if region == "" {
+		region = "us-east-1"
+	}
+
+	awsCfg, err := awsconfig.LoadDefaultConfig(context.Background(),
+		awsconfig.WithRegion(region),
+		awsconfig.WithCredentialsProvider(aws.CredentialsProviderFunc(
+			func(ctx context.Context) (aws.Credentials, error) {
+				return aws.Credentials{
+					AccessKeyID:     cfg.AWSAccessKeyID,
+					SecretAccessKey: cfg.AWSSecretAccessKey,
+				}, nil
+			},
+		)),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("erro ao carregar configurações da AWS: %w", err)
+	}
+
+	return &Route53Provider{
+		client: route53.NewFromConfig(awsCfg),
+		zoneID: cfg.Route53HostedZoneID,
+	}, nil
+}
+
+// CreateRecord cria ou substitui o registro (UPSERT). Registros ALIAS não são suportados,
+// pois o alias do Route53 aponta apenas para recursos da AWS.
+func (p *Route53Provider) CreateRecord(ctx context.Context, record Record) error {
+	if record.Type == TypeALIAS {
+		return ErrUnsupportedRecord
+	}
+
+	value := record.Value
+	if record.Type == TypeTXT {
+		value = strconv.Quote(value)
+	}
+
+	set := &types.ResourceRecordSet{
+		Name:            aws.String(normalizeName(record.Name) + "."),
+		Type:            types.RRType(record.Type),
+		TTL:             aws.Int64(int64(ttlOrDefault(record.TTL))),
+		ResourceRecords: []types.ResourceRecord{{Value: aws.String(value)}},
+	}
+	if err := p.change(ctx, types.ChangeActionUpsert, set); err != nil {
+		return fmt.Errorf("erro ao criar registro %s %s no Route53: %w", record.Type, record.Name, err)
+	}
+
+	slog.InfoContext(ctx, "Registro DNS criado no Route53", "type", record.Type, "name", record.Name, "value", record.Value)
+	return nil
+}
+
+// DeleteRecord remove o registro. O Route53 exige o conteúdo atual do registro para excluí-lo,
+// por isso ele é consultado antes.
+func (p *Route53Provider) DeleteRecord(ctx context.Context, name string, recordType RecordType) error {
+	if recordType == TypeALIAS {
+		return nil
+	}
+
+	fqdn := normalizeName(name) + "."
+	out, err := p.client.ListResourceRecordSets(ctx, &route53.ListResourceRecordSetsInput{
+		HostedZoneId:    aws.String(p.zoneID),
+		StartRecordName: aws.String(fqdn),
+		StartRecordType: types.RRType(recordType),
+		MaxItems:        aws.Int32(1),
+	})
+	if err != nil {
+		return fmt.Errorf("erro ao consultar registro %s %s no Route53: %w", recordType, name, err)
+	}
+
+	for _, set := range out.ResourceRecordSets {
+		if normalizeName(aws.ToString(set.Name)) != normalizeName(name) || set.Type != types.RRType(recordType) {
+			continue
+		}
+		if err := p.change(ctx, types.ChangeActionDelete, &set); err != nil {
+			return fmt.Errorf("erro ao excluir registro %s %s no Route53: %w", recordType, name, err)
+		}
+		slog.InfoContext(ctx, "Registro DNS excluído do Route53", "type", recordType, "name", name)
+	}
+	return nil
+}
+
+// change aplica uma alteração na hosted zone
+func (p *Route53Provider) change(ctx context.Context, action types.ChangeAction, set *types.ResourceRecordSet) error {
+	_, err := p.client.ChangeResourceRecordSets(ctx, &route53.ChangeResourceRecordSetsInput{
+		HostedZoneId: aws.String(p.zoneID),
+		ChangeBatch: &types.ChangeBatch{
+			Comment: aws.String("poc-netlify"),
+			Changes: []types.Change{{Action: action, ResourceRecordSet: set}},
+		},
+	})
+	return err
+}
@@ -0,0 +1,126 @@
+package i18n
+
+import (
+	"embed"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Lang identifica um idioma do catálogo de mensagens (tag BCP 47)
+type Lang string
+
+const (
+	PortugueseBR Lang = "pt-BR"
+	English      Lang = "en"
+)
+
+// Default é o idioma usado quando nem a requisição nem a conta definem um idioma suportado
+const Default = PortugueseBR
+
+// Supported lista os idiomas com catálogo de mensagens
+var Supported = []Lang{PortugueseBR, English}
+
+//go:embed locales/*.json
+var locales embed.FS
+
+// catalog guarda as mensagens de cada idioma, indexadas pela chave (ex: error.site_not_found)
+var catalog = loadCatalog()
+
+// loadCatalog lê os arquivos de mensagens embutidos no binário
+func loadCatalog() map[Lang]map[string]string {
+	catalog := make(map[Lang]map[string]string, len(Supported))
+	for _, lang := range Supported {
+		data, err := locales.ReadFile("locales/" + string(lang) + ".json")
+		if err != nil {
+			panic(fmt.Sprintf("catálogo de mensagens %s ausente: %v", lang, err))
+		}
+		messages := make(map[string]string)
+		if err := json.Unmarshal(data, &messages); err != nil {
+			panic(fmt.Sprintf("catálogo de mensagens %s inválido: %v", lang, err))
+		}
+		catalog[lang] = messages
+	}
+	return catalog
+}
+
+// T retorna a mensagem da chave no idioma informado, formatada com args (como fmt.Sprintf).
+// Chaves ausentes no idioma usam o idioma padrão e, em último caso, a própria chave.
+func T(lang Lang, key string, args ...interface{}) string {
+	message, ok := catalog[lang][key]
+	if !ok {
+		if message, ok = catalog[Default][key]; !ok {
+			message = key
+		}
+	}
+	if len(args) > 0 {
+		return fmt.Sprintf(message, args...)
+	}
+	return message
+}
+
+// Messages retorna as mensagens do idioma cujas chaves começam com um dos prefixos informados
+func Messages(lang Lang, prefixes ...string) map[string]string {
+	messages := make(map[string]string)
+	for _, source := range []Lang{Default, lang} {
+		for key, message := range catalog[source] {
+			for _, prefix := range prefixes {
+				if strings.HasPrefix(key, prefix) {
+					messages[key] = message
+					break
+				}
+			}
+		}
+	}
+	return messages
+}
+
+// Parse converte uma tag de idioma (ex: en-US, pt, pt_BR) no idioma suportado correspondente
+func Parse(tag string) (Lang, bool) {
+	tag = strings.ToLower(strings.TrimSpace(strings.ReplaceAll(tag, "_", "-")))
+	primary, _, _ := strings.Cut(tag, "-")
+	for _, lang := range Supported {
+		langPrimary, _, _ := strings.Cut(strings.ToLower(string(lang)), "-")
+		if tag == strings.ToLower(string(lang)) || primary == langPrimary {
+			return lang, true
+		}
+	}
+	return "", false
+}
+
+// Negotiate escolhe o idioma suportado de maior preferência em um cabeçalho Accept-Language
+func Negotiate(acceptLanguage string) (Lang, bool) {
+	type candidate struct {
+		tag     string
+		quality float64
+	}
+
+	var candidates []candidate
+	for _, part := range strings.Split(acceptLanguage, ",") {
+		tag, params, _ := strings.Cut(strings.TrimSpace(part), ";")
+		if tag == "" || tag == "*" {
+			continue
+		}
+		quality := 1.0
+		if q, ok := strings.CutPrefix(strings.TrimSpace(params), "q="); ok {
+			if value, err := strconv.ParseFloat(q, 64); err == nil {
+				quality = value
+			}
+		}
+		if quality > 0 {
+			candidates = append(candidates, candidate{tag: tag, quality: quality})
+		}
+	}
+
+	sort.SliceStable(candidates, func(i, j int) bool {
+		return candidates[i].quality > candidates[j].quality
+	})
+	for _, c := range candidates {
+		if lang, ok := Parse(c.tag); ok {
+			return lang, true
+		}
+	}
+	return "", false
+}
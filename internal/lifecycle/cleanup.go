@@ -0,0 +1,91 @@
+package lifecycle
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"time"
+
+	"github.com/kodestech/poc-netlify/internal/config"
+	"github.com/kodestech/poc-netlify/internal/logging"
+	"github.com/kodestech/poc-netlify/internal/netlify"
+	"github.com/kodestech/poc-netlify/internal/store"
+)
+
+// Cleaner exclui da Netlify os sites temporários cujo prazo expirou.
+// Os agendamentos ficam no banco local, então a limpeza continua após reinícios do servidor.
+type Cleaner struct {
+	config *config.Config
+	store  *store.Store
+}
+
+// NewCleaner cria o responsável pela limpeza dos sites temporários
+func NewCleaner(cfg *config.Config, st *store.Store) *Cleaner {
+	return &Cleaner{
+		config: cfg,
+		store:  st,
+	}
+}
+
+// Run verifica os sites expirados a cada CleanupInterval até o contexto ser cancelado
+func (c *Cleaner) Run(ctx context.Context) {
+	slog.InfoContext(ctx, "Limpeza de sites temporários iniciada", "interval", c.config.CleanupInterval.String())
+
+	ticker := time.NewTicker(c.config.CleanupInterval)
+	defer ticker.Stop()
+
+	for {
+		// Executar imediatamente para recuperar exclusões que venceram com o servidor parado
+		c.RunOnce(ctx)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// RunOnce exclui todos os sites com prazo vencido
+func (c *Cleaner) RunOnce(ctx context.Context) {
+	expired, err := c.store.ListExpiredSites(time.Now())
+	if err != nil {
+		slog.ErrorContext(ctx, "Erro ao listar sites expirados", "error", err)
+		return
+	}
+
+	for _, exp := range expired {
+		if ctx.Err() != nil {
+			return
+		}
+		siteCtx := logging.With(ctx, "account_id", exp.AccountID, "site_id", exp.SiteID, "site_name", exp.SiteName)
+		if err := c.deleteSite(siteCtx, exp); err != nil {
+			// O agendamento é mantido para nova tentativa na próxima verificação
+			slog.ErrorContext(siteCtx, "Erro ao excluir site temporário", "error", err)
+		}
+	}
+}
+
+// deleteSite exclui um site expirado e remove seu agendamento
+func (c *Cleaner) deleteSite(ctx context.Context, exp store.SiteExpiration) error {
+	acc, ok, err := c.store.GetAccount(exp.AccountID)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		slog.WarnContext(ctx, "Conta não existe mais, cancelando exclusão do site")
+		return c.store.CancelSiteDeletion(exp.SiteID)
+	}
+
+	netlifyClient, err := netlify.NewClient(c.config.ForAccount(acc.ID, acc.NetlifyToken, acc.BaseDomain, acc.S3Prefix))
+	if err != nil {
+		return err
+	}
+
+	slog.InfoContext(ctx, "Excluindo site temporário", "expires_at", exp.ExpiresAt.Format(time.RFC3339))
+	if err := netlifyClient.DeleteSite(ctx, exp.SiteID); err != nil && !errors.Is(err, netlify.ErrSiteNotFound) {
+		return err
+	}
+
+	return c.store.CancelSiteDeletion(exp.SiteID)
+}
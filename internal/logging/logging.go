@@ -0,0 +1,136 @@
+package logging
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"strings"
+
+	"go.opentelemetry.io/otel/trace"
+)
+
+// Options define o destino e o nível dos logs da aplicação
+type Options struct {
+	// Path é o arquivo de log; vazio grava apenas na saída padrão
+	Path string
+	// Level é o nível mínimo registrado (debug, info, warn ou error)
+	Level slog.Level
+	// MaxSize é o tamanho, em bytes, a partir do qual o arquivo é rotacionado (0 desativa a rotação)
+	MaxSize int64
+	// MaxBackups é a quantidade de arquivos rotacionados mantidos (path.1, path.2, ...)
+	MaxBackups int
+}
+
+// Setup configura o logger padrão do slog para gravar JSON na saída padrão e no arquivo de log.
+// Os logs feitos com o pacote log passam pelo mesmo logger, no nível info.
+// Tokens, chaves e conteúdos longos são removidos de todos os registros (ver AddSecrets e RedactString).
+// O arquivo retornado deve ser fechado ao encerrar a aplicação (nil quando Path está vazio).
+func Setup(opts Options) (*RotatingFile, error) {
+	var out io.Writer = os.Stdout
+	var file *RotatingFile
+	if opts.Path != "" {
+		var err error
+		file, err = OpenRotatingFile(opts.Path, opts.MaxSize, opts.MaxBackups)
+		if err != nil {
+			return nil, err
+		}
+		out = io.MultiWriter(os.Stdout, file)
+	}
+
+	handler := NewHandler(slog.NewJSONHandler(out, &slog.HandlerOptions{Level: opts.Level}))
+	slog.SetDefault(slog.New(handler))
+
+	return file, nil
+}
+
+// ParseLevel converte o nome de um nível de log (debug, info, warn, error) em slog.Level
+func ParseLevel(name string) (slog.Level, error) {
+	var level slog.Level
+	if err := level.UnmarshalText([]byte(strings.TrimSpace(name))); err != nil {
+		return 0, fmt.Errorf("nível de log inválido: %s", name)
+	}
+	return level, nil
+}
+
+// contextKey é a chave dos campos de log no contexto
+type contextKey struct{}
+
+// With retorna uma cópia de ctx com campos de log adicionais (pares chave/valor, como em slog.Info).
+// Todos os logs feitos com o contexto (slog.InfoContext, slog.ErrorContext...) incluem esses campos.
+func With(ctx context.Context, args ...any) context.Context {
+	var r slog.Record
+	r.Add(args...)
+
+	attrs := append([]slog.Attr(nil), fromContext(ctx)...)
+	r.Attrs(func(a slog.Attr) bool {
+		attrs = append(attrs, a)
+		return true
+	})
+	return context.WithValue(ctx, contextKey{}, attrs)
+}
+
+// fromContext retorna os campos de log adicionados ao contexto com With
+func fromContext(ctx context.Context) []slog.Attr {
+	if ctx == nil {
+		return nil
+	}
+	attrs, _ := ctx.Value(contextKey{}).([]slog.Attr)
+	return attrs
+}
+
+// Handler acrescenta aos registros os campos adicionados ao contexto com With e remove
+// dos registros os valores sensíveis (ver RedactString)
+type Handler struct {
+	slog.Handler
+}
+
+// NewHandler cria um Handler sobre o handler informado
+func NewHandler(h slog.Handler) *Handler {
+	return &Handler{Handler: h}
+}
+
+// Handle grava o registro, sem valores sensíveis, com os campos do contexto. Campos já presentes no registro têm prioridade.
+func (h *Handler) Handle(ctx context.Context, r slog.Record) error {
+	redacted := slog.NewRecord(r.Time, r.Level, RedactString(r.Message), r.PC)
+	present := make(map[string]bool, r.NumAttrs())
+	r.Attrs(func(a slog.Attr) bool {
+		present[a.Key] = true
+		redacted.AddAttrs(redactAttr(a))
+		return true
+	})
+
+	// O valor mais recente de um campo repetido no contexto prevalece
+	attrs := fromContext(ctx)
+	last := make(map[string]int, len(attrs))
+	for i, a := range attrs {
+		last[a.Key] = i
+	}
+
+	for i, a := range attrs {
+		if !present[a.Key] && last[a.Key] == i {
+			redacted.AddAttrs(redactAttr(a))
+		}
+	}
+
+	// Correlacionar o registro com o trace da operação, quando o tracing está ativo
+	if span := trace.SpanContextFromContext(ctx); span.IsValid() {
+		redacted.AddAttrs(slog.String("trace_id", span.TraceID().String()), slog.String("span_id", span.SpanID().String()))
+	}
+	return h.Handler.Handle(ctx, redacted)
+}
+
+// WithAttrs retorna um Handler com os campos informados, sem valores sensíveis
+func (h *Handler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	redacted := make([]slog.Attr, len(attrs))
+	for i, a := range attrs {
+		redacted[i] = redactAttr(a)
+	}
+	return &Handler{Handler: h.Handler.WithAttrs(redacted)}
+}
+
+// WithGroup retorna um Handler que agrupa os campos seguintes
+func (h *Handler) WithGroup(name string) slog.Handler {
+	return &Handler{Handler: h.Handler.WithGroup(name)}
+}
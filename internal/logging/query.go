@@ -0,0 +1,231 @@
+package logging
+
+import (
+	"bufio"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Entry é um registro do arquivo de log
+type Entry struct {
+	Time      time.Time              `json:"time" swagger:"description=Horário do registro"`
+	Level     string                 `json:"level" example:"ERROR" swagger:"description=Nível (DEBUG, INFO, WARN, ERROR)"`
+	Message   string                 `json:"message" example:"Erro no rollback" swagger:"description=Mensagem do registro"`
+	RequestID string                 `json:"request_id,omitempty" example:"3f2a9c1d7e8b4a60" swagger:"description=ID da requisição"`
+	Account   string                 `json:"account,omitempty" example:"elizio" swagger:"description=Conta"`
+	SiteID    string                 `json:"site_id,omitempty" example:"a1b2c3d4" swagger:"description=ID do site na Netlify"`
+	DeployID  string                 `json:"deploy_id,omitempty" example:"5f1b2c3d4e5f6a7b8c9d0e1f" swagger:"description=ID do deploy na Netlify"`
+	JobID     string                 `json:"job_id,omitempty" example:"9f86d081884c7d65" swagger:"description=ID do job"`
+	Fields    map[string]interface{} `json:"fields,omitempty" swaggertype:"object" swagger:"description=Demais campos do registro"`
+}
+
+// Filter define os critérios de uma consulta aos logs. Campos vazios não filtram.
+type Filter struct {
+	// Level é o nível mínimo dos registros retornados
+	Level     slog.Level
+	From      time.Time
+	To        time.Time
+	RequestID string
+	Account   string
+	SiteID    string
+	DeployID  string
+	// Cursor continua uma consulta anterior (Page.NextCursor)
+	Cursor string
+	// Limit é a quantidade máxima de registros retornados
+	Limit int
+}
+
+// Page é uma página de registros, do mais recente ao mais antigo
+type Page struct {
+	Entries []Entry
+	// NextCursor continua a consulta a partir do último registro da página (vazio na última página)
+	NextCursor string
+}
+
+// ErrInvalidCursor indica um cursor de paginação inválido
+var ErrInvalidCursor = errors.New("cursor de paginação inválido")
+
+// entryFields são os campos do JSON gravado pelo slog copiados para os campos próprios de Entry
+var entryFields = []string{"time", "level", "msg", "request_id", "account", "site_id", "deploy_id", "job_id"}
+
+// cursor é a posição de uma consulta: o horário do último registro retornado e quantos registros
+// com esse mesmo horário já foram percorridos (o arquivo é lido do fim para o início)
+type cursor struct {
+	time time.Time
+	skip int
+}
+
+// Query consulta o arquivo de log e os arquivos rotacionados, do registro mais recente ao mais antigo.
+// Linhas que não são JSON (ex: logs anteriores ao formato estruturado) são ignoradas e os valores
+// sensíveis são removidos dos registros retornados.
+func Query(path string, filter Filter) (*Page, error) {
+	after, err := decodeCursor(filter.Cursor)
+	if err != nil {
+		return nil, err
+	}
+
+	files, err := logFiles(path)
+	if err != nil {
+		return nil, fmt.Errorf("erro ao listar arquivos de log: %w", err)
+	}
+
+	page := &Page{Entries: []Entry{}}
+	var last cursor
+	for _, name := range files {
+		lines, err := readLines(name)
+		if errors.Is(err, os.ErrNotExist) {
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("erro ao ler arquivo de log: %w", err)
+		}
+
+		for i := len(lines) - 1; i >= 0; i-- {
+			entry, level, ok := parseEntry(lines[i])
+			if !ok {
+				continue
+			}
+
+			// Pular os registros já percorridos nas páginas anteriores
+			if after != nil && entry.Time.After(after.time) {
+				continue
+			}
+			if entry.Time.Equal(last.time) {
+				last.skip++
+			} else {
+				last = cursor{time: entry.Time, skip: 1}
+			}
+			if after != nil && entry.Time.Equal(after.time) && last.skip <= after.skip {
+				continue
+			}
+
+			if !filter.From.IsZero() && entry.Time.Before(filter.From) {
+				return page, nil
+			}
+			if !filter.matches(entry, level) {
+				continue
+			}
+			page.Entries = append(page.Entries, entry)
+			if filter.Limit > 0 && len(page.Entries) >= filter.Limit {
+				page.NextCursor = encodeCursor(last)
+				return page, nil
+			}
+		}
+	}
+	return page, nil
+}
+
+// matches indica se o registro atende ao filtro
+func (f Filter) matches(e Entry, level slog.Level) bool {
+	switch {
+	case level < f.Level:
+		return false
+	case !f.To.IsZero() && e.Time.After(f.To):
+		return false
+	case f.RequestID != "" && e.RequestID != f.RequestID:
+		return false
+	case f.Account != "" && e.Account != f.Account:
+		return false
+	case f.SiteID != "" && e.SiteID != f.SiteID:
+		return false
+	case f.DeployID != "" && e.DeployID != f.DeployID:
+		return false
+	}
+	return true
+}
+
+// parseEntry converte uma linha JSON gravada pelo slog em Entry
+func parseEntry(line []byte) (Entry, slog.Level, bool) {
+	var fields map[string]interface{}
+	if err := json.Unmarshal(line, &fields); err != nil {
+		return Entry{}, 0, false
+	}
+
+	str := func(key string) string {
+		value, _ := fields[key].(string)
+		return value
+	}
+
+	t, err := time.Parse(time.RFC3339Nano, str("time"))
+	if err != nil {
+		return Entry{}, 0, false
+	}
+	var level slog.Level
+	if err := level.UnmarshalText([]byte(str("level"))); err != nil {
+		return Entry{}, 0, false
+	}
+
+	entry := Entry{
+		Time:      t,
+		Level:     level.String(),
+		Message:   RedactString(str("msg")),
+		RequestID: str("request_id"),
+		Account:   str("account"),
+		SiteID:    str("site_id"),
+		DeployID:  str("deploy_id"),
+		JobID:     str("job_id"),
+	}
+	for _, key := range entryFields {
+		delete(fields, key)
+	}
+	if len(fields) > 0 {
+		// Registros gravados antes da remoção dos valores sensíveis (ou de um segredo registrado depois) também são filtrados
+		entry.Fields = RedactValue(fields).(map[string]interface{})
+	}
+	return entry, level, true
+}
+
+// readLines lê todas as linhas de um arquivo de log
+func readLines(name string) ([][]byte, error) {
+	file, err := os.Open(name)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	var lines [][]byte
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		lines = append(lines, append([]byte(nil), scanner.Bytes()...))
+	}
+	return lines, scanner.Err()
+}
+
+// encodeCursor codifica a posição da consulta em um cursor opaco
+func encodeCursor(c cursor) string {
+	value := strconv.FormatInt(c.time.UnixNano(), 10) + "." + strconv.Itoa(c.skip)
+	return base64.RawURLEncoding.EncodeToString([]byte(value))
+}
+
+// decodeCursor decodifica um cursor gerado por encodeCursor (nil quando vazio)
+func decodeCursor(value string) (*cursor, error) {
+	if value == "" {
+		return nil, nil
+	}
+
+	raw, err := base64.RawURLEncoding.DecodeString(value)
+	if err != nil {
+		return nil, ErrInvalidCursor
+	}
+	nanos, skip, ok := strings.Cut(string(raw), ".")
+	if !ok {
+		return nil, ErrInvalidCursor
+	}
+	n, err := strconv.ParseInt(nanos, 10, 64)
+	if err != nil {
+		return nil, ErrInvalidCursor
+	}
+	s, err := strconv.Atoi(skip)
+	if err != nil || s < 0 {
+		return nil, ErrInvalidCursor
+	}
+	return &cursor{time: time.Unix(0, n), skip: s}, nil
+}
@@ -0,0 +1,196 @@
+package logging
+
+import (
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+	"unicode/utf8"
+)
+
+// Redacted substitui os valores sensíveis nos logs
+const Redacted = "[REDACTED]"
+
+// minSecretLen é o tamanho mínimo de um segredo registrado com AddSecrets; valores menores
+// (ex: credenciais de teste) substituiriam trechos comuns das mensagens
+const minSecretLen = 8
+
+// maxValueLen é o tamanho máximo de um texto nos logs; textos maiores (ex: conteúdo de arquivos) são truncados
+const maxValueLen = 2048
+
+// sensitiveKeys são trechos de nomes de campos cujos valores nunca são registrados
+var sensitiveKeys = []string{"token", "secret", "password", "authorization", "api_key", "apikey", "api-key", "access_key", "credential", "cookie"}
+
+var (
+	// keyValuePattern encontra pares chave/valor com chave sensível em textos livres (ex: %+v de structs, JSON, query strings)
+	keyValuePattern = regexp.MustCompile(`(?i)([\w-]*(?:token|secret|password|authorization|api[_-]?key|access[_-]?key|credential|cookie)[\w-]*"?(?:[:=]\s*"|[:=]))\[?(?:(?:bearer|basic)\s+)?[^\s"'&,;}\]]+`)
+	// bearerPattern encontra credenciais enviadas no cabeçalho Authorization
+	bearerPattern = regexp.MustCompile(`(?i)\b(bearer|basic)\s+[\w.~+/=-]{8,}`)
+	// awsKeyPattern encontra IDs de chaves de acesso da AWS
+	awsKeyPattern = regexp.MustCompile(`\b(?:AKIA|ASIA)[0-9A-Z]{16}\b`)
+	// netlifyTokenPattern encontra tokens de acesso pessoal da Netlify
+	netlifyTokenPattern = regexp.MustCompile(`\bnf[pc]_[A-Za-z0-9]{16,}`)
+	// payloadPattern encontra blocos longos em base64 (ex: conteúdo de arquivos enviados)
+	payloadPattern = regexp.MustCompile(`[A-Za-z0-9+/]{256,}={0,2}`)
+)
+
+// secrets são os valores registrados com AddSecrets
+var secrets = struct {
+	sync.RWMutex
+	values   map[string]bool
+	replacer *strings.Replacer
+}{values: map[string]bool{}}
+
+// AddSecrets registra valores (tokens, chaves, senhas) que são substituídos por [REDACTED] em todos os logs
+func AddSecrets(values ...string) {
+	secrets.RLock()
+	missing := false
+	for _, value := range values {
+		if len(value) >= minSecretLen && !secrets.values[value] {
+			missing = true
+			break
+		}
+	}
+	secrets.RUnlock()
+	if !missing {
+		return
+	}
+
+	secrets.Lock()
+	defer secrets.Unlock()
+	for _, value := range values {
+		if len(value) >= minSecretLen {
+			secrets.values[value] = true
+		}
+	}
+
+	// Segredos mais longos primeiro, para que um segredo contido em outro não deixe parte dele visível
+	sorted := make([]string, 0, len(secrets.values))
+	for value := range secrets.values {
+		sorted = append(sorted, value)
+	}
+	sort.Slice(sorted, func(i, j int) bool { return len(sorted[i]) > len(sorted[j]) })
+
+	pairs := make([]string, 0, 2*len(sorted))
+	for _, value := range sorted {
+		pairs = append(pairs, value, Redacted)
+	}
+	secrets.replacer = strings.NewReplacer(pairs...)
+}
+
+// RedactString remove de um texto os segredos registrados, credenciais reconhecidas e blocos longos de dados
+func RedactString(s string) string {
+	secrets.RLock()
+	replacer := secrets.replacer
+	secrets.RUnlock()
+	if replacer != nil {
+		s = replacer.Replace(s)
+	}
+
+	s = payloadPattern.ReplaceAllStringFunc(s, func(payload string) string {
+		return fmt.Sprintf("[%d bytes omitidos]", len(payload))
+	})
+	s = keyValuePattern.ReplaceAllStringFunc(s, func(pair string) string {
+		key := keyValuePattern.FindStringSubmatch(pair)[1]
+		// Valor já ocultado (ex: logs lidos de volta do arquivo)
+		if pair[len(key):] == strings.TrimSuffix(Redacted, "]") {
+			return pair
+		}
+		return key + Redacted
+	})
+	s = bearerPattern.ReplaceAllString(s, "${1} "+Redacted)
+	s = awsKeyPattern.ReplaceAllString(s, Redacted)
+	s = netlifyTokenPattern.ReplaceAllString(s, Redacted)
+
+	if len(s) > maxValueLen {
+		// O texto truncado, com o aviso, não passa de maxValueLen: ao ser lido de volta não é truncado de novo
+		cut := maxValueLen - 64
+		for cut > 0 && !utf8.RuneStart(s[cut]) {
+			cut--
+		}
+		s = fmt.Sprintf("%s... [%d bytes omitidos]", s[:cut], len(s)-cut)
+	}
+	return s
+}
+
+// sensitiveKey indica se o campo com esse nome guarda um valor sensível
+func sensitiveKey(key string) bool {
+	key = strings.ToLower(key)
+	for _, part := range sensitiveKeys {
+		if strings.Contains(key, part) {
+			return true
+		}
+	}
+	return false
+}
+
+// redactAttr remove os valores sensíveis de um campo de log
+func redactAttr(a slog.Attr) slog.Attr {
+	value := a.Value.Resolve()
+
+	switch value.Kind() {
+	case slog.KindBool, slog.KindInt64, slog.KindUint64, slog.KindFloat64, slog.KindDuration, slog.KindTime:
+		return slog.Attr{Key: a.Key, Value: value}
+	case slog.KindGroup:
+		attrs := value.Group()
+		redactedAttrs := make([]slog.Attr, len(attrs))
+		for i, attr := range attrs {
+			redactedAttrs[i] = redactAttr(attr)
+		}
+		return slog.Attr{Key: a.Key, Value: slog.GroupValue(redactedAttrs...)}
+	}
+
+	if sensitiveKey(a.Key) {
+		return slog.String(a.Key, Redacted)
+	}
+
+	if value.Kind() == slog.KindAny {
+		switch v := value.Any().(type) {
+		case error:
+			return slog.String(a.Key, RedactString(v.Error()))
+		case fmt.Stringer:
+			return slog.String(a.Key, RedactString(v.String()))
+		default:
+			// Structs, mapas e listas são gravados em JSON já sem os valores sensíveis
+			data, err := json.Marshal(v)
+			if err != nil {
+				return slog.String(a.Key, RedactString(fmt.Sprintf("%+v", v)))
+			}
+			var decoded interface{}
+			if err := json.Unmarshal(data, &decoded); err != nil {
+				return slog.String(a.Key, RedactString(string(data)))
+			}
+			return slog.Any(a.Key, RedactValue(decoded))
+		}
+	}
+
+	return slog.String(a.Key, RedactString(value.String()))
+}
+
+// RedactValue remove os valores sensíveis de um valor decodificado de JSON (mapas, listas e textos)
+func RedactValue(v interface{}) interface{} {
+	switch v := v.(type) {
+	case string:
+		return RedactString(v)
+	case map[string]interface{}:
+		redacted := make(map[string]interface{}, len(v))
+		for key, value := range v {
+			if _, isString := value.(string); isString && sensitiveKey(key) {
+				redacted[key] = Redacted
+				continue
+			}
+			redacted[key] = RedactValue(value)
+		}
+		return redacted
+	case []interface{}:
+		redacted := make([]interface{}, len(v))
+		for i, value := range v {
+			redacted[i] = RedactValue(value)
+		}
+		return redacted
+	}
+	return v
+}
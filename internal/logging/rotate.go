@@ -0,0 +1,124 @@
+package logging
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// RotatingFile é um arquivo de log que, ao atingir o tamanho máximo, é renomeado para path.1
+// (e os anteriores para path.2, path.3, ...), mantendo no máximo maxBackups arquivos antigos
+type RotatingFile struct {
+	mu         sync.Mutex
+	path       string
+	maxSize    int64
+	maxBackups int
+	file       *os.File
+	size       int64
+}
+
+// OpenRotatingFile abre (ou cria) o arquivo de log, acrescentando ao conteúdo existente
+func OpenRotatingFile(path string, maxSize int64, maxBackups int) (*RotatingFile, error) {
+	f := &RotatingFile{path: path, maxSize: maxSize, maxBackups: maxBackups}
+	if err := f.open(); err != nil {
+		return nil, err
+	}
+	return f, nil
+}
+
+// Write grava p no arquivo, rotacionando-o antes se o tamanho máximo for ultrapassado
+func (f *RotatingFile) Write(p []byte) (int, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if f.maxSize > 0 && f.size > 0 && f.size+int64(len(p)) > f.maxSize {
+		if err := f.rotate(); err != nil {
+			return 0, err
+		}
+	}
+
+	n, err := f.file.Write(p)
+	f.size += int64(n)
+	return n, err
+}
+
+// Close fecha o arquivo de log
+func (f *RotatingFile) Close() error {
+	if f == nil {
+		return nil
+	}
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.file.Close()
+}
+
+// open abre o arquivo de log atual e registra o seu tamanho
+func (f *RotatingFile) open() error {
+	file, err := os.OpenFile(f.path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
+	if err != nil {
+		return fmt.Errorf("erro ao abrir arquivo de log: %w", err)
+	}
+	info, err := file.Stat()
+	if err != nil {
+		file.Close()
+		return fmt.Errorf("erro ao abrir arquivo de log: %w", err)
+	}
+	f.file = file
+	f.size = info.Size()
+	return nil
+}
+
+// rotate renomeia os arquivos de log (path -> path.1 -> path.2 ...), descarta o mais antigo e abre um novo arquivo
+func (f *RotatingFile) rotate() error {
+	if err := f.file.Close(); err != nil {
+		return fmt.Errorf("erro ao rotacionar arquivo de log: %w", err)
+	}
+
+	if f.maxBackups > 0 {
+		for i := f.maxBackups - 1; i >= 1; i-- {
+			os.Rename(backupName(f.path, i), backupName(f.path, i+1))
+		}
+		if err := os.Rename(f.path, backupName(f.path, 1)); err != nil {
+			return fmt.Errorf("erro ao rotacionar arquivo de log: %w", err)
+		}
+	} else if err := os.Remove(f.path); err != nil {
+		return fmt.Errorf("erro ao rotacionar arquivo de log: %w", err)
+	}
+
+	return f.open()
+}
+
+// backupName retorna o nome do n-ésimo arquivo rotacionado
+func backupName(path string, n int) string {
+	return path + "." + strconv.Itoa(n)
+}
+
+// logFiles lista o arquivo de log e os arquivos rotacionados existentes, do mais recente ao mais antigo
+func logFiles(path string) ([]string, error) {
+	matches, err := filepath.Glob(path + ".*")
+	if err != nil {
+		return nil, err
+	}
+
+	type backup struct {
+		name string
+		n    int
+	}
+	var backups []backup
+	for _, name := range matches {
+		if n, err := strconv.Atoi(strings.TrimPrefix(name, path+".")); err == nil && n > 0 {
+			backups = append(backups, backup{name: name, n: n})
+		}
+	}
+	sort.Slice(backups, func(i, j int) bool { return backups[i].n < backups[j].n })
+
+	files := []string{path}
+	for _, b := range backups {
+		files = append(files, b.name)
+	}
+	return files, nil
+}
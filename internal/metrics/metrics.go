@@ -0,0 +1,141 @@
+package metrics
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+// namespace é o prefixo dos nomes de todas as métricas da aplicação
+const namespace = "netlify_deploy"
+
+// Resultados usados no rótulo outcome
+const (
+	OutcomeSuccess = "success"
+	OutcomeError   = "error"
+)
+
+// Registry reúne as métricas da aplicação e as métricas do runtime Go e do processo
+var Registry = prometheus.NewRegistry()
+
+var factory = promauto.With(Registry)
+
+var (
+	// HTTPRequests conta as requisições à API por método, rota (modelo, ex: /api/accounts/:account/sites) e status
+	HTTPRequests = factory.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "http_requests_total",
+		Help:      "Requisições recebidas pela API, por método, rota e status.",
+	}, []string{"method", "route", "status"})
+
+	// HTTPRequestDuration mede a latência das requisições à API por método e rota
+	HTTPRequestDuration = factory.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "http_request_duration_seconds",
+		Help:      "Latência das requisições à API, por método e rota.",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"method", "route"})
+
+	// Deploys conta os deploys por origem dos arquivos (upload, folder, s3, content) e resultado final
+	Deploys = factory.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "deploys_total",
+		Help:      "Deploys por origem e resultado final na Netlify (success: ready; error: falha no envio, error, rejected ou espera esgotada).",
+	}, []string{"source", "outcome"})
+
+	// DeployDuration mede a duração dos deploys, do início do envio ao estado final na Netlify
+	DeployDuration = factory.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "deploy_duration_seconds",
+		Help:      "Duração dos deploys, do início do envio dos arquivos ao estado final na Netlify, por origem e resultado.",
+		Buckets:   []float64{1, 2.5, 5, 10, 30, 60, 120, 300, 600, 1800},
+	}, []string{"source", "outcome"})
+
+	// UploadedFiles conta os arquivos enviados para a Netlify
+	UploadedFiles = factory.NewCounter(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "uploaded_files_total",
+		Help:      "Arquivos enviados para a Netlify.",
+	})
+
+	// UploadedBytes conta os bytes enviados para a Netlify
+	UploadedBytes = factory.NewCounter(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "uploaded_bytes_total",
+		Help:      "Bytes de arquivos enviados para a Netlify.",
+	})
+
+	// NetlifyRequests conta as chamadas à API da Netlify (cada tentativa) por operação e status
+	NetlifyRequests = factory.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "netlify_requests_total",
+		Help:      "Chamadas à API da Netlify, incluindo novas tentativas, por operação e status (error em falhas de rede).",
+	}, []string{"operation", "status"})
+
+	// NetlifyRequestDuration mede a latência das chamadas à API da Netlify por operação
+	NetlifyRequestDuration = factory.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "netlify_request_duration_seconds",
+		Help:      "Latência das chamadas à API da Netlify, por operação.",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"operation"})
+
+	// S3Objects conta os objetos baixados do S3
+	S3Objects = factory.NewCounter(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "s3_objects_downloaded_total",
+		Help:      "Objetos baixados do S3 (deploys e cálculo de digests).",
+	})
+
+	// S3Bytes conta os bytes baixados do S3
+	S3Bytes = factory.NewCounter(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "s3_bytes_downloaded_total",
+		Help:      "Bytes baixados do S3.",
+	})
+
+	// JobsInFlight indica quantos jobs de deploy estão em execução
+	JobsInFlight = factory.NewGauge(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Name:      "jobs_in_flight",
+		Help:      "Jobs de deploy em execução.",
+	})
+
+	// JobsQueued indica quantos jobs de deploy aguardam um worker
+	JobsQueued = factory.NewGauge(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Name:      "jobs_queued",
+		Help:      "Jobs de deploy aguardando na fila.",
+	})
+)
+
+func init() {
+	Registry.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+	)
+}
+
+// Handler retorna o handler HTTP que expõe as métricas no formato do Prometheus
+func Handler() http.Handler {
+	return promhttp.HandlerFor(Registry, promhttp.HandlerOpts{Registry: Registry})
+}
+
+// Outcome retorna o resultado de uma operação para o rótulo outcome
+func Outcome(err error) string {
+	if err != nil {
+		return OutcomeError
+	}
+	return OutcomeSuccess
+}
+
+// ObserveDeploy registra um deploy da origem informada, iniciado em startedAt, com o resultado err
+func ObserveDeploy(source string, startedAt time.Time, err error) {
+	outcome := Outcome(err)
+	Deploys.WithLabelValues(source, outcome).Inc()
+	DeployDuration.WithLabelValues(source, outcome).Observe(time.Since(startedAt).Seconds())
+}
@@ -1,44 +1,91 @@
 package netlify
 
 import (
-	"bytes"
 	"context"
-	"encoding/json"
 	"fmt"
-	"io"
-	"log"
+	"log/slog"
 	"net/http"
 	"os"
 	"path/filepath"
+	"strings"
+	"sync"
 	"time"
 
 	"github.com/go-openapi/runtime"
 	"github.com/go-openapi/runtime/client"
 	"github.com/go-openapi/strfmt"
 	"github.com/kodestech/poc-netlify/internal/config"
+	"github.com/kodestech/poc-netlify/internal/dns"
+	"github.com/kodestech/poc-netlify/internal/logging"
+	"github.com/kodestech/poc-netlify/internal/store"
+	"github.com/kodestech/poc-netlify/internal/tracing"
 	"github.com/netlify/open-api/go/models"
+	"github.com/netlify/open-api/go/plumbing/operations"
 	"github.com/netlify/open-api/go/porcelain"
 )
 
 // Client encapsula a integração com a API da Netlify
 type Client struct {
-	netlify *porcelain.Netlify
-	config  *config.Config
-	auth    runtime.ClientAuthInfoWriter
+	netlify     *porcelain.Netlify
+	transport   *client.Runtime
+	config      *config.Config
+	auth        runtime.ClientAuthInfoWriter
+	recorder    DeployRecorder
+	domains     DomainRecorder
+	dnsProvider dns.Provider
+	retries     *retryTransport
+
+	// Deploys enviados aguardando o estado final para as métricas (ver observeDeploy)
+	pendingMu sync.Mutex
+	pending   map[string]pendingDeploy
 }
 
-// NewClient cria um novo cliente Netlify
-func NewClient(cfg *config.Config) (*Client, error) {
+// NewClient cria um novo cliente Netlify para a API em cfg.NetlifyAPIURL
+func NewClient(cfg *config.Config, opts ...ClientOption) (*Client, error) {
 	// Verificar se o token está configurado
 	if cfg.NetlifyToken == "" {
-		log.Printf("ERRO: Token da Netlify não configurado")
+		slog.Error("Token da Netlify não configurado")
 		return nil, fmt.Errorf("token da Netlify não configurado")
 	}
 
-	log.Printf("Criando cliente Netlify com token: %s...", cfg.NetlifyToken[:10])
+	// O token da conta nunca aparece nos logs, mesmo em mensagens de erro da API
+	logging.AddSecrets(cfg.NetlifyToken)
+	slog.Debug("Criando cliente Netlify", "api_url", cfg.NetlifyAPIURL)
+
+	var options clientOptions
+	for _, opt := range opts {
+		opt(&options)
+	}
+
+	host, basePath, scheme, err := parseAPIURL(cfg.NetlifyAPIURL)
+	if err != nil {
+		return nil, err
+	}
+
+	// Todas as chamadas passam pela política de novas tentativas (429, 5xx e falhas de rede)
+	policy := DefaultRetryPolicy
+	if options.retryPolicy != nil {
+		policy = *options.retryPolicy
+	} else if cfg.NetlifyMaxRetries > 0 {
+		policy.MaxRetries = cfg.NetlifyMaxRetries
+	}
+
+	httpClient := &http.Client{}
+	if options.httpClient != nil {
+		copied := *options.httpClient
+		httpClient = &copied
+	}
+	base := httpClient.Transport
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	// Cada tentativa gera um span e é contabilizada nas métricas, antes da política de novas tentativas
+	traced := &tracingTransport{base: &metricsTransport{base: base, basePath: basePath}, basePath: basePath}
+	retries := &retryTransport{base: traced, policy: policy}
+	httpClient.Transport = retries
 
 	// Configurar cliente HTTP com autenticação
-	transport := client.New("api.netlify.com", "/api/v1", []string{"https"})
+	transport := client.NewWithClient(host, basePath, []string{scheme}, httpClient)
 
 	// Criar a autenticação
 	auth := runtime.ClientAuthInfoWriterFunc(func(req runtime.ClientRequest, reg strfmt.Registry) error {
@@ -52,41 +99,50 @@ func NewClient(cfg *config.Config) (*Client, error) {
 	netlifyClient := porcelain.New(transport, strfmt.Default)
 
 	return &Client{
-		netlify: netlifyClient,
-		config:  cfg,
-		auth:    auth,
+		netlify:   netlifyClient,
+		transport: transport,
+		config:    cfg,
+		auth:      auth,
+		retries:   retries,
 	}, nil
 }
 
 // VerifySiteById verifica se um site existe na Netlify pelo ID
-func (c *Client) VerifySiteById(ctx context.Context, siteID string) (*models.Site, bool, error) {
-	log.Printf("Verificando se o site com ID %s existe", siteID)
+func (c *Client) VerifySiteById(ctx context.Context, siteID string) (site *models.Site, found bool, err error) {
+	ctx, span := tracing.Start(ctx, "netlify.VerifySiteById", tracing.SiteID.String(siteID))
+	defer func() { tracing.End(span, err) }()
+
+	ctx = logging.With(ctx, "site_id", siteID)
+	slog.DebugContext(ctx, "Verificando se o site existe")
 
 	// Criar um contexto com autenticação
 	authCtx := c.createAuthContext(ctx)
 
 	// Obter o site pelo ID
-	site, err := c.netlify.GetSite(authCtx, siteID)
+	site, err = c.netlify.GetSite(authCtx, siteID)
 	if err != nil {
-		if err.Error() == "site not found" {
-			log.Printf("Site com ID %s não encontrado", siteID)
+		if isNotFound(err) || err.Error() == "site not found" {
+			slog.InfoContext(ctx, "Site não encontrado")
 			return nil, false, nil
 		}
 		return nil, false, fmt.Errorf("erro ao verificar site: %w", err)
 	}
 
 	if site == nil {
-		log.Printf("Site com ID %s não encontrado", siteID)
+		slog.InfoContext(ctx, "Site não encontrado")
 		return nil, false, nil
 	}
 
-	log.Printf("Site encontrado: %s (ID: %s)", site.Name, site.ID)
+	slog.DebugContext(ctx, "Site encontrado", "site_name", site.Name)
 	return site, true, nil
 }
 
 // VerifySite verifica se o site já existe na Netlify
-func (c *Client) VerifySite(ctx context.Context) (*models.Site, bool, error) {
-	log.Printf("Verificando se o site %s já existe", c.config.NetlifySubdomain)
+func (c *Client) VerifySite(ctx context.Context) (site *models.Site, found bool, err error) {
+	ctx, span := tracing.Start(ctx, "netlify.VerifySite", tracing.SiteName.String(c.config.NetlifySubdomain))
+	defer func() { tracing.End(span, err) }()
+
+	slog.DebugContext(ctx, "Verificando se o site já existe", "subdomain", c.config.NetlifySubdomain)
 
 	// Listar todos os sites do usuário
 	sites, err := c.ListSites(ctx)
@@ -98,32 +154,35 @@ func (c *Client) VerifySite(ctx context.Context) (*models.Site, bool, error) {
 	for _, site := range sites {
 		// Verificar pelo domínio personalizado
 		if site.CustomDomain == c.config.NetlifySubdomain {
-			log.Printf("Site encontrado: %s (ID: %s)", site.Name, site.ID)
+			slog.InfoContext(ctx, "Site encontrado", "site_id", site.ID, "site_name", site.Name)
 			return site, true, nil
 		}
 
 		// Verificar pelos aliases de domínio
 		for _, domain := range site.DomainAliases {
 			if domain == c.config.NetlifySubdomain {
-				log.Printf("Site encontrado: %s (ID: %s)", site.Name, site.ID)
+				slog.InfoContext(ctx, "Site encontrado", "site_id", site.ID, "site_name", site.Name)
 				return site, true, nil
 			}
 		}
 
 		// Verificar pelo subdomínio padrão da Netlify
 		if site.Name+".netlify.app" == c.config.NetlifySubdomain {
-			log.Printf("Site encontrado: %s (ID: %s)", site.Name, site.ID)
+			slog.InfoContext(ctx, "Site encontrado", "site_id", site.ID, "site_name", site.Name)
 			return site, true, nil
 		}
 	}
 
-	log.Printf("Site %s não encontrado", c.config.NetlifySubdomain)
+	slog.InfoContext(ctx, "Site não encontrado", "subdomain", c.config.NetlifySubdomain)
 	return nil, false, nil
 }
 
 // CreateSite cria um novo site na Netlify
-func (c *Client) CreateSite(ctx context.Context) (*models.Site, error) {
-	log.Printf("Criando novo site: %s", c.config.NetlifySubdomain)
+func (c *Client) CreateSite(ctx context.Context) (site *models.Site, err error) {
+	ctx, span := tracing.Start(ctx, "netlify.CreateSite", tracing.SiteName.String(c.config.Username))
+	defer func() { tracing.End(span, err) }()
+
+	slog.InfoContext(ctx, "Criando novo site", "site_name", c.config.Username, "subdomain", c.config.NetlifySubdomain)
 
 	// Configurar as opções do site
 	siteParams := models.SiteSetup{
@@ -137,18 +196,22 @@ func (c *Client) CreateSite(ctx context.Context) (*models.Site, error) {
 	}
 
 	// Criar o site
-	site, err := c.netlify.CreateSite(ctx, &siteParams, false)
+	site, err = c.netlify.CreateSite(c.createAuthContext(ctx), &siteParams, false)
 	if err != nil {
 		return nil, fmt.Errorf("erro ao criar site: %w", err)
 	}
 
-	log.Printf("Site criado com sucesso: %s (ID: %s)", site.Name, site.ID)
+	slog.InfoContext(ctx, "Site criado com sucesso", "site_id", site.ID, "site_name", site.Name)
 	return site, nil
 }
 
 // ConfigureDNS configura o DNS para o site
-func (c *Client) ConfigureDNS(ctx context.Context, site *models.Site) error {
-	log.Printf("Configurando DNS para o site %s", site.Name)
+func (c *Client) ConfigureDNS(ctx context.Context, site *models.Site) (err error) {
+	ctx, span := tracing.Start(ctx, "netlify.ConfigureDNS", tracing.SiteID.String(site.ID))
+	defer func() { tracing.End(span, err) }()
+
+	ctx = logging.With(ctx, "site_id", site.ID)
+	slog.InfoContext(ctx, "Configurando DNS do site", "site_name", site.Name)
 
 	// Configurar o domínio personalizado padrão (subdomínio)
 	if err := c.configureCustomDomain(ctx, site, c.config.NetlifySubdomain); err != nil {
@@ -157,7 +220,7 @@ func (c *Client) ConfigureDNS(ctx context.Context, site *models.Site) error {
 
 	// Se um domínio personalizado foi fornecido, configurá-lo também
 	if c.config.CustomDomain != "" {
-		log.Printf("Configurando domínio personalizado: %s", c.config.CustomDomain)
+		slog.InfoContext(ctx, "Configurando domínio personalizado", "domain", c.config.CustomDomain)
 		if err := c.configureCustomDomain(ctx, site, c.config.CustomDomain); err != nil {
 			return fmt.Errorf("erro ao configurar domínio personalizado: %w", err)
 		}
@@ -170,7 +233,7 @@ func (c *Client) ConfigureDNS(ctx context.Context, site *models.Site) error {
 func (c *Client) configureCustomDomain(ctx context.Context, site *models.Site, domain string) error {
 	// Verificar se o domínio já está configurado
 	if site.CustomDomain == domain {
-		log.Printf("Domínio %s já está configurado como domínio principal", domain)
+		slog.DebugContext(ctx, "Domínio já configurado como principal", "domain", domain)
 		return nil
 	}
 
@@ -186,81 +249,108 @@ func (c *Client) configureCustomDomain(ctx context.Context, site *models.Site, d
 	if !domainFound {
 		// Adicionar o domínio como alias
 		site.DomainAliases = append(site.DomainAliases, domain)
-		log.Printf("Adicionando %s como alias de domínio", domain)
+		slog.InfoContext(ctx, "Adicionando alias de domínio", "domain", domain)
 	} else {
-		log.Printf("Domínio %s já está configurado como alias", domain)
+		slog.DebugContext(ctx, "Domínio já configurado como alias", "domain", domain)
 		return nil
 	}
 
+	// Criar o registro DNS apontando o domínio para o site
+	if err := c.provisionDomainRecords(ctx, site, domain, ""); err != nil {
+		return err
+	}
+
 	// Atualizar o site
 	siteSetup := &models.SiteSetup{
 		Site: *site,
 	}
 
-	// Utilizar o contexto que foi passado, que já contém a autenticação
-	updatedSite, err := c.netlify.UpdateSite(ctx, siteSetup)
+	// Garantir a autenticação mesmo quando o contexto recebido não a possui
+	updatedSite, err := c.netlify.UpdateSite(c.createAuthContext(ctx), siteSetup)
 	if err != nil {
 		return fmt.Errorf("erro ao atualizar site com domínio personalizado: %w", err)
 	}
 
-	log.Printf("Domínio %s configurado com sucesso para o site %s", domain, updatedSite.Name)
-
-	// Exibir mensagem informativa sobre DNS
-	log.Printf("Para configurar o DNS para %s, adicione um registro CNAME apontando para o domínio Netlify", domain)
+	slog.InfoContext(ctx, "Domínio configurado com sucesso", "domain", domain, "site_name", updatedSite.Name)
 
 	return nil
 }
 
 // DeploySite realiza o deploy dos arquivos para o site
-func (c *Client) DeploySite(ctx context.Context, site *models.Site, deployDir string) (*models.Deploy, error) {
-	log.Printf("Iniciando deploy para o site %s a partir do diretório %s", site.Name, deployDir)
+func (c *Client) DeploySite(ctx context.Context, site *models.Site, deployDir string) (deploy *models.Deploy, err error) {
+	ctx, span := tracing.Start(ctx, "netlify.DeploySite", tracing.SiteID.String(site.ID), tracing.SiteName.String(site.Name))
+	defer func() { tracing.End(span, err) }()
+
+	ctx = logging.With(ctx, "site_id", site.ID)
+	slog.InfoContext(ctx, "Iniciando deploy", "site_name", site.Name, "dir", deployDir)
+
+	startedAt := time.Now()
+	defer func() { c.observeDeploy(ctx, store.SourceFolder, startedAt, deploy, err) }()
+	observer := newProgressObserver(ctx)
 
 	// Configurar opções de deploy
 	deployOptions := porcelain.DeployOptions{
-		SiteID:  site.ID,
-		Dir:     deployDir,
-		IsDraft: false,
-		Title:   fmt.Sprintf("Deploy automático para %s", c.config.NetlifySubdomain),
+		SiteID:   site.ID,
+		Dir:      deployDir,
+		IsDraft:  isDraft(ctx),
+		Title:    fmt.Sprintf("Deploy automático para %s", c.config.NetlifySubdomain),
+		Observer: observer,
 	}
 
 	// Realizar o deploy
-	deploy, err := c.netlify.DeploySite(ctx, deployOptions)
+	deploy, err = c.netlify.DeploySite(c.createAuthContext(ctx), deployOptions)
 	if err != nil {
 		return nil, fmt.Errorf("erro ao realizar deploy: %w", err)
 	}
 
-	log.Printf("Deploy iniciado com sucesso: ID %s", deploy.ID)
+	tracing.SetAttributes(ctx, tracing.DeployID.String(deploy.ID))
+	slog.InfoContext(ctx, "Deploy iniciado com sucesso", "deploy_id", deploy.ID)
+	c.recordDeploy(ctx, site, deploy, observer, startedAt, store.SourceFolder, deployDir)
 	return deploy, nil
 }
 
 // DeployContent realiza o deploy de conteúdo para o site
-func (c *Client) DeployContent(ctx context.Context, site *models.Site, files map[string]string) (*models.Deploy, error) {
-	log.Printf("Iniciando deploy de conteúdo para o site %s", site.Name)
+func (c *Client) DeployContent(ctx context.Context, site *models.Site, files map[string]string) (deploy *models.Deploy, err error) {
+	ctx, span := tracing.Start(ctx, "netlify.DeployContent", tracing.SiteID.String(site.ID), tracing.SiteName.String(site.Name), tracing.Files.Int(len(files)))
+	defer func() { tracing.End(span, err) }()
+
+	ctx = logging.With(ctx, "site_id", site.ID)
+	slog.InfoContext(ctx, "Iniciando deploy de conteúdo", "site_name", site.Name)
+
+	startedAt := time.Now()
+	defer func() { c.observeDeploy(ctx, store.SourceContent, startedAt, deploy, err) }()
+	observer := newProgressObserver(ctx)
 
 	// Criar um diretório temporário
 	tmpDir, err := c.createTempFilesFromContent(files)
 	if err != nil {
 		return nil, fmt.Errorf("erro ao criar arquivos temporários: %w", err)
 	}
+	defer os.RemoveAll(tmpDir)
 
 	// Configurar opções de deploy
 	deployOptions := porcelain.DeployOptions{
-		SiteID:  site.ID,
-		Dir:     tmpDir,
-		IsDraft: false,
-		Title:   fmt.Sprintf("Deploy de conteúdo para %s", site.Name),
+		SiteID:   site.ID,
+		Dir:      tmpDir,
+		IsDraft:  isDraft(ctx),
+		Title:    fmt.Sprintf("Deploy de conteúdo para %s", site.Name),
+		Observer: observer,
 	}
 
 	// Realizar o deploy
-	deploy, err := c.netlify.DeploySite(ctx, deployOptions)
+	deploy, err = c.netlify.DeploySite(c.createAuthContext(ctx), deployOptions)
 	if err != nil {
 		return nil, fmt.Errorf("erro ao realizar deploy de conteúdo: %w", err)
 	}
 
-	log.Printf("Deploy de conteúdo iniciado com sucesso: ID %s", deploy.ID)
+	tracing.SetAttributes(ctx, tracing.DeployID.String(deploy.ID))
+	slog.InfoContext(ctx, "Deploy de conteúdo iniciado com sucesso", "deploy_id", deploy.ID)
+	c.recordDeploy(ctx, site, deploy, observer, startedAt, store.SourceContent, "")
 	return deploy, nil
 }
 
+// createTempFilesFromContent grava os arquivos em um diretório temporário, criando as subpastas
+// dos caminhos relativos (ex: css/pop-up.css). Caminhos fora do diretório são recusados.
 func (c *Client) createTempFilesFromContent(files map[string]string) (string, error) {
 	tmpDir, err := os.MkdirTemp("", "netlify-deploy")
 	if err != nil {
@@ -268,9 +358,19 @@ func (c *Client) createTempFilesFromContent(files map[string]string) (string, er
 	}
 
 	for filename, content := range files {
-		filePath := filepath.Join(tmpDir, filename)
-		err = os.WriteFile(filePath, []byte(content), 0644)
-		if err != nil {
+		name := filepath.FromSlash(strings.TrimPrefix(filename, "/"))
+		if !filepath.IsLocal(name) {
+			os.RemoveAll(tmpDir)
+			return "", fmt.Errorf("%w: caminho de arquivo inválido '%s'", ErrInvalidInput, filename)
+		}
+
+		filePath := filepath.Join(tmpDir, name)
+		if err := os.MkdirAll(filepath.Dir(filePath), 0755); err != nil {
+			os.RemoveAll(tmpDir)
+			return "", err
+		}
+		if err := os.WriteFile(filePath, []byte(content), 0644); err != nil {
+			os.RemoveAll(tmpDir)
 			return "", err
 		}
 	}
@@ -279,30 +379,45 @@ func (c *Client) createTempFilesFromContent(files map[string]string) (string, er
 }
 
 // DeployLocalFolder realiza o deploy de uma pasta local para o site
-func (c *Client) DeployLocalFolder(ctx context.Context, site *models.Site, folderPath string) (*models.Deploy, error) {
-	log.Printf("Iniciando deploy da pasta local %s para o site %s", folderPath, site.Name)
+func (c *Client) DeployLocalFolder(ctx context.Context, site *models.Site, folderPath string) (deploy *models.Deploy, err error) {
+	ctx, span := tracing.Start(ctx, "netlify.DeployLocalFolder", tracing.SiteID.String(site.ID), tracing.SiteName.String(site.Name))
+	defer func() { tracing.End(span, err) }()
+
+	ctx = logging.With(ctx, "site_id", site.ID)
+	slog.InfoContext(ctx, "Iniciando deploy da pasta local", "site_name", site.Name, "dir", folderPath)
+
+	startedAt := time.Now()
+	defer func() { c.observeDeploy(ctx, store.SourceFolder, startedAt, deploy, err) }()
+	observer := newProgressObserver(ctx)
 
 	// Configurar opções de deploy
 	deployOptions := porcelain.DeployOptions{
-		SiteID:  site.ID,
-		Dir:     folderPath,
-		IsDraft: false,
-		Title:   fmt.Sprintf("Deploy da pasta %s para %s", folderPath, site.Name),
+		SiteID:   site.ID,
+		Dir:      folderPath,
+		IsDraft:  isDraft(ctx),
+		Title:    fmt.Sprintf("Deploy da pasta %s para %s", folderPath, site.Name),
+		Observer: observer,
 	}
 
 	// Realizar o deploy
-	deploy, err := c.netlify.DeploySite(ctx, deployOptions)
+	deploy, err = c.netlify.DeploySite(c.createAuthContext(ctx), deployOptions)
 	if err != nil {
 		return nil, fmt.Errorf("erro ao realizar deploy da pasta local: %w", err)
 	}
 
-	log.Printf("Deploy da pasta local iniciado com sucesso: ID %s", deploy.ID)
+	tracing.SetAttributes(ctx, tracing.DeployID.String(deploy.ID))
+	slog.InfoContext(ctx, "Deploy da pasta local iniciado com sucesso", "deploy_id", deploy.ID)
+
+	c.recordDeploy(ctx, site, deploy, observer, startedAt, store.SourceFolder, folderPath)
 	return deploy, nil
 }
 
 // CreateOrGetSite cria um novo site ou retorna um existente na Netlify
-func (c *Client) CreateOrGetSite(ctx context.Context, siteName, description string) (*models.Site, error) {
-	log.Printf("Verificando se o site %s já existe", siteName)
+func (c *Client) CreateOrGetSite(ctx context.Context, siteName, description string) (site *models.Site, err error) {
+	ctx, span := tracing.Start(ctx, "netlify.CreateOrGetSite", tracing.SiteName.String(siteName))
+	defer func() { tracing.End(span, err) }()
+
+	slog.DebugContext(ctx, "Verificando se o site já existe", "site_name", siteName)
 
 	// Listar todos os sites do usuário
 	sites, err := c.ListSites(ctx)
@@ -313,7 +428,7 @@ func (c *Client) CreateOrGetSite(ctx context.Context, siteName, description stri
 	// Verificar se algum site corresponde ao nome desejado
 	for _, site := range sites {
 		if site.Name == siteName {
-			log.Printf("Site encontrado: %s (ID: %s)", site.Name, site.ID)
+			slog.InfoContext(ctx, "Site encontrado", "site_id", site.ID, "site_name", site.Name)
 			return site, nil
 		}
 	}
@@ -326,142 +441,147 @@ func (c *Client) CreateOrGetSite(ctx context.Context, siteName, description stri
 	}
 
 	// Criar o site
-	log.Printf("Criando novo site: %s", siteName)
-	site, err := c.netlify.CreateSite(ctx, &siteParams, false)
+	slog.InfoContext(ctx, "Criando novo site", "site_name", siteName)
+	site, err = c.netlify.CreateSite(c.createAuthContext(ctx), &siteParams, false)
 	if err != nil {
 		return nil, fmt.Errorf("erro ao criar site: %w", err)
 	}
 
-	log.Printf("Site criado com sucesso: %s (ID: %s)", site.Name, site.ID)
+	slog.InfoContext(ctx, "Site criado com sucesso", "site_id", site.ID, "site_name", site.Name)
 	return site, nil
 }
 
-// WaitForDeploy aguarda a conclusão do deploy
-func (c *Client) WaitForDeploy(ctx context.Context, deployID string) (*models.Deploy, error) {
-	log.Printf("Aguardando conclusão do deploy %s", deployID)
-
-	// Aguardar até que o deploy esteja pronto
-	finished := false
-	var deploy *models.Deploy
-	var err error
-
-	for !finished {
-		log.Printf("Verificando status do deploy %s...", deployID)
-
-		// Obter status do deploy
-		deploy, err = c.netlify.GetDeploy(ctx, deployID)
-		if err != nil {
-			return nil, fmt.Errorf("erro ao verificar status do deploy: %w", err)
-		}
-
-		// Verificar se o deploy foi concluído
-		if deploy.State == "ready" {
-			finished = true
-			log.Printf("Deploy concluído com sucesso: %s", deploy.URL)
-		} else if deploy.State == "error" {
-			return nil, fmt.Errorf("erro no deploy: %s", deploy.ErrorMessage)
-		} else {
-			log.Printf("Deploy ainda em progresso (estado: %s). Aguardando 2 segundos...", deploy.State)
-			time.Sleep(2 * time.Second)
-		}
-	}
-
-	return deploy, nil
-}
-
 // ListSites lista todos os sites do usuário
-func (c *Client) ListSites(ctx context.Context) ([]*models.Site, error) {
-	log.Printf("Listando sites do usuário...")
+func (c *Client) ListSites(ctx context.Context) (sites []*models.Site, err error) {
+	ctx, span := tracing.Start(ctx, "netlify.ListSites")
+	defer func() { tracing.End(span, err) }()
+
+	slog.DebugContext(ctx, "Listando sites do usuário")
 
 	// Criar um contexto com autenticação
 	authCtx := c.createAuthContext(ctx)
 
 	// Listar sites
-	sites, err := c.netlify.ListSites(authCtx, nil)
+	sites, err = c.netlify.ListSites(authCtx, nil)
 	if err != nil {
-		log.Printf("Erro ao listar sites: %v", err)
+		slog.ErrorContext(ctx, "Erro ao listar sites", "error", err)
 		return nil, fmt.Errorf("erro ao listar sites: %w", err)
 	}
 
-	log.Printf("Encontrados %d sites", len(sites))
+	slog.DebugContext(ctx, "Sites listados", "sites", len(sites))
 	return sites, nil
 }
 
+// VerifyToken confirma que o token do cliente é aceito pela Netlify, listando no máximo um site
+func (c *Client) VerifyToken(ctx context.Context) (err error) {
+	ctx, span := tracing.Start(ctx, "netlify.VerifyToken")
+	defer func() { tracing.End(span, err) }()
+
+	perPage := int32(1)
+	params := operations.NewListSitesParams().WithContext(ctx).WithPerPage(&perPage)
+	if _, err := c.netlify.Operations.ListSites(params, c.auth); err != nil {
+		return fmt.Errorf("erro ao verificar token da Netlify: %w", Classify(err))
+	}
+	return nil
+}
+
 // AddCustomDomain adiciona um domínio personalizado a um site.
 // Se o site não possuir domínio principal, o domínio recebido será definido como principal (com validação TXT).
 // Se já houver domínio principal, o domínio recebido será adicionado como alias, desde que não exista.
-func (c *Client) AddCustomDomain(ctx context.Context, siteID, domain string) error {
-	log.Printf("Iniciando adição de domínio %s para o site %s", domain, siteID)
+func (c *Client) AddCustomDomain(ctx context.Context, siteID, domain string) (err error) {
+	ctx, span := tracing.Start(ctx, "netlify.AddCustomDomain", tracing.SiteID.String(siteID), tracing.Domain.String(domain))
+	defer func() { tracing.End(span, err) }()
+
+	ctx = logging.With(ctx, "site_id", siteID, "domain", domain)
+	slog.InfoContext(ctx, "Iniciando adição de domínio")
 
 	if siteID == "" {
-		return fmt.Errorf("ID do site não pode ser vazio")
+		return fmt.Errorf("%w: ID do site não pode ser vazio", ErrInvalidInput)
 	}
 	if domain == "" {
-		return fmt.Errorf("domínio não pode ser vazio")
+		return fmt.Errorf("%w: domínio não pode ser vazio", ErrInvalidInput)
 	}
 
 	authCtx := c.createAuthContext(ctx)
 	site, err := c.netlify.GetSite(authCtx, siteID)
 	if err != nil {
-		log.Printf("Erro ao obter site %s: %v", siteID, err)
+		slog.ErrorContext(ctx, "Erro ao obter site", "error", err)
+		if isNotFound(err) {
+			return fmt.Errorf("%w: %s", ErrSiteNotFound, siteID)
+		}
 		return fmt.Errorf("erro ao obter site: %w", err)
 	}
 	if site == nil {
-		return fmt.Errorf("site não encontrado")
+		return fmt.Errorf("%w: %s", ErrSiteNotFound, siteID)
 	}
-	log.Printf("Site obtido com sucesso: %s", site.Name)
+	slog.DebugContext(ctx, "Site obtido", "site_name", site.Name)
 
 	// Se o site não possui domínio principal, definir o domínio recebido como principal
 	if site.CustomDomain == "" {
-		log.Printf("Site %s não possui domínio principal. Configurando %s como domínio principal.", site.Name, domain)
-		// O valor "12345abc" deve ser o mesmo configurado no registro TXT no DNS
-		if err := c.SetDefaultDomain(ctx, siteID, domain, "12345abc"); err != nil {
+		slog.InfoContext(ctx, "Site sem domínio principal, configurando o domínio como principal", "site_name", site.Name)
+		// O valor enviado para verificação deve ser o mesmo do registro TXT criado no DNS
+		txtValue, err := dns.RandomTXTValue()
+		if err != nil {
+			return err
+		}
+		if err := c.provisionDomainRecords(ctx, site, domain, txtValue); err != nil {
+			return err
+		}
+		if err := c.SetDefaultDomain(ctx, siteID, domain, txtValue); err != nil {
 			return fmt.Errorf("erro ao definir domínio como principal: %w", err)
 		}
+		c.recordDomainVerification(ctx, siteID, domain, txtValue)
 		return nil
 	}
 
 	// Se já existe um domínio personalizado, verificar se o domínio recebido já está cadastrado
 	if site.CustomDomain == domain {
-		return fmt.Errorf("domínio %s já existe como domínio principal", domain)
+		return fmt.Errorf("%w: %s já é o domínio principal", ErrDomainExists, domain)
 	}
 	for _, d := range site.DomainAliases {
 		if d == domain {
-			return fmt.Errorf("domínio %s já existe como alias", domain)
+			return fmt.Errorf("%w: %s já é um alias", ErrDomainExists, domain)
 		}
 	}
 
+	// Criar o registro DNS apontando o domínio para o site
+	if err := c.provisionDomainRecords(ctx, site, domain, ""); err != nil {
+		return err
+	}
+
 	// Adicionar o domínio como alias
 	site.DomainAliases = append(site.DomainAliases, domain)
-	log.Printf("Adicionando %s como alias de domínio para o site %s", domain, site.Name)
+	slog.InfoContext(ctx, "Adicionando alias de domínio", "site_name", site.Name)
 
 	siteSetup := &models.SiteSetup{
 		Site: *site,
 	}
-	log.Printf("Atualizando site com novo alias...")
 	updatedSite, err := c.netlify.UpdateSite(authCtx, siteSetup)
 	if err != nil {
-		log.Printf("Erro ao atualizar site com novo domínio: %v", err)
+		slog.ErrorContext(ctx, "Erro ao atualizar site com novo domínio", "error", err)
 		return fmt.Errorf("erro ao adicionar domínio personalizado: %w", err)
 	}
 
-	log.Printf("Domínio %s adicionado com sucesso como alias para o site %s", domain, updatedSite.Name)
-	log.Printf("Para configurar o DNS para %s, adicione um registro CNAME apontando para o domínio Netlify", domain)
+	slog.InfoContext(ctx, "Alias de domínio adicionado com sucesso", "site_name", updatedSite.Name)
 	return nil
 }
 
 // RemoveCustomDomain remove um domínio personalizado de um site
-func (c *Client) RemoveCustomDomain(ctx context.Context, siteID, domain string) error {
-	log.Printf("Iniciando remoção de domínio %s do site %s", domain, siteID)
+func (c *Client) RemoveCustomDomain(ctx context.Context, siteID, domain string) (err error) {
+	ctx, span := tracing.Start(ctx, "netlify.RemoveCustomDomain", tracing.SiteID.String(siteID), tracing.Domain.String(domain))
+	defer func() { tracing.End(span, err) }()
+
+	ctx = logging.With(ctx, "site_id", siteID, "domain", domain)
+	slog.InfoContext(ctx, "Iniciando remoção de domínio")
 
 	// Verificar se o siteID é válido
 	if siteID == "" {
-		return fmt.Errorf("ID do site não pode ser vazio")
+		return fmt.Errorf("%w: ID do site não pode ser vazio", ErrInvalidInput)
 	}
 
 	// Verificar se o domínio é válido
 	if domain == "" {
-		return fmt.Errorf("domínio não pode ser vazio")
+		return fmt.Errorf("%w: domínio não pode ser vazio", ErrInvalidInput)
 	}
 
 	// Criar um contexto com autenticação
@@ -470,14 +590,17 @@ func (c *Client) RemoveCustomDomain(ctx context.Context, siteID, domain string)
 	// Obter o site atual
 	site, err := c.netlify.GetSite(authCtx, siteID)
 	if err != nil {
+		if isNotFound(err) {
+			return fmt.Errorf("%w: %s", ErrSiteNotFound, siteID)
+		}
 		return fmt.Errorf("erro ao obter site: %w", err)
 	}
 
 	if site == nil {
-		return fmt.Errorf("site não encontrado")
+		return fmt.Errorf("%w: %s", ErrSiteNotFound, siteID)
 	}
 
-	log.Printf("Site obtido com sucesso: %s", site.Name)
+	slog.DebugContext(ctx, "Site obtido", "site_name", site.Name)
 
 	// Verificar se o domínio está nos aliases
 	var domainIndex = -1
@@ -491,9 +614,9 @@ func (c *Client) RemoveCustomDomain(ctx context.Context, siteID, domain string)
 	if domainIndex >= 0 {
 		// Remover o domínio dos aliases
 		site.DomainAliases = append(site.DomainAliases[:domainIndex], site.DomainAliases[domainIndex+1:]...)
-		log.Printf("Removendo %s dos aliases de domínio para o site %s", domain, site.Name)
+		slog.InfoContext(ctx, "Removendo alias de domínio", "site_name", site.Name)
 	} else {
-		log.Printf("Domínio %s não encontrado nos aliases do site %s", domain, site.Name)
+		slog.WarnContext(ctx, "Domínio não encontrado nos aliases do site", "site_name", site.Name)
 		return nil
 	}
 
@@ -507,30 +630,42 @@ func (c *Client) RemoveCustomDomain(ctx context.Context, siteID, domain string)
 		return fmt.Errorf("erro ao remover domínio personalizado: %w", err)
 	}
 
-	log.Printf("Domínio %s removido com sucesso do site %s", domain, updatedSite.Name)
+	slog.InfoContext(ctx, "Domínio removido com sucesso", "site_name", updatedSite.Name)
+
+	// Remover os registros DNS do domínio
+	if err := c.removeDomainRecords(ctx, domain); err != nil {
+		slog.WarnContext(ctx, "Erro ao remover registros DNS do domínio", "error", err)
+	}
 
 	return nil
 }
 
 // SetDefaultDomain define um domínio como o domínio principal de um site.
 // Remove o domínio dos aliases (caso exista) para evitar duplicação e envia o valor do TXT para verificação.
-func (c *Client) SetDefaultDomain(ctx context.Context, siteID, domain, recordTxtValue string) error {
-	log.Printf("Iniciando configuração de domínio %s como padrão para o site %s", domain, siteID)
+func (c *Client) SetDefaultDomain(ctx context.Context, siteID, domain, recordTxtValue string) (err error) {
+	ctx, span := tracing.Start(ctx, "netlify.SetDefaultDomain", tracing.SiteID.String(siteID), tracing.Domain.String(domain))
+	defer func() { tracing.End(span, err) }()
+
+	ctx = logging.With(ctx, "site_id", siteID, "domain", domain)
+	slog.InfoContext(ctx, "Iniciando configuração do domínio principal")
 
 	if siteID == "" {
-		return fmt.Errorf("ID do site não pode ser vazio")
+		return fmt.Errorf("%w: ID do site não pode ser vazio", ErrInvalidInput)
 	}
 	if domain == "" {
-		return fmt.Errorf("domínio não pode ser vazio")
+		return fmt.Errorf("%w: domínio não pode ser vazio", ErrInvalidInput)
 	}
 
 	authCtx := c.createAuthContext(ctx)
 	site, err := c.netlify.GetSite(authCtx, siteID)
 	if err != nil {
+		if isNotFound(err) {
+			return fmt.Errorf("%w: %s", ErrSiteNotFound, siteID)
+		}
 		return fmt.Errorf("erro ao obter site: %w", err)
 	}
 	if site == nil {
-		return fmt.Errorf("site não encontrado")
+		return fmt.Errorf("%w: %s", ErrSiteNotFound, siteID)
 	}
 
 	// Remover o domínio dos aliases, se existir, para evitar duplicação
@@ -544,7 +679,7 @@ func (c *Client) SetDefaultDomain(ctx context.Context, siteID, domain, recordTxt
 
 	// Definir o domínio principal
 	site.CustomDomain = domain
-	log.Printf("Definindo %s como domínio principal para o site %s", domain, site.Name)
+	slog.DebugContext(ctx, "Definindo domínio principal", "site_name", site.Name)
 
 	// Monta o payload com o campo "record_txt_value" para verificação
 	payload := map[string]interface{}{
@@ -554,59 +689,45 @@ func (c *Client) SetDefaultDomain(ctx context.Context, siteID, domain, recordTxt
 		"record_txt_value": recordTxtValue,
 	}
 
-	data, err := json.Marshal(payload)
-	if err != nil {
-		return fmt.Errorf("erro ao serializar payload: %w", err)
+	if _, err := c.patchSite(ctx, siteID, payload); err != nil {
+		return fmt.Errorf("erro ao definir domínio principal: %w", err)
 	}
 
-	url := fmt.Sprintf("https://api.netlify.com/api/v1/sites/%s", siteID)
-	req, err := http.NewRequest("PATCH", url, bytes.NewReader(data))
-	if err != nil {
-		return fmt.Errorf("erro ao criar request: %w", err)
-	}
-	req.Header.Set("Content-Type", "application/json")
-	req.Header.Set("Authorization", "Bearer "+c.config.NetlifyToken)
-
-	clientHTTP := &http.Client{Timeout: 10 * time.Second}
-	resp, err := clientHTTP.Do(req)
-	if err != nil {
-		return fmt.Errorf("erro ao enviar request: %w", err)
-	}
-	defer resp.Body.Close()
-
-	if resp.StatusCode >= 300 {
-		body, _ := io.ReadAll(resp.Body)
-		return fmt.Errorf("erro ao definir domínio principal: status %d, resposta: %s", resp.StatusCode, string(body))
-	}
-
-	log.Printf("Domínio %s definido como principal para o site %s com verificação TXT", domain, site.Name)
+	slog.InfoContext(ctx, "Domínio definido como principal com verificação TXT", "site_name", site.Name)
 	return nil
 }
 
 // SwitchDefaultDomain troca o domínio principal de um site.
 // Converte o domínio atualmente principal em alias e promove um domínio existente entre os aliases a principal.
-func (c *Client) SwitchDefaultDomain(ctx context.Context, siteID, newPrincipalDomain, recordTxtValue string) error {
-	log.Printf("Iniciando a troca do domínio principal para %s no site %s", newPrincipalDomain, siteID)
+func (c *Client) SwitchDefaultDomain(ctx context.Context, siteID, newPrincipalDomain, recordTxtValue string) (err error) {
+	ctx, span := tracing.Start(ctx, "netlify.SwitchDefaultDomain", tracing.SiteID.String(siteID), tracing.Domain.String(newPrincipalDomain))
+	defer func() { tracing.End(span, err) }()
+
+	ctx = logging.With(ctx, "site_id", siteID, "domain", newPrincipalDomain)
+	slog.InfoContext(ctx, "Iniciando a troca do domínio principal")
 
 	if siteID == "" {
-		return fmt.Errorf("ID do site não pode ser vazio")
+		return fmt.Errorf("%w: ID do site não pode ser vazio", ErrInvalidInput)
 	}
 	if newPrincipalDomain == "" {
-		return fmt.Errorf("domínio não pode ser vazio")
+		return fmt.Errorf("%w: domínio não pode ser vazio", ErrInvalidInput)
 	}
 
 	authCtx := c.createAuthContext(ctx)
 	site, err := c.netlify.GetSite(authCtx, siteID)
 	if err != nil {
+		if isNotFound(err) {
+			return fmt.Errorf("%w: %s", ErrSiteNotFound, siteID)
+		}
 		return fmt.Errorf("erro ao obter site: %w", err)
 	}
 	if site == nil {
-		return fmt.Errorf("site não encontrado")
+		return fmt.Errorf("%w: %s", ErrSiteNotFound, siteID)
 	}
 
 	// Se o novo domínio já for o principal, retorna erro
 	if site.CustomDomain == newPrincipalDomain {
-		return fmt.Errorf("o domínio %s já é o principal", newPrincipalDomain)
+		return fmt.Errorf("%w: %s já é o domínio principal", ErrDomainExists, newPrincipalDomain)
 	}
 
 	// Verificar se o novo domínio existe nos aliases e removê-lo
@@ -620,7 +741,7 @@ func (c *Client) SwitchDefaultDomain(ctx context.Context, siteID, newPrincipalDo
 		}
 	}
 	if !found {
-		return fmt.Errorf("o domínio %s não foi encontrado entre os aliases", newPrincipalDomain)
+		return fmt.Errorf("%w: %s não está entre os aliases", ErrDomainNotFound, newPrincipalDomain)
 	}
 
 	// Adicionar o domínio atualmente principal aos aliases (se existir e não duplicar)
@@ -649,42 +770,25 @@ func (c *Client) SwitchDefaultDomain(ctx context.Context, siteID, newPrincipalDo
 		"record_txt_value": recordTxtValue,
 	}
 
-	data, err := json.Marshal(payload)
-	if err != nil {
-		return fmt.Errorf("erro ao serializar payload: %w", err)
+	if _, err := c.patchSite(ctx, siteID, payload); err != nil {
+		return fmt.Errorf("erro ao definir domínio principal: %w", err)
 	}
 
-	url := fmt.Sprintf("https://api.netlify.com/api/v1/sites/%s", siteID)
-	req, err := http.NewRequest("PATCH", url, bytes.NewReader(data))
-	if err != nil {
-		return fmt.Errorf("erro ao criar request: %w", err)
-	}
-	req.Header.Set("Content-Type", "application/json")
-	req.Header.Set("Authorization", "Bearer "+c.config.NetlifyToken)
-
-	clientHTTP := &http.Client{Timeout: 10 * time.Second}
-	resp, err := clientHTTP.Do(req)
-	if err != nil {
-		return fmt.Errorf("erro ao enviar request: %w", err)
-	}
-	defer resp.Body.Close()
-
-	if resp.StatusCode >= 300 {
-		body, _ := io.ReadAll(resp.Body)
-		return fmt.Errorf("erro ao definir domínio principal: status %d, resposta: %s", resp.StatusCode, string(body))
-	}
-
-	log.Printf("Domínio %s agora é o principal para o site %s", newPrincipalDomain, site.Name)
+	slog.InfoContext(ctx, "Domínio principal trocado com sucesso", "site_name", site.Name, "previous_domain", oldPrincipal)
 	return nil
 }
 
 // RemovePrimaryDomain remove o domínio principal de um site
-func (c *Client) RemovePrimaryDomain(ctx context.Context, siteID string) error {
-	log.Printf("Iniciando remoção do domínio principal do site %s", siteID)
+func (c *Client) RemovePrimaryDomain(ctx context.Context, siteID string) (err error) {
+	ctx, span := tracing.Start(ctx, "netlify.RemovePrimaryDomain", tracing.SiteID.String(siteID))
+	defer func() { tracing.End(span, err) }()
+
+	ctx = logging.With(ctx, "site_id", siteID)
+	slog.InfoContext(ctx, "Iniciando remoção do domínio principal")
 
 	// Verificar se o siteID é válido
 	if siteID == "" {
-		return fmt.Errorf("ID do site não pode ser vazio")
+		return fmt.Errorf("%w: ID do site não pode ser vazio", ErrInvalidInput)
 	}
 
 	// Criar um contexto com autenticação
@@ -693,18 +797,21 @@ func (c *Client) RemovePrimaryDomain(ctx context.Context, siteID string) error {
 	// Obter o site atual
 	site, err := c.netlify.GetSite(authCtx, siteID)
 	if err != nil {
+		if isNotFound(err) {
+			return fmt.Errorf("%w: %s", ErrSiteNotFound, siteID)
+		}
 		return fmt.Errorf("erro ao obter site: %w", err)
 	}
 
 	if site == nil {
-		return fmt.Errorf("site não encontrado")
+		return fmt.Errorf("%w: %s", ErrSiteNotFound, siteID)
 	}
 
-	log.Printf("Site obtido com sucesso: %s", site.Name)
+	slog.DebugContext(ctx, "Site obtido", "site_name", site.Name)
 
 	// Verificar se existe um domínio principal
 	if site.CustomDomain == "" {
-		log.Printf("Site %s não tem domínio principal configurado", site.Name)
+		slog.WarnContext(ctx, "Site sem domínio principal configurado", "site_name", site.Name)
 		return nil
 	}
 
@@ -713,7 +820,7 @@ func (c *Client) RemovePrimaryDomain(ctx context.Context, siteID string) error {
 
 	// Limpar o domínio principal
 	site.CustomDomain = ""
-	log.Printf("Removendo %s como domínio principal do site %s", oldDomain, site.Name)
+	slog.InfoContext(ctx, "Removendo domínio principal", "domain", oldDomain, "site_name", site.Name)
 
 	// Atualizar o site
 	siteSetup := &models.SiteSetup{
@@ -725,7 +832,12 @@ func (c *Client) RemovePrimaryDomain(ctx context.Context, siteID string) error {
 		return fmt.Errorf("erro ao remover domínio principal: %w", err)
 	}
 
-	log.Printf("Domínio principal removido com sucesso do site %s", updatedSite.Name)
+	slog.InfoContext(ctx, "Domínio principal removido com sucesso", "domain", oldDomain, "site_name", updatedSite.Name)
+
+	// Remover os registros DNS do antigo domínio principal
+	if err := c.removeDomainRecords(ctx, oldDomain); err != nil {
+		slog.WarnContext(ctx, "Erro ao remover registros DNS do domínio", "domain", oldDomain, "error", err)
+	}
 
 	return nil
 }
@@ -737,5 +849,5 @@ func (c *Client) GetAuth() runtime.ClientAuthInfoWriter {
 
 // createAuthContext cria um contexto com informações de autenticação
 func (c *Client) createAuthContext(ctx context.Context) context.Context {
-	return context.WithValue(ctx, "netlify.auth_info", c.auth)
+	return context.WithValue(ctx, "netlify.auth_info", c.tracedAuth(ctx))
 }
@@ -0,0 +1,439 @@
+package netlify_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/kodestech/poc-netlify/internal/config"
+	"github.com/kodestech/poc-netlify/internal/dns"
+	"github.com/kodestech/poc-netlify/internal/metrics"
+	"github.com/kodestech/poc-netlify/internal/netlify"
+	"github.com/kodestech/poc-netlify/internal/netlify/netlifytest"
+	"github.com/kodestech/poc-netlify/internal/store"
+	"github.com/netlify/open-api/go/models"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+// testRetryPolicy repete as chamadas sem as esperas reais da política padrão
+var testRetryPolicy = netlify.RetryPolicy{MaxRetries: 3, BaseDelay: time.Millisecond, MaxDelay: 10 * time.Millisecond}
+
+// fastWait consulta o estado do deploy sem esperar os 2 segundos padrão
+var fastWait = []netlify.WaitOption{netlify.WithPollInterval(5 * time.Millisecond), netlify.WithMaxWait(5 * time.Second)}
+
+// newTestClient cria um cliente apontado para um servidor falso novo
+func newTestClient(t *testing.T, opts ...netlify.ClientOption) (*netlify.Client, *netlifytest.Server) {
+	t.Helper()
+
+	srv := netlifytest.NewServer()
+	srv.Token = "token-teste"
+	t.Cleanup(srv.Close)
+
+	cfg := &config.Config{
+		NetlifyToken:     "token-teste",
+		NetlifyAPIURL:    srv.APIURL(),
+		Username:         "meu-site",
+		NetlifySubdomain: "meu-site.sites.exemplo.com",
+	}
+	client, err := netlify.NewClient(cfg, append([]netlify.ClientOption{netlify.WithRetryPolicy(testRetryPolicy)}, opts...)...)
+	if err != nil {
+		t.Fatalf("erro ao criar cliente: %v", err)
+	}
+	return client, srv
+}
+
+// deployAndWait publica o conteúdo no site e aguarda o deploy ficar pronto
+func deployAndWait(t *testing.T, ctx context.Context, client *netlify.Client, site *models.Site, files map[string]string) *models.Deploy {
+	t.Helper()
+
+	deploy, err := client.DeployContent(ctx, site, files)
+	if err != nil {
+		t.Fatalf("erro no deploy: %v", err)
+	}
+	deploy, err = client.WaitForDeploy(ctx, deploy.ID, fastWait...)
+	if err != nil {
+		t.Fatalf("erro aguardando o deploy: %v", err)
+	}
+	return deploy
+}
+
+func TestCreateDeployAndWait(t *testing.T) {
+	ctx := context.Background()
+	client, srv := newTestClient(t)
+
+	site, err := client.CreateSite(ctx)
+	if err != nil {
+		t.Fatalf("erro ao criar site: %v", err)
+	}
+	if site.Name != "meu-site" || site.CustomDomain != "meu-site.sites.exemplo.com" {
+		t.Fatalf("site criado com nome %q e domínio %q", site.Name, site.CustomDomain)
+	}
+
+	files := map[string]string{
+		"index.html":       "<h1>Olá</h1>",
+		"css/pop-up.css":   "body { color: red; }",
+		"img/logo/a.svg":   "<svg/>",
+		".well-known/test": "ok",
+	}
+	deploy := deployAndWait(t, ctx, client, site, files)
+	if deploy.State != "ready" {
+		t.Fatalf("deploy no estado %q, esperado ready", deploy.State)
+	}
+
+	published := srv.Files(deploy.ID)
+	for path, content := range files {
+		if string(published[path]) != content {
+			t.Errorf("arquivo %s publicado com %q, esperado %q", path, published[path], content)
+		}
+	}
+
+	current, _ := srv.Site(site.ID)
+	if current.PublishedDeploy == nil || current.PublishedDeploy.ID != deploy.ID {
+		t.Fatalf("deploy %s não foi publicado", deploy.ID)
+	}
+}
+
+func TestWaitForDeployFailures(t *testing.T) {
+	ctx := context.Background()
+
+	for _, tc := range []struct {
+		state string
+		want  error
+	}{
+		{"error", netlify.ErrDeployFailed},
+		{"rejected", netlify.ErrDeployRejected},
+	} {
+		t.Run(tc.state, func(t *testing.T) {
+			client, srv := newTestClient(t)
+			site := srv.AddSite("site-falho")
+
+			deploy, err := client.DeployContent(ctx, site, map[string]string{"index.html": tc.state})
+			if err != nil {
+				t.Fatalf("erro no deploy: %v", err)
+			}
+			srv.SetDeployState(deploy.ID, tc.state, "falha simulada")
+
+			_, err = client.WaitForDeploy(ctx, deploy.ID, fastWait...)
+			if !errors.Is(err, tc.want) {
+				t.Fatalf("erro %v, esperado %v", err, tc.want)
+			}
+			var stateErr *netlify.DeployStateError
+			if !errors.As(err, &stateErr) || stateErr.Message != "falha simulada" {
+				t.Fatalf("erro %v sem a mensagem da Netlify", err)
+			}
+		})
+	}
+}
+
+func TestDeployMetricsRecordFinalState(t *testing.T) {
+	ctx := context.Background()
+	client, srv := newTestClient(t)
+	site := srv.AddSite("site-metricas")
+
+	count := func(outcome string) float64 {
+		return testutil.ToFloat64(metrics.Deploys.WithLabelValues(string(store.SourceContent), outcome))
+	}
+	successes, failures := count(metrics.OutcomeSuccess), count(metrics.OutcomeError)
+
+	// O deploy enviado só é contabilizado quando a Netlify informa o estado final
+	deploy, err := client.DeployContent(ctx, site, map[string]string{"index.html": "métricas"})
+	if err != nil {
+		t.Fatalf("erro no deploy: %v", err)
+	}
+	if got := count(metrics.OutcomeSuccess); got != successes {
+		t.Fatalf("deploy contabilizado antes do estado final: %v sucessos, esperado %v", got, successes)
+	}
+	if _, err := client.WaitForDeploy(ctx, deploy.ID, fastWait...); err != nil {
+		t.Fatalf("erro aguardando o deploy: %v", err)
+	}
+	if got := count(metrics.OutcomeSuccess); got != successes+1 {
+		t.Fatalf("%v sucessos após o deploy ficar pronto, esperado %v", got, successes+1)
+	}
+
+	// Um deploy enviado com sucesso que falha no processamento conta como erro
+	deploy, err = client.DeployContent(ctx, site, map[string]string{"index.html": "falha"})
+	if err != nil {
+		t.Fatalf("erro no deploy: %v", err)
+	}
+	srv.SetDeployState(deploy.ID, "error", "falha simulada")
+	if _, err := client.WaitForDeploy(ctx, deploy.ID, fastWait...); !errors.Is(err, netlify.ErrDeployFailed) {
+		t.Fatalf("erro %v, esperado ErrDeployFailed", err)
+	}
+	if got := count(metrics.OutcomeError); got != failures+1 {
+		t.Fatalf("%v erros após o deploy falhar, esperado %v", got, failures+1)
+	}
+	if got := count(metrics.OutcomeSuccess); got != successes+1 {
+		t.Fatalf("deploy com falha contabilizado como sucesso: %v sucessos", got)
+	}
+}
+
+func TestWaitForDeployTimeout(t *testing.T) {
+	ctx := context.Background()
+	client, srv := newTestClient(t)
+	site := srv.AddSite("site-lento")
+
+	deploy, err := client.DeployContent(ctx, site, map[string]string{"index.html": "lento"})
+	if err != nil {
+		t.Fatalf("erro no deploy: %v", err)
+	}
+	srv.SetDeployState(deploy.ID, "processing", "")
+
+	_, err = client.WaitForDeploy(ctx, deploy.ID, netlify.WithPollInterval(5*time.Millisecond), netlify.WithMaxWait(50*time.Millisecond))
+	if !errors.Is(err, netlify.ErrDeployTimeout) {
+		t.Fatalf("erro %v, esperado ErrDeployTimeout", err)
+	}
+}
+
+func TestRollbackDeploy(t *testing.T) {
+	ctx := context.Background()
+	client, srv := newTestClient(t)
+	site := srv.AddSite("site-rollback")
+
+	first := deployAndWait(t, ctx, client, site, map[string]string{"index.html": "versão 1"})
+	second := deployAndWait(t, ctx, client, site, map[string]string{"index.html": "versão 2"})
+
+	current, _ := srv.Site(site.ID)
+	if current.PublishedDeploy.ID != second.ID {
+		t.Fatalf("deploy publicado %s, esperado %s", current.PublishedDeploy.ID, second.ID)
+	}
+
+	restored, err := client.RollbackDeploy(ctx, site.ID, netlify.PreviousDeployTarget)
+	if err != nil {
+		t.Fatalf("erro no rollback: %v", err)
+	}
+	if restored.ID != first.ID {
+		t.Fatalf("rollback restaurou %s, esperado %s", restored.ID, first.ID)
+	}
+	current, _ = srv.Site(site.ID)
+	if current.PublishedDeploy.ID != first.ID {
+		t.Fatalf("deploy publicado %s após o rollback, esperado %s", current.PublishedDeploy.ID, first.ID)
+	}
+
+	// Deploys de outro site e IDs vazios são erros do chamador, não da Netlify
+	other := srv.AddSite("outro-site")
+	foreign := deployAndWait(t, ctx, client, other, map[string]string{"index.html": "outro"})
+	if _, err := client.RollbackDeploy(ctx, site.ID, foreign.ID); !errors.Is(err, netlify.ErrDeployNotFound) {
+		t.Fatalf("rollback para deploy de outro site retornou %v, esperado ErrDeployNotFound", err)
+	}
+	if _, err := client.RollbackDeploy(ctx, "", first.ID); !errors.Is(err, netlify.ErrInvalidInput) {
+		t.Fatalf("rollback sem site retornou %v, esperado ErrInvalidInput", err)
+	}
+}
+
+func TestRollbackWithoutPreviousDeploy(t *testing.T) {
+	ctx := context.Background()
+	client, srv := newTestClient(t)
+	site := srv.AddSite("site-novo")
+
+	deployAndWait(t, ctx, client, site, map[string]string{"index.html": "única versão"})
+
+	if _, err := client.RollbackDeploy(ctx, site.ID, netlify.PreviousDeployTarget); !errors.Is(err, netlify.ErrNoPreviousDeploy) {
+		t.Fatalf("erro %v, esperado ErrNoPreviousDeploy", err)
+	}
+}
+
+func TestRollbackPaginatesDeploys(t *testing.T) {
+	defer netlify.SetDeploysPerPage(2)()
+
+	ctx := context.Background()
+	client, srv := newTestClient(t)
+	srv.DeploysPerPage = 2
+	site := srv.AddSite("site-paginado")
+
+	first := deployAndWait(t, ctx, client, site, map[string]string{"index.html": "versão 1"})
+	deployAndWait(t, ctx, client, site, map[string]string{"index.html": "versão 2"})
+	// Rascunhos mais recentes empurram o deploy anterior para a terceira página
+	for _, content := range []string{"rascunho 1", "rascunho 2", "rascunho 3"} {
+		deployAndWait(t, netlify.WithDraft(ctx), client, site, map[string]string{"index.html": content})
+	}
+
+	previous, err := client.PreviousDeploy(ctx, site.ID)
+	if err != nil {
+		t.Fatalf("erro ao buscar o deploy anterior: %v", err)
+	}
+	if previous.ID != first.ID {
+		t.Fatalf("deploy anterior %s, esperado %s", previous.ID, first.ID)
+	}
+}
+
+func TestPublishDraft(t *testing.T) {
+	ctx := context.Background()
+	client, srv := newTestClient(t)
+	site := srv.AddSite("site-rascunho")
+
+	live := deployAndWait(t, ctx, client, site, map[string]string{"index.html": "produção"})
+	draft := deployAndWait(t, netlify.WithDraft(ctx), client, site, map[string]string{"index.html": "rascunho"})
+	if !draft.Draft {
+		t.Fatalf("deploy %s não foi criado como rascunho", draft.ID)
+	}
+	if netlify.PreviewURL(draft) == "" || netlify.PreviewURL(draft) == site.SslURL {
+		t.Fatalf("URL de pré-visualização inválida: %q", netlify.PreviewURL(draft))
+	}
+
+	// O rascunho fica pronto sem substituir o deploy publicado
+	current, _ := srv.Site(site.ID)
+	if current.PublishedDeploy.ID != live.ID {
+		t.Fatalf("rascunho %s foi publicado antes da revisão", draft.ID)
+	}
+
+	if _, err := client.PublishDeploy(ctx, site.ID, draft.ID); err != nil {
+		t.Fatalf("erro ao publicar rascunho: %v", err)
+	}
+	current, _ = srv.Site(site.ID)
+	if current.PublishedDeploy.ID != draft.ID {
+		t.Fatalf("deploy publicado %s, esperado o rascunho %s", current.PublishedDeploy.ID, draft.ID)
+	}
+
+	if _, err := client.PublishDeploy(ctx, site.ID, live.ID); !errors.Is(err, netlify.ErrDeployNotDraft) {
+		t.Fatalf("publicar deploy que não é rascunho retornou %v, esperado ErrDeployNotDraft", err)
+	}
+}
+
+func TestDomainsAndCertificate(t *testing.T) {
+	ctx := context.Background()
+	client, srv := newTestClient(t)
+	provider := dns.NewMemoryProvider()
+	client.SetDNSProvider(provider)
+	site := srv.AddSite("site-dominios")
+
+	// O primeiro domínio vira o principal, com o TXT de verificação enviado à Netlify e criado no DNS
+	if err := client.AddCustomDomain(ctx, site.ID, "www.exemplo.com.br"); err != nil {
+		t.Fatalf("erro ao adicionar domínio principal: %v", err)
+	}
+	current, _ := srv.Site(site.ID)
+	if current.CustomDomain != "www.exemplo.com.br" {
+		t.Fatalf("domínio principal %q, esperado www.exemplo.com.br", current.CustomDomain)
+	}
+	txt, ok := provider.Lookup("netlify-challenge.www.exemplo.com.br", dns.TypeTXT)
+	if !ok || txt.Value == "" || txt.Value != srv.TXTValue(site.ID) {
+		t.Fatalf("registro TXT %+v diferente do valor enviado à Netlify %q", txt, srv.TXTValue(site.ID))
+	}
+	if cname, ok := provider.Lookup("www.exemplo.com.br", dns.TypeCNAME); !ok || cname.Value != "site-dominios.netlify.app" {
+		t.Fatalf("registro CNAME %+v não aponta para o site", cname)
+	}
+
+	// Os seguintes viram aliases; domínios repetidos são recusados
+	if err := client.AddCustomDomain(ctx, site.ID, "loja.exemplo.com.br"); err != nil {
+		t.Fatalf("erro ao adicionar alias: %v", err)
+	}
+	current, _ = srv.Site(site.ID)
+	if len(current.DomainAliases) != 1 || current.DomainAliases[0] != "loja.exemplo.com.br" {
+		t.Fatalf("aliases %v, esperado [loja.exemplo.com.br]", current.DomainAliases)
+	}
+	if err := client.AddCustomDomain(ctx, site.ID, "loja.exemplo.com.br"); !errors.Is(err, netlify.ErrDomainExists) {
+		t.Fatalf("alias repetido retornou %v, esperado ErrDomainExists", err)
+	}
+
+	if err := client.RemoveCustomDomain(ctx, site.ID, "loja.exemplo.com.br"); err != nil {
+		t.Fatalf("erro ao remover alias: %v", err)
+	}
+	current, _ = srv.Site(site.ID)
+	if len(current.DomainAliases) != 0 {
+		t.Fatalf("aliases %v após a remoção", current.DomainAliases)
+	}
+
+	// Certificado: inexistente antes da solicitação, emitido para os domínios do site depois
+	cert, err := client.GetCertificate(ctx, site.ID)
+	if err != nil || cert != nil {
+		t.Fatalf("certificado %+v (erro %v) antes da solicitação", cert, err)
+	}
+	if _, err := client.ProvisionCertificate(ctx, site.ID); err != nil {
+		t.Fatalf("erro ao solicitar certificado: %v", err)
+	}
+	cert, err = client.GetCertificate(ctx, site.ID)
+	if err != nil || cert == nil || cert.State != "issued" {
+		t.Fatalf("certificado %+v (erro %v), esperado emitido", cert, err)
+	}
+	if len(cert.Domains) != 1 || cert.Domains[0] != "www.exemplo.com.br" {
+		t.Fatalf("certificado emitido para %v", cert.Domains)
+	}
+}
+
+func TestRetryOnRateLimitAndUnavailable(t *testing.T) {
+	ctx := context.Background()
+	client, srv := newTestClient(t)
+	site := srv.AddSite("site-instavel")
+
+	// Falhas temporárias abaixo do limite de tentativas são transparentes para o chamador
+	srv.Fail(
+		netlifytest.Failure{Status: http.StatusTooManyRequests, RetryAfter: 1, Method: http.MethodGet},
+		netlifytest.Failure{Status: http.StatusServiceUnavailable, Method: http.MethodGet},
+	)
+	got, err := client.GetSite(ctx, site.ID)
+	if err != nil {
+		t.Fatalf("erro após falhas temporárias: %v", err)
+	}
+	if got.ID != site.ID {
+		t.Fatalf("site %s, esperado %s", got.ID, site.ID)
+	}
+	if stats := client.RetryStats(); stats.Retries != 2 {
+		t.Fatalf("%d novas tentativas, esperado 2", stats.Retries)
+	}
+
+	// Os uploads de arquivos também são repetidos
+	srv.Fail(netlifytest.Failure{Status: http.StatusServiceUnavailable, Method: http.MethodPut, PathPrefix: "/api/v1/deploys/"})
+	deployAndWait(t, ctx, client, site, map[string]string{"index.html": "após falha no upload"})
+
+	// Esgotadas as tentativas, o erro identifica a causa
+	for _, tc := range []struct {
+		status int
+		want   error
+	}{
+		{http.StatusTooManyRequests, netlify.ErrRateLimited},
+		{http.StatusBadGateway, netlify.ErrUnavailable},
+	} {
+		failures := make([]netlifytest.Failure, testRetryPolicy.MaxRetries+1)
+		for i := range failures {
+			failures[i] = netlifytest.Failure{Status: tc.status, Method: http.MethodGet}
+		}
+		srv.Fail(failures...)
+
+		_, err := client.GetSite(ctx, site.ID)
+		if !errors.Is(err, tc.want) {
+			t.Fatalf("status %d retornou %v, esperado %v", tc.status, err, tc.want)
+		}
+	}
+}
+
+func TestUnauthorizedToken(t *testing.T) {
+	ctx := context.Background()
+	client, srv := newTestClient(t)
+	site := srv.AddSite("site-privado")
+	if err := client.VerifyToken(ctx); err != nil {
+		t.Fatalf("token válido recusado: %v", err)
+	}
+	srv.Token = "outro-token"
+
+	_, err := client.GetSite(ctx, site.ID)
+	if !errors.Is(netlify.Classify(err), netlify.ErrUnauthorized) {
+		t.Fatalf("erro %v, esperado ErrUnauthorized", err)
+	}
+	if err := client.VerifyToken(ctx); !errors.Is(err, netlify.ErrUnauthorized) {
+		t.Fatalf("verificação do token retornou %v, esperado ErrUnauthorized", err)
+	}
+}
+
+func TestSiteErrorsAreClassified(t *testing.T) {
+	ctx := context.Background()
+	client, srv := newTestClient(t)
+	site := srv.AddSite("site-classificado")
+
+	if _, err := client.ListSiteDeploys(ctx, "site-inexistente"); !errors.Is(err, netlify.ErrSiteNotFound) {
+		t.Fatalf("listagem de deploys retornou %v, esperado ErrSiteNotFound", err)
+	}
+	params := netlify.TestDeployParams{SiteID: "site-inexistente", SiteName: "site-inexistente"}
+	if _, err := client.ExecuteTestDeploy(ctx, params); !errors.Is(err, netlify.ErrSiteNotFound) {
+		t.Fatalf("teste de deploy retornou %v, esperado ErrSiteNotFound", err)
+	}
+
+	srv.Token = "outro-token"
+	if _, err := client.ListSiteDeploys(ctx, site.ID); !errors.Is(err, netlify.ErrUnauthorized) {
+		t.Fatalf("listagem de deploys retornou %v, esperado ErrUnauthorized", err)
+	}
+	params = netlify.TestDeployParams{SiteID: site.ID, SiteName: site.Name}
+	if _, err := client.ExecuteTestDeploy(ctx, params); !errors.Is(err, netlify.ErrUnauthorized) {
+		t.Fatalf("teste de deploy retornou %v, esperado ErrUnauthorized", err)
+	}
+}
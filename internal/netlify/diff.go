@@ -0,0 +1,225 @@
+package netlify
+
+import (
+	"context"
+	"crypto/sha1"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/kodestech/poc-netlify/internal/logging"
+	"github.com/kodestech/poc-netlify/internal/tracing"
+	"github.com/netlify/open-api/go/plumbing/operations"
+)
+
+// DiffFile descreve um arquivo que muda entre o deploy publicado e a origem do novo deploy
+type DiffFile struct {
+	Path string `json:"path" example:"index.html" swagger:"description=Caminho do arquivo no site"`
+	// Size é o tamanho na origem; nos arquivos removidos, o tamanho no deploy publicado
+	Size int64 `json:"size" example:"2048" swagger:"description=Tamanho do arquivo em bytes (nos removidos, o tamanho no deploy publicado)"`
+	// LiveSize é o tamanho no deploy publicado, apenas nos arquivos modificados
+	LiveSize int64 `json:"live_size,omitempty" example:"1980" swagger:"description=Tamanho atual do arquivo no deploy publicado (apenas nos modificados)"`
+}
+
+// DeployDiff é o resultado de um dry-run: o que mudaria no site se a origem fosse publicada
+type DeployDiff struct {
+	SiteID       string     `json:"site_id" example:"a1b2c3d4" swagger:"description=ID do site na Netlify"`
+	LiveDeployID string     `json:"live_deploy_id,omitempty" example:"5f1b2c3d4e5f6a7b8c9d0e1f" swagger:"description=ID do deploy publicado usado na comparação (vazio se o site nunca foi publicado)"`
+	Added        []DiffFile `json:"added" swagger:"description=Arquivos que serão criados"`
+	Modified     []DiffFile `json:"modified" swagger:"description=Arquivos cujo conteúdo será alterado"`
+	Removed      []DiffFile `json:"removed" swagger:"description=Arquivos publicados que deixarão de existir"`
+	Unchanged    int        `json:"unchanged" example:"40" swagger:"description=Quantidade de arquivos sem alteração"`
+	UploadBytes  int64      `json:"upload_bytes" example:"4096" swagger:"description=Total de bytes dos arquivos adicionados e modificados"`
+}
+
+// HasChanges indica se publicar a origem alteraria algum arquivo do site
+func (d *DeployDiff) HasChanges() bool {
+	return len(d.Added) > 0 || len(d.Modified) > 0 || len(d.Removed) > 0
+}
+
+// LiveFiles lista os arquivos do deploy publicado atualmente no site, com o SHA1 de cada um.
+// Um site sem deploy publicado não tem arquivos.
+func (c *Client) LiveFiles(ctx context.Context, siteID string) (files []RemoteFile, err error) {
+	ctx, span := tracing.Start(ctx, "netlify.LiveFiles", tracing.SiteID.String(siteID))
+	defer func() { tracing.End(span, err) }()
+
+	params := operations.NewListSiteFilesParams().WithContext(ctx).WithSiteID(siteID)
+	resp, err := c.netlify.Operations.ListSiteFiles(params, c.auth)
+	if err != nil {
+		if isNotFound(err) {
+			return nil, fmt.Errorf("%w: %s", ErrSiteNotFound, siteID)
+		}
+		return nil, fmt.Errorf("erro ao listar arquivos do site: %w", err)
+	}
+
+	files = make([]RemoteFile, 0, len(resp.Payload))
+	for _, f := range resp.Payload {
+		path := f.Path
+		if path == "" {
+			path = f.ID
+		}
+		files = append(files, RemoteFile{Path: strings.TrimPrefix(path, "/"), SHA1: f.Sha, Size: f.Size})
+	}
+	return files, nil
+}
+
+// DiffDeploy compara o manifesto de uma origem com os arquivos do deploy publicado do site,
+// sem criar nenhum deploy
+func (c *Client) DiffDeploy(ctx context.Context, siteID string, files []RemoteFile) (diff *DeployDiff, err error) {
+	ctx, span := tracing.Start(ctx, "netlify.DiffDeploy", tracing.SiteID.String(siteID), tracing.Files.Int(len(files)))
+	defer func() { tracing.End(span, err) }()
+
+	ctx = logging.With(ctx, "site_id", siteID)
+	slog.InfoContext(ctx, "Comparando origem com o deploy publicado", "files", len(files))
+
+	if siteID == "" {
+		return nil, fmt.Errorf("%w: ID do site não pode ser vazio", ErrInvalidInput)
+	}
+
+	site, err := c.GetSite(ctx, siteID)
+	if err != nil {
+		return nil, err
+	}
+
+	diff = &DeployDiff{SiteID: siteID, Added: []DiffFile{}, Modified: []DiffFile{}, Removed: []DiffFile{}}
+	var live []RemoteFile
+	if site.PublishedDeploy != nil {
+		diff.LiveDeployID = site.PublishedDeploy.ID
+		live, err = c.LiveFiles(ctx, siteID)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	liveByPath := make(map[string]RemoteFile, len(live))
+	for _, f := range live {
+		liveByPath[f.Path] = f
+	}
+
+	seen := make(map[string]bool, len(files))
+	for _, f := range files {
+		seen[f.Path] = true
+		current, ok := liveByPath[f.Path]
+		switch {
+		case !ok:
+			diff.Added = append(diff.Added, DiffFile{Path: f.Path, Size: f.Size})
+			diff.UploadBytes += f.Size
+		case current.SHA1 != f.SHA1:
+			diff.Modified = append(diff.Modified, DiffFile{Path: f.Path, Size: f.Size, LiveSize: current.Size})
+			diff.UploadBytes += f.Size
+		default:
+			diff.Unchanged++
+		}
+	}
+	for _, f := range live {
+		if !seen[f.Path] {
+			diff.Removed = append(diff.Removed, DiffFile{Path: f.Path, Size: f.Size})
+		}
+	}
+
+	for _, list := range [][]DiffFile{diff.Added, diff.Modified, diff.Removed} {
+		sort.Slice(list, func(i, j int) bool { return list[i].Path < list[j].Path })
+	}
+
+	slog.InfoContext(ctx, "Comparação concluída",
+		"live_deploy_id", diff.LiveDeployID,
+		"added", len(diff.Added),
+		"modified", len(diff.Modified),
+		"removed", len(diff.Removed),
+		"unchanged", diff.Unchanged,
+	)
+	return diff, nil
+}
+
+// FolderManifest calcula o SHA1 dos arquivos de uma pasta local, ignorando os mesmos arquivos
+// ocultos que o deploy de pastas ignora (exceto .well-known)
+func FolderManifest(dir string) ([]RemoteFile, error) {
+	var files []RemoteFile
+	err := filepath.WalkDir(dir, func(path string, entry os.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if !entry.Type().IsRegular() {
+			return nil
+		}
+
+		rel, err := filepath.Rel(dir, path)
+		if err != nil {
+			return err
+		}
+		rel = filepath.ToSlash(rel)
+		if ignoreFile(rel) {
+			return nil
+		}
+
+		sum, size, err := fileDigest(path)
+		if err != nil {
+			return fmt.Errorf("erro ao calcular digest de %s: %w", rel, err)
+		}
+		files = append(files, RemoteFile{Path: rel, SHA1: sum, Size: size})
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("erro ao percorrer a pasta %s: %w", dir, err)
+	}
+	return files, nil
+}
+
+// ContentManifest calcula o SHA1 de arquivos em memória, indexados pelo caminho no site
+func ContentManifest(contents map[string]string) []RemoteFile {
+	files := make([]RemoteFile, 0, len(contents))
+	for path, content := range contents {
+		sum := sha1.Sum([]byte(content))
+		files = append(files, RemoteFile{Path: path, SHA1: hex.EncodeToString(sum[:]), Size: int64(len(content))})
+	}
+	return files
+}
+
+// Manifest monta o manifesto da origem do teste de deploy, com a mesma prioridade de ExecuteTestDeploy:
+// arquivo enviado, conteúdo de teste e, por último, a pasta local
+func (p TestDeployParams) Manifest() ([]RemoteFile, error) {
+	switch {
+	case p.FileContent != "":
+		data, err := base64.StdEncoding.DecodeString(p.FileContent)
+		if err != nil {
+			return nil, fmt.Errorf("%w: erro ao decodificar conteúdo do arquivo: %v", ErrInvalidInput, err)
+		}
+		return ContentManifest(map[string]string{"index.html": string(data)}), nil
+	case p.TestContent != "":
+		return ContentManifest(map[string]string{"index.html": p.TestContent}), nil
+	case p.FolderPath != "":
+		return FolderManifest(p.FolderPath)
+	default:
+		return nil, fmt.Errorf("%w: nenhum arquivo, conteúdo ou pasta informado", ErrInvalidInput)
+	}
+}
+
+// fileDigest retorna o SHA1 hexadecimal e o tamanho de um arquivo local
+func fileDigest(path string) (string, int64, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return "", 0, err
+	}
+	defer f.Close()
+
+	h := sha1.New()
+	size, err := io.Copy(h, f)
+	if err != nil {
+		return "", 0, err
+	}
+	return hex.EncodeToString(h.Sum(nil)), size, nil
+}
+
+// ignoreFile indica se o deploy de pastas da Netlify ignora o arquivo (ocultos e __MACOSX, exceto .well-known)
+func ignoreFile(rel string) bool {
+	if strings.HasPrefix(rel, ".") || strings.Contains(rel, "/.") || strings.HasPrefix(rel, "__MACOS") {
+		return !strings.HasPrefix(rel, ".well-known/")
+	}
+	return false
+}
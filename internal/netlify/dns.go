@@ -0,0 +1,97 @@
+package netlify
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+
+	"github.com/kodestech/poc-netlify/internal/dns"
+	"github.com/netlify/open-api/go/models"
+	"golang.org/x/net/publicsuffix"
+)
+
+const (
+	// netlifyLoadBalancer é o destino recomendado pela Netlify para registros ALIAS de domínios raiz
+	netlifyLoadBalancer = "apex-loadbalancer.netlify.com"
+	// netlifyLoadBalancerIP é o destino do registro A quando o provedor não suporta ALIAS
+	netlifyLoadBalancerIP = "75.2.60.5"
+	// txtChallengePrefix é o prefixo do registro TXT usado pela Netlify para verificar o domínio
+	txtChallengePrefix = "netlify-challenge."
+)
+
+// SetDNSProvider define o provedor onde os registros DNS dos domínios personalizados serão criados
+func (c *Client) SetDNSProvider(provider dns.Provider) {
+	c.dnsProvider = provider
+}
+
+// siteTarget retorna o domínio da Netlify para o qual os domínios personalizados do site apontam
+func siteTarget(site *models.Site) string {
+	return site.Name + ".netlify.app"
+}
+
+// isApexDomain indica se o domínio é raiz (ex: exemplo.com.br), que não pode receber CNAME
+func isApexDomain(domain string) bool {
+	apex, err := publicsuffix.EffectiveTLDPlusOne(domain)
+	return err == nil && apex == domain
+}
+
+// provisionDomainRecords cria os registros que apontam o domínio para o site e,
+// se txtValue for informado, o registro TXT de verificação
+func (c *Client) provisionDomainRecords(ctx context.Context, site *models.Site, domain, txtValue string) error {
+	if c.dnsProvider == nil {
+		slog.WarnContext(ctx, "Provedor DNS não configurado: adicione manualmente um registro CNAME", "domain", domain, "target", siteTarget(site))
+		if txtValue != "" {
+			slog.WarnContext(ctx, "Adicione também o registro TXT de verificação", "record", txtChallengePrefix+domain, "value", txtValue)
+		}
+		return nil
+	}
+
+	ttl := c.config.DNSRecordTTL
+	var err error
+	if isApexDomain(domain) {
+		// Domínios raiz usam ALIAS para o balanceador da Netlify ou, na falta dele, um registro A
+		err = c.dnsProvider.CreateRecord(ctx, dns.Record{Name: domain, Type: dns.TypeALIAS, Value: netlifyLoadBalancer, TTL: ttl})
+		if errors.Is(err, dns.ErrUnsupportedRecord) {
+			err = c.dnsProvider.CreateRecord(ctx, dns.Record{Name: domain, Type: dns.TypeA, Value: netlifyLoadBalancerIP, TTL: ttl})
+		}
+	} else {
+		err = c.dnsProvider.CreateRecord(ctx, dns.Record{Name: domain, Type: dns.TypeCNAME, Value: siteTarget(site), TTL: ttl})
+	}
+	if err != nil {
+		return fmt.Errorf("erro ao criar registro DNS para %s: %w", domain, err)
+	}
+
+	if txtValue != "" {
+		err := c.dnsProvider.CreateRecord(ctx, dns.Record{Name: txtChallengePrefix + domain, Type: dns.TypeTXT, Value: txtValue, TTL: ttl})
+		if err != nil {
+			return fmt.Errorf("erro ao criar registro TXT de verificação para %s: %w", domain, err)
+		}
+	}
+
+	slog.InfoContext(ctx, "Registros DNS criados", "domain", domain, "target", siteTarget(site))
+	return nil
+}
+
+// removeDomainRecords remove os registros DNS criados para o domínio
+func (c *Client) removeDomainRecords(ctx context.Context, domain string) error {
+	if c.dnsProvider == nil {
+		return nil
+	}
+
+	types := []dns.RecordType{dns.TypeCNAME}
+	if isApexDomain(domain) {
+		types = []dns.RecordType{dns.TypeALIAS, dns.TypeA}
+	}
+
+	var firstErr error
+	for _, recordType := range types {
+		if err := c.dnsProvider.DeleteRecord(ctx, domain, recordType); err != nil && firstErr == nil {
+			firstErr = err
+		}
+	}
+	if err := c.dnsProvider.DeleteRecord(ctx, txtChallengePrefix+domain, dns.TypeTXT); err != nil && firstErr == nil {
+		firstErr = err
+	}
+	return firstErr
+}
@@ -0,0 +1,236 @@
+package netlify
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"strings"
+	"time"
+
+	"github.com/kodestech/poc-netlify/internal/dns"
+	"github.com/kodestech/poc-netlify/internal/store"
+	"github.com/kodestech/poc-netlify/internal/tracing"
+	"github.com/netlify/open-api/go/models"
+)
+
+// DomainState representa a etapa de configuração de um domínio personalizado
+type DomainState string
+
+const (
+	// DomainPendingDNS indica que o DNS ainda não aponta para a Netlify ou o TXT de verificação não foi encontrado
+	DomainPendingDNS DomainState = "pending_dns"
+	// DomainVerified indica que o DNS está correto, mas o certificado ainda não foi solicitado
+	DomainVerified DomainState = "verified"
+	// DomainCertProvisioning indica que o certificado está sendo emitido ou ainda não cobre o domínio
+	DomainCertProvisioning DomainState = "cert_provisioning"
+	// DomainLive indica que o domínio aponta para a Netlify e possui certificado emitido
+	DomainLive DomainState = "live"
+)
+
+// DomainRecorder guarda os valores TXT usados na verificação dos domínios
+type DomainRecorder interface {
+	SaveDomainVerification(v *store.DomainVerification) error
+	GetDomainVerification(domain string) (*store.DomainVerification, bool, error)
+}
+
+// DomainStatus representa o estado de um domínio personalizado de um site
+type DomainStatus struct {
+	Domain          string      `json:"domain" example:"meu-site.exemplo.com" swagger:"description=Domínio personalizado"`
+	Primary         bool        `json:"primary" example:"true" swagger:"description=Indica se é o domínio principal do site"`
+	State           DomainState `json:"state" example:"live" swagger:"description=Etapa atual (pending_dns, verified, cert_provisioning, live)"`
+	ExpectedTarget  string      `json:"expected_target" example:"meu-site.netlify.app" swagger:"description=Destino esperado do CNAME/ALIAS"`
+	CNAME           string      `json:"cname,omitempty" example:"meu-site.netlify.app" swagger:"description=Destino do CNAME encontrado no DNS"`
+	Addresses       []string    `json:"addresses,omitempty" swagger:"description=Endereços resolvidos para o domínio"`
+	PointsToNetlify bool        `json:"points_to_netlify" example:"true" swagger:"description=Indica se o DNS aponta para a Netlify"`
+	TXTRecord       string      `json:"txt_record,omitempty" example:"netlify-challenge.meu-site.exemplo.com" swagger:"description=Nome do registro TXT de verificação"`
+	TXTVerified     *bool       `json:"txt_verified,omitempty" example:"true" swagger:"description=Indica se o TXT de verificação foi encontrado (ausente quando não há valor registrado)"`
+	CertCovered     bool        `json:"cert_covered" example:"true" swagger:"description=Indica se o certificado do site cobre o domínio"`
+	Errors          []string    `json:"errors,omitempty" swagger:"description=Falhas nas consultas DNS"`
+}
+
+// SiteDomainsStatus representa o estado dos domínios e do certificado de um site
+type SiteDomainsStatus struct {
+	SiteID      string                 `json:"site_id" example:"a1b2c3d4" swagger:"description=ID do site na Netlify"`
+	SiteName    string                 `json:"site_name" example:"meu-site" swagger:"description=Nome do site na Netlify"`
+	Certificate *models.SniCertificate `json:"certificate,omitempty" swaggertype:"object" swagger:"description=Certificado TLS do site (ausente se ainda não solicitado)"`
+	Domains     []DomainStatus         `json:"domains" swagger:"description=Estado de cada domínio personalizado"`
+	CheckedAt   time.Time              `json:"checked_at" swagger:"description=Momento da verificação"`
+}
+
+// SetDomainRecorder define onde os valores TXT de verificação dos domínios serão guardados
+func (c *Client) SetDomainRecorder(recorder DomainRecorder) {
+	c.domains = recorder
+}
+
+// recordDomainVerification guarda o valor TXT enviado à Netlify para o domínio
+func (c *Client) recordDomainVerification(ctx context.Context, siteID, domain, txtValue string) {
+	if c.domains == nil {
+		return
+	}
+
+	err := c.domains.SaveDomainVerification(&store.DomainVerification{
+		Domain:    domain,
+		SiteID:    siteID,
+		TXTValue:  txtValue,
+		CreatedAt: time.Now(),
+	})
+	if err != nil {
+		slog.WarnContext(ctx, "Erro ao registrar verificação do domínio", "site_id", siteID, "domain", domain, "error", err)
+	}
+}
+
+// GetCertificate retorna o certificado TLS do site, ou nil se ainda não foi solicitado
+func (c *Client) GetCertificate(ctx context.Context, siteID string) (cert *models.SniCertificate, err error) {
+	ctx, span := tracing.Start(ctx, "netlify.GetCertificate", tracing.SiteID.String(siteID))
+	defer func() { tracing.End(span, err) }()
+
+	cert, err = c.netlify.GetSiteTLSCertificate(c.createAuthContext(ctx), siteID)
+	if err != nil {
+		if isNotFound(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("erro ao obter certificado: %w", err)
+	}
+	return cert, nil
+}
+
+// ProvisionCertificate solicita à Netlify a emissão do certificado Let's Encrypt para os domínios do site
+func (c *Client) ProvisionCertificate(ctx context.Context, siteID string) (cert *models.SniCertificate, err error) {
+	ctx, span := tracing.Start(ctx, "netlify.ProvisionCertificate", tracing.SiteID.String(siteID))
+	defer func() { tracing.End(span, err) }()
+
+	slog.InfoContext(ctx, "Solicitando certificado TLS", "site_id", siteID)
+
+	if _, err := c.GetSite(ctx, siteID); err != nil {
+		return nil, err
+	}
+
+	cert, err = c.netlify.ConfigureSiteTLSCertificate(c.createAuthContext(ctx), siteID, nil)
+	if err != nil {
+		return nil, fmt.Errorf("erro ao solicitar certificado: %w", err)
+	}
+
+	slog.InfoContext(ctx, "Certificado TLS solicitado", "site_id", siteID, "state", cert.State)
+	return cert, nil
+}
+
+// DomainsStatus verifica o DNS, o TXT de verificação e o certificado de cada domínio personalizado do site
+func (c *Client) DomainsStatus(ctx context.Context, siteID string, resolver dns.Resolver) (status *SiteDomainsStatus, err error) {
+	ctx, span := tracing.Start(ctx, "netlify.DomainsStatus", tracing.SiteID.String(siteID))
+	defer func() { tracing.End(span, err) }()
+
+	site, err := c.GetSite(ctx, siteID)
+	if err != nil {
+		return nil, err
+	}
+
+	cert, err := c.GetCertificate(ctx, siteID)
+	if err != nil {
+		return nil, err
+	}
+
+	status = &SiteDomainsStatus{
+		SiteID:      site.ID,
+		SiteName:    site.Name,
+		Certificate: cert,
+		Domains:     []DomainStatus{},
+		CheckedAt:   time.Now(),
+	}
+
+	if site.CustomDomain != "" {
+		status.Domains = append(status.Domains, c.domainStatus(ctx, site, site.CustomDomain, true, cert, resolver))
+	}
+	for _, alias := range site.DomainAliases {
+		status.Domains = append(status.Domains, c.domainStatus(ctx, site, alias, false, cert, resolver))
+	}
+	return status, nil
+}
+
+// domainStatus consulta o DNS de um domínio e determina sua etapa de configuração
+func (c *Client) domainStatus(ctx context.Context, site *models.Site, domain string, primary bool, cert *models.SniCertificate, resolver dns.Resolver) DomainStatus {
+	status := DomainStatus{
+		Domain:         domain,
+		Primary:        primary,
+		ExpectedTarget: siteTarget(site),
+	}
+	if isApexDomain(domain) {
+		status.ExpectedTarget = netlifyLoadBalancer
+	}
+
+	if cname, err := resolver.LookupCNAME(ctx, domain); err == nil {
+		status.CNAME = strings.TrimSuffix(cname, ".")
+	} else {
+		status.Errors = append(status.Errors, fmt.Sprintf("CNAME: %v", err))
+	}
+	if addrs, err := resolver.LookupHost(ctx, domain); err == nil {
+		status.Addresses = addrs
+	} else {
+		status.Errors = append(status.Errors, fmt.Sprintf("endereços: %v", err))
+	}
+	status.PointsToNetlify = pointsToNetlify(site, status)
+
+	// O TXT só é conferido quando o valor enviado à Netlify é conhecido
+	if c.domains != nil {
+		if v, ok, err := c.domains.GetDomainVerification(domain); err == nil && ok {
+			status.TXTRecord = txtChallengePrefix + domain
+			verified := false
+			if values, err := resolver.LookupTXT(ctx, status.TXTRecord); err == nil {
+				for _, value := range values {
+					if value == v.TXTValue {
+						verified = true
+						break
+					}
+				}
+			} else {
+				status.Errors = append(status.Errors, fmt.Sprintf("TXT: %v", err))
+			}
+			status.TXTVerified = &verified
+		}
+	}
+
+	status.CertCovered = certCovers(cert, domain)
+
+	switch {
+	case !status.PointsToNetlify || (status.TXTVerified != nil && !*status.TXTVerified):
+		status.State = DomainPendingDNS
+	case cert == nil:
+		status.State = DomainVerified
+	case status.CertCovered && (cert.State == "issued" || cert.State == "custom"):
+		status.State = DomainLive
+	default:
+		status.State = DomainCertProvisioning
+	}
+	return status
+}
+
+// pointsToNetlify indica se o CNAME ou os endereços resolvidos apontam para a Netlify
+func pointsToNetlify(site *models.Site, status DomainStatus) bool {
+	cname := strings.ToLower(status.CNAME)
+	if cname == siteTarget(site) || cname == site.Name+".netlify.com" || cname == netlifyLoadBalancer {
+		return true
+	}
+	for _, addr := range status.Addresses {
+		if addr == netlifyLoadBalancerIP || addr == netlifyLoadBalancer {
+			return true
+		}
+	}
+	return false
+}
+
+// certCovers indica se o certificado cobre o domínio, considerando curingas (*.exemplo.com)
+func certCovers(cert *models.SniCertificate, domain string) bool {
+	if cert == nil {
+		return false
+	}
+	for _, name := range cert.Domains {
+		if strings.EqualFold(name, domain) {
+			return true
+		}
+		if strings.HasPrefix(name, "*.") {
+			if i := strings.Index(domain, "."); i > 0 && strings.EqualFold(name[2:], domain[i+1:]) {
+				return true
+			}
+		}
+	}
+	return false
+}
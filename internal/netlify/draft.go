@@ -0,0 +1,63 @@
+package netlify
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+
+	"github.com/kodestech/poc-netlify/internal/logging"
+	"github.com/kodestech/poc-netlify/internal/tracing"
+	"github.com/netlify/open-api/go/models"
+)
+
+// ErrDeployNotDraft indica que o deploy a publicar não é um rascunho
+var ErrDeployNotDraft = errors.New("deploy não é um rascunho")
+
+type draftKey struct{}
+
+// WithDraft retorna um contexto em que os próximos deploys são criados como rascunho:
+// ficam disponíveis apenas na URL de pré-visualização até serem publicados com PublishDeploy
+func WithDraft(ctx context.Context) context.Context {
+	return context.WithValue(ctx, draftKey{}, true)
+}
+
+// isDraft indica se o contexto pede um deploy em rascunho (WithDraft)
+func isDraft(ctx context.Context) bool {
+	draft, _ := ctx.Value(draftKey{}).(bool)
+	return draft
+}
+
+// PreviewURL retorna a URL de pré-visualização de um deploy, preferindo HTTPS
+func PreviewURL(deploy *models.Deploy) string {
+	if deploy.DeploySslURL != "" {
+		return deploy.DeploySslURL
+	}
+	return deploy.DeployURL
+}
+
+// PublishDeploy publica em produção um deploy em rascunho já revisado e aguarda a publicação
+func (c *Client) PublishDeploy(ctx context.Context, siteID, deployID string) (deploy *models.Deploy, err error) {
+	ctx, span := tracing.Start(ctx, "netlify.PublishDeploy", tracing.SiteID.String(siteID), tracing.DeployID.String(deployID))
+	defer func() { tracing.End(span, err) }()
+
+	ctx = logging.With(ctx, "site_id", siteID)
+	slog.InfoContext(ctx, "Publicando rascunho", "deploy_id", deployID)
+
+	if siteID == "" {
+		return nil, fmt.Errorf("%w: ID do site não pode ser vazio", ErrInvalidInput)
+	}
+	if deployID == "" {
+		return nil, fmt.Errorf("%w: ID do deploy não pode ser vazio", ErrInvalidInput)
+	}
+
+	target, err := c.GetDeploy(ctx, deployID)
+	if err != nil {
+		return nil, err
+	}
+	if !target.Draft {
+		return nil, fmt.Errorf("%w: %s", ErrDeployNotDraft, target.ID)
+	}
+
+	return c.restoreDeploy(ctx, siteID, target)
+}
@@ -0,0 +1,95 @@
+package netlify
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/netlify/open-api/go/models"
+)
+
+// Erros sentinela do pacote. Os erros retornados pelo Client os envolvem com %w,
+// então compare com errors.Is; a API converte cada um em um código HTTP (ver internal/api/errors.go).
+// ErrRateLimited e ErrUnavailable ficam em retry.go; ErrDeployFailed, ErrDeployRejected e ErrDeployTimeout em wait.go;
+// ErrDeployNotReady e ErrNoPreviousDeploy em rollback.go; ErrDeployNotDraft em draft.go.
+var (
+	// ErrInvalidInput indica parâmetros ausentes ou inválidos (ex: ID do site vazio)
+	ErrInvalidInput = errors.New("parâmetros inválidos")
+	// ErrNotFound indica que o recurso consultado não existe na Netlify
+	ErrNotFound = errors.New("recurso não encontrado na Netlify")
+	// ErrSiteNotFound indica que o site não existe na Netlify
+	ErrSiteNotFound = errors.New("site não encontrado")
+	// ErrDeployNotFound indica que o deploy não existe na Netlify
+	ErrDeployNotFound = errors.New("deploy não encontrado")
+	// ErrDomainExists indica que o domínio já está cadastrado no site (principal ou alias)
+	ErrDomainExists = errors.New("domínio já cadastrado no site")
+	// ErrDomainNotFound indica que o domínio não está cadastrado no site
+	ErrDomainNotFound = errors.New("domínio não encontrado no site")
+	// ErrPrimaryDomainSet indica que a operação exige um site sem domínio principal
+	ErrPrimaryDomainSet = errors.New("o site já tem um domínio principal configurado")
+	// ErrUnauthorized indica que a Netlify recusou o token da conta
+	ErrUnauthorized = errors.New("token da Netlify inválido ou sem permissão")
+	// ErrRejected indica que a Netlify recusou a requisição por violar suas regras (status 422)
+	ErrRejected = errors.New("requisição recusada pela Netlify")
+)
+
+// Classify associa um erro da API da Netlify ao erro sentinela correspondente, preservando o original.
+// Erros já classificados ou que não vieram da Netlify são retornados sem alteração.
+func Classify(err error) error {
+	if err == nil || isClassified(err) {
+		return err
+	}
+
+	var coder interface{ Code() int }
+	if !errors.As(err, &coder) {
+		return err
+	}
+
+	switch coder.Code() {
+	case http.StatusUnauthorized, http.StatusForbidden:
+		return fmt.Errorf("%w: %w", ErrUnauthorized, err)
+	case http.StatusNotFound:
+		return fmt.Errorf("%w: %w", ErrNotFound, err)
+	case http.StatusUnprocessableEntity:
+		// A Netlify não aceita alterar os aliases enquanto o domínio principal não é verificado
+		if strings.Contains(errorMessage(err), "while primary") {
+			return fmt.Errorf("%w: %w", ErrPrimaryDomainSet, err)
+		}
+		return fmt.Errorf("%w: %w", ErrRejected, err)
+	}
+	return err
+}
+
+// isClassified indica se o erro já envolve um dos erros sentinela do pacote
+func isClassified(err error) bool {
+	for _, sentinel := range []error{
+		ErrInvalidInput, ErrNotFound, ErrSiteNotFound, ErrDeployNotFound, ErrDomainExists, ErrDomainNotFound,
+		ErrPrimaryDomainSet, ErrUnauthorized, ErrRejected, ErrRateLimited, ErrUnavailable,
+		ErrDeployFailed, ErrDeployRejected, ErrDeployTimeout, ErrDeployNotReady, ErrNoPreviousDeploy, ErrDeployNotDraft,
+	} {
+		if errors.Is(err, sentinel) {
+			return true
+		}
+	}
+	return false
+}
+
+// errorMessage extrai a mensagem enviada pela Netlify no corpo do erro, quando disponível
+func errorMessage(err error) string {
+	var payload interface{ GetPayload() *models.Error }
+	if errors.As(err, &payload) && payload.GetPayload() != nil {
+		return payload.GetPayload().Message
+	}
+	var apiErr *APIError
+	if errors.As(err, &apiErr) {
+		return apiErr.Body
+	}
+	return err.Error()
+}
+
+// isNotFound indica se o erro retornado pela API da Netlify é um 404
+func isNotFound(err error) bool {
+	var coder interface{ Code() int }
+	return errors.As(err, &coder) && coder.Code() == http.StatusNotFound
+}
@@ -0,0 +1,8 @@
+package netlify
+
+// SetDeploysPerPage altera o tamanho das páginas de deploys durante um teste
+func SetDeploysPerPage(perPage int) (restore func()) {
+	previous := deploysPerPage
+	deploysPerPage = perPage
+	return func() { deploysPerPage = previous }
+}
@@ -0,0 +1,91 @@
+package netlify
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/kodestech/poc-netlify/internal/store"
+	"github.com/netlify/open-api/go/models"
+)
+
+// DeployRecorder registra no histórico os deploys realizados pelo cliente
+type DeployRecorder interface {
+	SaveDeploy(rec *store.DeployRecord) error
+	UpdateDeployState(deployID, state, errMsg string, finishedAt time.Time) error
+}
+
+type deploySourceKey struct{}
+
+type deploySource struct {
+	sourceType store.SourceType
+	source     string
+}
+
+// WithDeploySource retorna um contexto que identifica a origem dos arquivos do próximo deploy
+func WithDeploySource(ctx context.Context, sourceType store.SourceType, source string) context.Context {
+	return context.WithValue(ctx, deploySourceKey{}, deploySource{sourceType: sourceType, source: source})
+}
+
+// sourceFromContext retorna a origem informada via WithDeploySource ou, se ausente, a origem padrão
+func sourceFromContext(ctx context.Context, sourceType store.SourceType, source string) (store.SourceType, string) {
+	if src, ok := ctx.Value(deploySourceKey{}).(deploySource); ok {
+		return src.sourceType, src.source
+	}
+	return sourceType, source
+}
+
+// SetRecorder define onde os deploys realizados pelo cliente serão registrados
+func (c *Client) SetRecorder(recorder DeployRecorder) {
+	c.recorder = recorder
+}
+
+// deployTotals informa a quantidade e o tamanho dos arquivos de um deploy
+type deployTotals interface {
+	totals() (int, int64)
+}
+
+// recordDeploy registra um deploy recém-criado no histórico.
+// A origem informada via WithDeploySource tem prioridade sobre a origem padrão.
+func (c *Client) recordDeploy(ctx context.Context, site *models.Site, deploy *models.Deploy, observer deployTotals, startedAt time.Time, sourceType store.SourceType, source string) {
+	if c.recorder == nil {
+		return
+	}
+
+	sourceType, source = sourceFromContext(ctx, sourceType, source)
+
+	accountID := ""
+	if c.config != nil {
+		accountID = c.config.AccountID
+	}
+
+	files, bytes := observer.totals()
+	rec := &store.DeployRecord{
+		DeployID:   deploy.ID,
+		SiteID:     site.ID,
+		SiteName:   site.Name,
+		AccountID:  accountID,
+		SourceType: sourceType,
+		Source:     source,
+		FileCount:  files,
+		TotalBytes: bytes,
+		State:      deploy.State,
+		Draft:      deploy.Draft,
+		StartedAt:  startedAt,
+	}
+
+	if err := c.recorder.SaveDeploy(rec); err != nil {
+		slog.WarnContext(ctx, "Erro ao registrar deploy no histórico", "deploy_id", deploy.ID, "error", err)
+	}
+}
+
+// recordDeployState registra o estado final de um deploy no histórico
+func (c *Client) recordDeployState(ctx context.Context, deploy *models.Deploy) {
+	if c.recorder == nil {
+		return
+	}
+
+	if err := c.recorder.UpdateDeployState(deploy.ID, deploy.State, deploy.ErrorMessage, time.Now()); err != nil {
+		slog.WarnContext(ctx, "Erro ao atualizar deploy no histórico", "deploy_id", deploy.ID, "state", deploy.State, "error", err)
+	}
+}
@@ -0,0 +1,110 @@
+package netlify
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/kodestech/poc-netlify/internal/metrics"
+	"github.com/kodestech/poc-netlify/internal/store"
+	"github.com/netlify/open-api/go/models"
+)
+
+// idCollections são os recursos da API da Netlify cujo segmento seguinte no caminho é um ID
+var idCollections = map[string]bool{
+	"accounts":    true,
+	"builds":      true,
+	"deploy_keys": true,
+	"deploys":     true,
+	"dns_records": true,
+	"dns_zones":   true,
+	"forms":       true,
+	"functions":   true,
+	"hooks":       true,
+	"sites":       true,
+	"snippets":    true,
+	"submissions": true,
+}
+
+// metricsTransport contabiliza nas métricas cada chamada à API da Netlify, inclusive as novas tentativas
+type metricsTransport struct {
+	base     http.RoundTripper
+	basePath string
+}
+
+// RoundTrip executa a requisição e registra a operação, o status e a latência
+func (t *metricsTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	operation := req.Method + " " + operationPath(strings.TrimPrefix(req.URL.Path, t.basePath))
+	startedAt := time.Now()
+
+	resp, err := t.base.RoundTrip(req)
+
+	status := "error"
+	if err == nil {
+		status = strconv.Itoa(resp.StatusCode)
+	}
+	metrics.NetlifyRequests.WithLabelValues(operation, status).Inc()
+	metrics.NetlifyRequestDuration.WithLabelValues(operation).Observe(time.Since(startedAt).Seconds())
+	return resp, err
+}
+
+// operationPath substitui os IDs e os caminhos de arquivos de um caminho da API por marcadores
+// (ex: /deploys/abc/files/css/site.css -> /deploys/:id/files/:path), limitando os valores do rótulo
+func operationPath(path string) string {
+	segments := strings.Split(strings.Trim(path, "/"), "/")
+	for i := 1; i < len(segments); i++ {
+		switch {
+		case segments[i-1] == "files":
+			segments = append(segments[:i], ":path")
+			return "/" + strings.Join(segments, "/")
+		case idCollections[segments[i-1]]:
+			segments[i] = ":id"
+		}
+	}
+	return "/" + strings.Join(segments, "/")
+}
+
+// pendingDeploy é um deploy enviado à Netlify cujo resultado final ainda não foi registrado nas métricas
+type pendingDeploy struct {
+	source    string
+	startedAt time.Time
+}
+
+// observeDeploy registra nas métricas um deploy iniciado em startedAt, com a origem informada
+// via WithDeploySource (ou a origem padrão). Falhas no envio são registradas imediatamente;
+// deploys enviados com sucesso só são registrados quando WaitForDeploy obtém o estado final.
+func (c *Client) observeDeploy(ctx context.Context, sourceType store.SourceType, startedAt time.Time, deploy *models.Deploy, err error) {
+	sourceType, _ = sourceFromContext(ctx, sourceType, "")
+	if err != nil || deploy == nil {
+		metrics.ObserveDeploy(string(sourceType), startedAt, err)
+		return
+	}
+
+	c.pendingMu.Lock()
+	defer c.pendingMu.Unlock()
+	if c.pending == nil {
+		c.pending = make(map[string]pendingDeploy)
+	}
+	c.pending[deploy.ID] = pendingDeploy{source: string(sourceType), startedAt: startedAt}
+}
+
+// observeDeployResult registra nas métricas o resultado final de um deploy enviado por este cliente
+// (ready, error, rejected ou espera interrompida). Deploys de outros clientes são ignorados.
+func (c *Client) observeDeployResult(deployID string, err error) {
+	c.pendingMu.Lock()
+	pending, ok := c.pending[deployID]
+	delete(c.pending, deployID)
+	c.pendingMu.Unlock()
+
+	if ok {
+		metrics.ObserveDeploy(pending.source, pending.startedAt, err)
+	}
+}
+
+// observeUpload registra nas métricas um arquivo enviado para a Netlify
+func observeUpload(bytes int64) {
+	metrics.UploadedFiles.Inc()
+	metrics.UploadedBytes.Add(float64(bytes))
+}
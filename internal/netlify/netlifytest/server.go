@@ -0,0 +1,625 @@
+// Package netlifytest implementa um servidor falso da API da Netlify, para testar o cliente
+// e a API sem acesso à rede. Aponte NETLIFY_API_URL (ou config.Config.NetlifyAPIURL) para Server.APIURL().
+package netlifytest
+
+import (
+	"crypto/sha1"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/netlify/open-api/go/models"
+)
+
+// Server é um servidor HTTP que simula os endpoints da Netlify usados pelo cliente:
+// sites, deploys, upload de arquivos, domínios e certificados SSL
+type Server struct {
+	*httptest.Server
+
+	// Token, se definido, é o único token aceito no cabeçalho Authorization
+	Token string
+	// CertState é o estado dos certificados solicitados (padrão: issued)
+	CertState string
+	// DeploysPerPage, se definido, é o tamanho da página de deploys quando a requisição não informa per_page
+	DeploysPerPage int
+
+	mu          sync.Mutex
+	nextID      int
+	sites       map[string]*models.Site
+	deploys     map[string]*models.Deploy
+	siteDeploys map[string][]string
+	deployFiles map[string]map[string]string
+	blobs       map[string][]byte
+	certs       map[string]*models.SniCertificate
+	txtValues   map[string]string
+	failures    []Failure
+}
+
+// Failure é uma resposta de erro injetada com Server.Fail, para simular limites de requisição e instabilidade
+type Failure struct {
+	// Status é o código HTTP retornado (ex: 429, 503)
+	Status int
+	// RetryAfter, se definido, é enviado no cabeçalho Retry-After (em segundos)
+	RetryAfter int
+	// Method e PathPrefix, se definidos, restringem as requisições afetadas
+	Method     string
+	PathPrefix string
+}
+
+// NewServer inicia um servidor falso vazio. Chame Close ao final.
+func NewServer() *Server {
+	s := &Server{
+		CertState:   "issued",
+		sites:       make(map[string]*models.Site),
+		deploys:     make(map[string]*models.Deploy),
+		siteDeploys: make(map[string][]string),
+		deployFiles: make(map[string]map[string]string),
+		blobs:       make(map[string][]byte),
+		certs:       make(map[string]*models.SniCertificate),
+		txtValues:   make(map[string]string),
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /api/v1/user", s.handleUser)
+	mux.HandleFunc("GET /api/v1/sites", s.handleListSites)
+	mux.HandleFunc("POST /api/v1/sites", s.handleCreateSite)
+	mux.HandleFunc("GET /api/v1/sites/{site_id}", s.handleGetSite)
+	mux.HandleFunc("PATCH /api/v1/sites/{site_id}", s.handleUpdateSite)
+	mux.HandleFunc("DELETE /api/v1/sites/{site_id}", s.handleDeleteSite)
+	mux.HandleFunc("GET /api/v1/sites/{site_id}/files", s.handleListSiteFiles)
+	mux.HandleFunc("GET /api/v1/sites/{site_id}/deploys", s.handleListSiteDeploys)
+	mux.HandleFunc("POST /api/v1/sites/{site_id}/deploys", s.handleCreateDeploy)
+	mux.HandleFunc("GET /api/v1/sites/{site_id}/deploys/{deploy_id}", s.handleGetDeploy)
+	mux.HandleFunc("POST /api/v1/sites/{site_id}/deploys/{deploy_id}/restore", s.handleRestoreDeploy)
+	mux.HandleFunc("GET /api/v1/deploys/{deploy_id}", s.handleGetDeploy)
+	mux.HandleFunc("PUT /api/v1/deploys/{deploy_id}/files/{path...}", s.handleUploadFile)
+	mux.HandleFunc("GET /api/v1/sites/{site_id}/ssl", s.handleGetCertificate)
+	mux.HandleFunc("POST /api/v1/sites/{site_id}/ssl", s.handleProvisionCertificate)
+
+	s.Server = httptest.NewServer(s.authenticate(mux))
+	return s
+}
+
+// APIURL retorna o endereço base da API falsa (equivalente a https://api.netlify.com/api/v1)
+func (s *Server) APIURL() string {
+	return s.URL + "/api/v1"
+}
+
+// AddSite cadastra um site diretamente no servidor, sem passar pela API
+func (s *Server) AddSite(name string) *models.Site {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.createSiteLocked(name)
+}
+
+// Site retorna uma cópia do site com o ID informado
+func (s *Server) Site(siteID string) (*models.Site, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	site, ok := s.sites[siteID]
+	if !ok {
+		return nil, false
+	}
+	snapshot := *site
+	return &snapshot, true
+}
+
+// Deploy retorna uma cópia do deploy com o ID informado
+func (s *Server) Deploy(deployID string) (*models.Deploy, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	deploy, ok := s.deploys[deployID]
+	if !ok {
+		return nil, false
+	}
+	snapshot := *deploy
+	return &snapshot, true
+}
+
+// SetDeployState força o estado de um deploy (ex: error, rejected), para simular falhas de processamento
+func (s *Server) SetDeployState(deployID, state, errorMessage string) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	deploy, ok := s.deploys[deployID]
+	if !ok {
+		return false
+	}
+	deploy.State = state
+	deploy.ErrorMessage = errorMessage
+	deploy.UpdatedAt = now()
+	return true
+}
+
+// Files retorna o conteúdo publicado em um deploy, indexado pelo caminho do arquivo
+func (s *Server) Files(deployID string) map[string][]byte {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	files := make(map[string][]byte)
+	for path, sum := range s.deployFiles[deployID] {
+		if data, ok := s.blobs[sum]; ok {
+			files[path] = data
+		}
+	}
+	return files
+}
+
+// TXTValue retorna o último record_txt_value enviado para o site
+func (s *Server) TXTValue(siteID string) string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.txtValues[siteID]
+}
+
+// Fail enfileira respostas de erro; cada uma é usada uma única vez, pela próxima requisição compatível
+func (s *Server) Fail(failures ...Failure) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.failures = append(s.failures, failures...)
+}
+
+// authenticate rejeita requisições sem o token esperado e aplica as falhas injetadas com Fail
+func (s *Server) authenticate(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if s.Token != "" && r.Header.Get("Authorization") != "Bearer "+s.Token {
+			writeError(w, http.StatusUnauthorized, "Access Denied")
+			return
+		}
+		if f, ok := s.takeFailure(r); ok {
+			if f.RetryAfter > 0 {
+				w.Header().Set("Retry-After", strconv.Itoa(f.RetryAfter))
+			}
+			if f.Status == http.StatusTooManyRequests {
+				w.Header().Set("X-RateLimit-Remaining", "0")
+			}
+			writeError(w, f.Status, http.StatusText(f.Status))
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// takeFailure remove e retorna a primeira falha injetada compatível com a requisição
+func (s *Server) takeFailure(r *http.Request) (Failure, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for i, f := range s.failures {
+		if f.Method != "" && f.Method != r.Method {
+			continue
+		}
+		if f.PathPrefix != "" && !strings.HasPrefix(r.URL.Path, f.PathPrefix) {
+			continue
+		}
+		s.failures = append(s.failures[:i], s.failures[i+1:]...)
+		return f, true
+	}
+	return Failure{}, false
+}
+
+func (s *Server) handleUser(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, http.StatusOK, map[string]string{"id": "user-1", "email": "teste@exemplo.com"})
+}
+
+func (s *Server) handleListSites(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	sites := make([]*models.Site, 0, len(s.sites))
+	for _, site := range s.sites {
+		sites = append(sites, site)
+	}
+	sort.Slice(sites, func(i, j int) bool { return sites[i].CreatedAt < sites[j].CreatedAt })
+	writeJSON(w, http.StatusOK, sites)
+}
+
+func (s *Server) handleCreateSite(w http.ResponseWriter, r *http.Request) {
+	var setup models.SiteSetup
+	if err := json.NewDecoder(r.Body).Decode(&setup); err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if setup.Name != "" && s.siteByNameLocked(setup.Name) != nil {
+		writeError(w, http.StatusUnprocessableEntity, "subdomain must be unique")
+		return
+	}
+	site := s.createSiteLocked(setup.Name)
+	site.CustomDomain = setup.CustomDomain
+	site.DomainAliases = setup.DomainAliases
+	writeJSON(w, http.StatusCreated, site)
+}
+
+func (s *Server) handleGetSite(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	site, ok := s.sites[r.PathValue("site_id")]
+	if !ok {
+		writeError(w, http.StatusNotFound, "Not Found")
+		return
+	}
+	writeJSON(w, http.StatusOK, site)
+}
+
+func (s *Server) handleUpdateSite(w http.ResponseWriter, r *http.Request) {
+	var fields map[string]json.RawMessage
+	if err := json.NewDecoder(r.Body).Decode(&fields); err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	site, ok := s.sites[r.PathValue("site_id")]
+	if !ok {
+		writeError(w, http.StatusNotFound, "Not Found")
+		return
+	}
+
+	// Aplicar apenas os campos enviados, como no PATCH da Netlify
+	var name string
+	if raw, ok := fields["name"]; ok && json.Unmarshal(raw, &name) == nil && name != "" && name != site.Name {
+		if s.siteByNameLocked(name) != nil {
+			writeError(w, http.StatusUnprocessableEntity, "subdomain must be unique")
+			return
+		}
+		site.Name = name
+		site.URL = "http://" + name + ".netlify.app"
+		site.SslURL = "https://" + name + ".netlify.app"
+	}
+	if raw, ok := fields["custom_domain"]; ok {
+		_ = json.Unmarshal(raw, &site.CustomDomain)
+	}
+	if raw, ok := fields["domain_aliases"]; ok {
+		site.DomainAliases = nil
+		_ = json.Unmarshal(raw, &site.DomainAliases)
+	}
+	if raw, ok := fields["record_txt_value"]; ok {
+		var value string
+		_ = json.Unmarshal(raw, &value)
+		s.txtValues[site.ID] = value
+	}
+	site.UpdatedAt = now()
+	writeJSON(w, http.StatusOK, site)
+}
+
+func (s *Server) handleDeleteSite(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	siteID := r.PathValue("site_id")
+	if _, ok := s.sites[siteID]; !ok {
+		writeError(w, http.StatusNotFound, "Not Found")
+		return
+	}
+	delete(s.sites, siteID)
+	delete(s.certs, siteID)
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (s *Server) handleListSiteDeploys(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	siteID := r.PathValue("site_id")
+	if _, ok := s.sites[siteID]; !ok {
+		writeError(w, http.StatusNotFound, "Not Found")
+		return
+	}
+
+	// Os deploys mais recentes primeiro, paginados como na Netlify
+	ids := s.siteDeploys[siteID]
+	deploys := make([]*models.Deploy, 0, len(ids))
+	for i := len(ids) - 1; i >= 0; i-- {
+		deploys = append(deploys, s.deploys[ids[i]])
+	}
+
+	page, _ := strconv.Atoi(r.URL.Query().Get("page"))
+	perPage, _ := strconv.Atoi(r.URL.Query().Get("per_page"))
+	if page <= 0 {
+		page = 1
+	}
+	if perPage <= 0 {
+		perPage = s.DeploysPerPage
+	}
+	if perPage > 0 {
+		start := (page - 1) * perPage
+		if start > len(deploys) {
+			start = len(deploys)
+		}
+		end := start + perPage
+		if end > len(deploys) {
+			end = len(deploys)
+		}
+		deploys = deploys[start:end]
+	}
+	writeJSON(w, http.StatusOK, deploys)
+}
+
+func (s *Server) handleListSiteFiles(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	site, ok := s.sites[r.PathValue("site_id")]
+	if !ok {
+		writeError(w, http.StatusNotFound, "Not Found")
+		return
+	}
+
+	// Os arquivos do deploy publicado, ordenados pelo caminho
+	files := []*models.File{}
+	if site.PublishedDeploy != nil {
+		for path, sum := range s.deployFiles[site.PublishedDeploy.ID] {
+			files = append(files, &models.File{ID: "/" + path, Path: "/" + path, Sha: sum, Size: int64(len(s.blobs[sum]))})
+		}
+	}
+	sort.Slice(files, func(i, j int) bool { return files[i].Path < files[j].Path })
+	writeJSON(w, http.StatusOK, files)
+}
+
+func (s *Server) handleCreateDeploy(w http.ResponseWriter, r *http.Request) {
+	var files models.DeployFiles
+	if err := json.NewDecoder(r.Body).Decode(&files); err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	site, ok := s.sites[r.PathValue("site_id")]
+	if !ok {
+		writeError(w, http.StatusNotFound, "Not Found")
+		return
+	}
+
+	sums := make(map[string]string)
+	if m, ok := files.Files.(map[string]interface{}); ok {
+		for path, sum := range m {
+			if str, ok := sum.(string); ok {
+				sums[strings.TrimPrefix(path, "/")] = str
+			}
+		}
+	}
+
+	// Solicitar apenas os arquivos cujo conteúdo o servidor ainda não possui
+	required := []string{}
+	seen := make(map[string]bool)
+	for _, sum := range sums {
+		if _, ok := s.blobs[sum]; !ok && !seen[sum] {
+			required = append(required, sum)
+			seen[sum] = true
+		}
+	}
+	sort.Strings(required)
+
+	s.nextID++
+	deploy := &models.Deploy{
+		ID:        fmt.Sprintf("deploy-%d", s.nextID),
+		SiteID:    site.ID,
+		Name:      site.Name,
+		State:     "prepared",
+		Required:  required,
+		Draft:     files.Draft,
+		Context:   "production",
+		Title:     r.URL.Query().Get("title"),
+		CreatedAt: now(),
+		UpdatedAt: now(),
+	}
+	deploy.DeployURL = fmt.Sprintf("http://%s--%s.netlify.app", deploy.ID, site.Name)
+	deploy.DeploySslURL = fmt.Sprintf("https://%s--%s.netlify.app", deploy.ID, site.Name)
+	deploy.URL = site.URL
+	deploy.SslURL = site.SslURL
+	if files.Draft {
+		deploy.Context = "deploy-preview"
+	}
+
+	s.deploys[deploy.ID] = deploy
+	s.siteDeploys[site.ID] = append(s.siteDeploys[site.ID], deploy.ID)
+	s.deployFiles[deploy.ID] = sums
+
+	if len(required) == 0 {
+		s.finishDeployLocked(deploy)
+	}
+	writeJSON(w, http.StatusOK, deploy)
+}
+
+func (s *Server) handleGetDeploy(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	deploy, ok := s.deploys[r.PathValue("deploy_id")]
+	if !ok || (r.PathValue("site_id") != "" && deploy.SiteID != r.PathValue("site_id")) {
+		writeError(w, http.StatusNotFound, "Not Found")
+		return
+	}
+	writeJSON(w, http.StatusOK, deploy)
+}
+
+func (s *Server) handleUploadFile(w http.ResponseWriter, r *http.Request) {
+	data, err := io.ReadAll(r.Body)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	deploy, ok := s.deploys[r.PathValue("deploy_id")]
+	if !ok {
+		writeError(w, http.StatusNotFound, "Not Found")
+		return
+	}
+
+	path := strings.TrimPrefix(r.PathValue("path"), "/")
+	expected, ok := s.deployFiles[deploy.ID][path]
+	if !ok {
+		writeError(w, http.StatusUnprocessableEntity, "file not part of the deploy: "+path)
+		return
+	}
+
+	hash := sha1.Sum(data)
+	sum := hex.EncodeToString(hash[:])
+	if sum != expected {
+		writeError(w, http.StatusUnprocessableEntity, "checksum mismatch for "+path)
+		return
+	}
+	s.blobs[sum] = data
+
+	remaining := deploy.Required[:0]
+	for _, required := range deploy.Required {
+		if required != sum {
+			remaining = append(remaining, required)
+		}
+	}
+	deploy.Required = remaining
+	deploy.State = "uploading"
+	deploy.UpdatedAt = now()
+
+	if len(deploy.Required) == 0 {
+		s.finishDeployLocked(deploy)
+	}
+	writeJSON(w, http.StatusOK, map[string]interface{}{"id": sum, "path": "/" + path, "sha": sum, "size": len(data)})
+}
+
+func (s *Server) handleRestoreDeploy(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	site, ok := s.sites[r.PathValue("site_id")]
+	deploy, found := s.deploys[r.PathValue("deploy_id")]
+	if !ok || !found || deploy.SiteID != site.ID {
+		writeError(w, http.StatusNotFound, "Not Found")
+		return
+	}
+	if deploy.State != "ready" {
+		writeError(w, http.StatusUnprocessableEntity, "deploy is not ready")
+		return
+	}
+
+	s.publishLocked(site, deploy)
+	writeJSON(w, http.StatusCreated, deploy)
+}
+
+func (s *Server) handleGetCertificate(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	cert, ok := s.certs[r.PathValue("site_id")]
+	if !ok {
+		writeError(w, http.StatusNotFound, "Not Found")
+		return
+	}
+	writeJSON(w, http.StatusOK, cert)
+}
+
+func (s *Server) handleProvisionCertificate(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	site, ok := s.sites[r.PathValue("site_id")]
+	if !ok {
+		writeError(w, http.StatusNotFound, "Not Found")
+		return
+	}
+
+	domains := []string{}
+	if site.CustomDomain != "" {
+		domains = append(domains, site.CustomDomain)
+	}
+	domains = append(domains, site.DomainAliases...)
+	if len(domains) == 0 {
+		writeError(w, http.StatusUnprocessableEntity, "site has no custom domains")
+		return
+	}
+
+	cert := &models.SniCertificate{
+		Domains:   domains,
+		State:     s.CertState,
+		CreatedAt: now(),
+		UpdatedAt: now(),
+		ExpiresAt: time.Now().Add(90 * 24 * time.Hour).UTC().Format(time.RFC3339),
+	}
+	s.certs[site.ID] = cert
+	site.Ssl = cert.State == "issued"
+	writeJSON(w, http.StatusOK, cert)
+}
+
+// createSiteLocked cria um site com ID sequencial; gera um nome se nenhum for informado
+func (s *Server) createSiteLocked(name string) *models.Site {
+	s.nextID++
+	id := fmt.Sprintf("site-%d", s.nextID)
+	if name == "" {
+		name = id
+	}
+
+	site := &models.Site{
+		ID:        id,
+		Name:      name,
+		State:     "current",
+		URL:       "http://" + name + ".netlify.app",
+		SslURL:    "https://" + name + ".netlify.app",
+		AdminURL:  "https://app.netlify.com/sites/" + name,
+		CreatedAt: now(),
+		UpdatedAt: now(),
+	}
+	s.sites[id] = site
+	return site
+}
+
+// siteByNameLocked procura um site pelo nome
+func (s *Server) siteByNameLocked(name string) *models.Site {
+	for _, site := range s.sites {
+		if site.Name == name {
+			return site
+		}
+	}
+	return nil
+}
+
+// finishDeployLocked conclui o deploy e o publica, exceto rascunhos
+func (s *Server) finishDeployLocked(deploy *models.Deploy) {
+	deploy.State = "ready"
+	deploy.UpdatedAt = now()
+	if deploy.Draft {
+		return
+	}
+	if site, ok := s.sites[deploy.SiteID]; ok {
+		s.publishLocked(site, deploy)
+	}
+}
+
+// publishLocked define o deploy como o publicado do site
+func (s *Server) publishLocked(site *models.Site, deploy *models.Deploy) {
+	deploy.PublishedAt = now()
+	published := *deploy
+	site.PublishedDeploy = &published
+	site.UpdatedAt = now()
+}
+
+func now() string {
+	return time.Now().UTC().Format(time.RFC3339Nano)
+}
+
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(v)
+}
+
+func writeError(w http.ResponseWriter, status int, message string) {
+	writeJSON(w, status, map[string]interface{}{"code": status, "message": message})
+}
@@ -0,0 +1,140 @@
+package netlify
+
+import (
+	"context"
+	"os"
+	"sync"
+
+	"github.com/netlify/open-api/go/models"
+	"github.com/netlify/open-api/go/porcelain"
+)
+
+// DeployPhase identifica a fase em que um deploy se encontra
+type DeployPhase string
+
+const (
+	PhaseDownloading DeployPhase = "downloading"
+	PhaseUploading   DeployPhase = "uploading"
+	PhaseProcessing  DeployPhase = "processing"
+	PhaseReady       DeployPhase = "ready"
+	PhaseError       DeployPhase = "error"
+)
+
+// DeployProgress recebe notificações sobre o andamento de um deploy
+type DeployProgress interface {
+	// SetPhase informa a mudança de fase do deploy
+	SetPhase(phase DeployPhase)
+	// SetUploadTotal informa quantos arquivos (e bytes) a Netlify solicitou para upload
+	SetUploadTotal(files int, bytes int64)
+	// AddUploaded informa que um arquivo foi enviado com sucesso
+	AddUploaded(name string, bytes int64)
+}
+
+// DeployEvents é implementado opcionalmente por um DeployProgress que também quer eventos por arquivo
+type DeployEvents interface {
+	// FileHashed informa que o digest de um arquivo foi calculado (hashed de total; total 0 se ainda desconhecido)
+	FileHashed(name string, hashed, total int)
+}
+
+type progressKey struct{}
+
+// WithProgress retorna um contexto que reporta o andamento dos deploys para progress
+func WithProgress(ctx context.Context, progress DeployProgress) context.Context {
+	return context.WithValue(ctx, progressKey{}, progress)
+}
+
+// progressFromContext obtém o DeployProgress do contexto, ou um que descarta as notificações
+func progressFromContext(ctx context.Context) DeployProgress {
+	if progress, ok := ctx.Value(progressKey{}).(DeployProgress); ok && progress != nil {
+		return progress
+	}
+	return noopProgress{}
+}
+
+type noopProgress struct{}
+
+func (noopProgress) SetPhase(DeployPhase)      {}
+func (noopProgress) SetUploadTotal(int, int64) {}
+func (noopProgress) AddUploaded(string, int64) {}
+
+// progressObserver adapta um DeployProgress à interface porcelain.DeployObserver
+type progressObserver struct {
+	progress DeployProgress
+
+	mu    sync.Mutex
+	sizes map[string][]int64
+	files int
+	bytes int64
+}
+
+func newProgressObserver(ctx context.Context) *progressObserver {
+	return &progressObserver{
+		progress: progressFromContext(ctx),
+		sizes:    make(map[string][]int64),
+	}
+}
+
+func (o *progressObserver) OnSetupWalk() error {
+	o.progress.SetPhase(PhaseUploading)
+	return nil
+}
+
+func (o *progressObserver) OnSuccessfulStep(f *porcelain.FileBundle) error {
+	var size int64
+	if info, err := os.Stat(f.Path); err == nil {
+		size = info.Size()
+	}
+
+	o.mu.Lock()
+	o.sizes[f.Sum] = append(o.sizes[f.Sum], size)
+	o.files++
+	o.bytes += size
+	hashed := o.files
+	o.mu.Unlock()
+
+	if events, ok := o.progress.(DeployEvents); ok {
+		events.FileHashed(f.Name, hashed, 0)
+	}
+	return nil
+}
+
+// totals retorna a quantidade e o tamanho total dos arquivos percorridos no deploy
+func (o *progressObserver) totals() (int, int64) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	return o.files, o.bytes
+}
+
+func (o *progressObserver) OnSuccessfulWalk(*models.DeployFiles) error { return nil }
+func (o *progressObserver) OnFailedWalk()                              {}
+func (o *progressObserver) OnSetupDelta(*models.DeployFiles) error     { return nil }
+func (o *progressObserver) OnFailedDelta(*models.DeployFiles)          {}
+
+func (o *progressObserver) OnSuccessfulDelta(_ *models.DeployFiles, deploy *models.Deploy) error {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	files := 0
+	var bytes int64
+	for _, sum := range deploy.Required {
+		for _, size := range o.sizes[sum] {
+			files++
+			bytes += size
+		}
+	}
+	o.progress.SetUploadTotal(files, bytes)
+	return nil
+}
+
+func (o *progressObserver) OnSetupUpload(*porcelain.FileBundle) error { return nil }
+func (o *progressObserver) OnFailedUpload(*porcelain.FileBundle)      {}
+
+func (o *progressObserver) OnSuccessfulUpload(f *porcelain.FileBundle) error {
+	var size int64
+	if info, err := os.Stat(f.Path); err == nil {
+		size = info.Size()
+	}
+	observeUpload(size)
+	o.progress.AddUploaded(f.Name, size)
+	return nil
+}
@@ -0,0 +1,218 @@
+package netlify
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/kodestech/poc-netlify/internal/logging"
+	"github.com/kodestech/poc-netlify/internal/store"
+	"github.com/kodestech/poc-netlify/internal/tracing"
+	"github.com/netlify/open-api/go/models"
+	"github.com/netlify/open-api/go/plumbing/operations"
+)
+
+const (
+	// remoteUploadConcurrency limita quantos arquivos remotos são enviados ao mesmo tempo
+	remoteUploadConcurrency = 8
+	// remoteAsyncFileLimit define a partir de quantos arquivos o manifesto é processado de forma assíncrona
+	remoteAsyncFileLimit = 7000
+	// remotePrepareTimeout limita a espera pelo processamento assíncrono do manifesto
+	remotePrepareTimeout = 5 * time.Minute
+)
+
+// RemoteFile descreve um arquivo de deploy cujo conteúdo é lido sob demanda
+type RemoteFile struct {
+	// Path é o caminho do arquivo no site, com separadores "/" (ex: img/logo.png)
+	Path string
+	// SHA1 é o digest hexadecimal do conteúdo do arquivo
+	SHA1 string
+	// Size é o tamanho do arquivo em bytes
+	Size int64
+}
+
+// FileOpener abre o conteúdo de um arquivo remoto para envio à Netlify
+type FileOpener func(ctx context.Context, file RemoteFile) (io.ReadCloser, error)
+
+// remoteTotals guarda os totais de um deploy de arquivos remotos
+type remoteTotals struct {
+	files int
+	bytes int64
+}
+
+func (t remoteTotals) totals() (int, int64) {
+	return t.files, t.bytes
+}
+
+// DeployRemoteFiles realiza o deploy a partir de um manifesto de arquivos remotos.
+// Apenas os arquivos que a Netlify informar como ausentes são abertos e enviados.
+func (c *Client) DeployRemoteFiles(ctx context.Context, site *models.Site, files []RemoteFile, open FileOpener) (deploy *models.Deploy, err error) {
+	ctx, span := tracing.Start(ctx, "netlify.DeployRemoteFiles", tracing.SiteID.String(site.ID), tracing.SiteName.String(site.Name), tracing.Files.Int(len(files)))
+	defer func() { tracing.End(span, err) }()
+
+	ctx = logging.With(ctx, "site_id", site.ID)
+	slog.InfoContext(ctx, "Iniciando deploy de arquivos remotos", "site_name", site.Name, "files", len(files))
+
+	if len(files) == 0 {
+		return nil, fmt.Errorf("nenhum arquivo informado para deploy")
+	}
+
+	startedAt := time.Now()
+	defer func() { c.observeDeploy(ctx, store.SourceS3, startedAt, deploy, err) }()
+	progress := progressFromContext(ctx)
+	progress.SetPhase(PhaseUploading)
+
+	// Montar o manifesto do deploy e agrupar os arquivos pelo digest
+	sums := make(map[string]string, len(files))
+	bySum := make(map[string][]RemoteFile)
+	total := remoteTotals{}
+	for _, f := range files {
+		if strings.ContainsAny(f.Path, "#?") {
+			return nil, fmt.Errorf("nome de arquivo inválido '%s': não pode conter # ou ?", f.Path)
+		}
+		sums[f.Path] = f.SHA1
+		bySum[f.SHA1] = append(bySum[f.SHA1], f)
+		total.files++
+		total.bytes += f.Size
+	}
+
+	// Criar o deploy informando o manifesto
+	title := fmt.Sprintf("Deploy automático para %s", site.Name)
+	deployFiles := &models.DeployFiles{
+		Files: sums,
+		Async: len(files) > remoteAsyncFileLimit,
+		Draft: isDraft(ctx),
+	}
+	params := operations.NewCreateSiteDeployParams().WithContext(ctx).WithSiteID(site.ID).WithDeploy(deployFiles).WithTitle(&title)
+	resp, err := c.netlify.Operations.CreateSiteDeploy(params, c.auth)
+	if err != nil {
+		return nil, fmt.Errorf("erro ao criar deploy: %w", err)
+	}
+	deploy = resp.Payload
+	ctx = logging.With(ctx, "deploy_id", deploy.ID)
+	tracing.SetAttributes(ctx, tracing.DeployID.String(deploy.ID))
+
+	if deployFiles.Async {
+		deploy, err = c.waitForPrepared(ctx, deploy)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// Selecionar apenas os arquivos solicitados pela Netlify
+	var required []RemoteFile
+	var requiredBytes int64
+	for _, sum := range deploy.Required {
+		for _, f := range bySum[sum] {
+			required = append(required, f)
+			requiredBytes += f.Size
+		}
+	}
+	progress.SetUploadTotal(len(required), requiredBytes)
+	slog.InfoContext(ctx, "Arquivos solicitados pela Netlify", "required", len(required), "files", len(files))
+
+	if err := c.uploadRemoteFiles(ctx, deploy, required, open, progress); err != nil {
+		return nil, err
+	}
+
+	slog.InfoContext(ctx, "Deploy de arquivos remotos iniciado com sucesso")
+	c.recordDeploy(ctx, site, deploy, total, startedAt, store.SourceS3, "")
+	return deploy, nil
+}
+
+// uploadRemoteFiles envia os arquivos remotos em paralelo, interrompendo no primeiro erro
+func (c *Client) uploadRemoteFiles(ctx context.Context, deploy *models.Deploy, files []RemoteFile, open FileOpener, progress DeployProgress) error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	var wg sync.WaitGroup
+	var once sync.Once
+	var uploadErr error
+	sem := make(chan struct{}, remoteUploadConcurrency)
+
+	for _, f := range files {
+		if ctx.Err() != nil {
+			break
+		}
+
+		wg.Add(1)
+		sem <- struct{}{}
+		go func(f RemoteFile) {
+			defer wg.Done()
+			defer func() { <-sem }()
+
+			if err := c.uploadRemoteFile(ctx, deploy, f, open); err != nil {
+				once.Do(func() {
+					uploadErr = err
+					cancel()
+				})
+				return
+			}
+			observeUpload(f.Size)
+			progress.AddUploaded(f.Path, f.Size)
+		}(f)
+	}
+	wg.Wait()
+
+	return uploadErr
+}
+
+// uploadRemoteFile abre um arquivo remoto e o envia para o deploy sem gravá-lo em disco,
+// reabrindo-o quando a Netlify pede uma nova tentativa
+func (c *Client) uploadRemoteFile(ctx context.Context, deploy *models.Deploy, f RemoteFile, open FileOpener) error {
+	err := c.retryUpload(ctx, f.Path, func() error {
+		body, err := open(ctx, f)
+		if err != nil {
+			return fmt.Errorf("erro ao abrir arquivo %s: %w", f.Path, err)
+		}
+		defer body.Close()
+
+		size := f.Size
+		params := operations.NewUploadDeployFileParams().WithContext(ctx).WithDeployID(deploy.ID).WithPath(f.Path).WithFileBody(body).WithSize(&size)
+		if _, err := c.netlify.Operations.UploadDeployFile(params, c.auth); err != nil {
+			return fmt.Errorf("erro ao enviar arquivo %s: %w", f.Path, err)
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	slog.DebugContext(ctx, "Arquivo enviado", "path", f.Path, "bytes", f.Size)
+	return nil
+}
+
+// waitForPrepared aguarda a Netlify processar um manifesto enviado de forma assíncrona
+func (c *Client) waitForPrepared(ctx context.Context, deploy *models.Deploy) (*models.Deploy, error) {
+	slog.InfoContext(ctx, "Aguardando processamento do manifesto do deploy")
+
+	deadline := time.Now().Add(remotePrepareTimeout)
+	for {
+		params := operations.NewGetSiteDeployParams().WithContext(ctx).WithSiteID(deploy.SiteID).WithDeployID(deploy.ID)
+		resp, err := c.netlify.Operations.GetSiteDeploy(params, c.auth)
+		if err != nil {
+			return nil, fmt.Errorf("erro ao verificar status do deploy: %w", err)
+		}
+
+		switch resp.Payload.State {
+		case "prepared", "ready":
+			return resp.Payload, nil
+		case "error":
+			return nil, fmt.Errorf("erro no processamento do deploy: %s", resp.Payload.ErrorMessage)
+		}
+
+		if time.Now().After(deadline) {
+			return nil, fmt.Errorf("tempo esgotado aguardando processamento do deploy %s", deploy.ID)
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(2 * time.Second):
+		}
+	}
+}
@@ -0,0 +1,312 @@
+package netlify
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"math/rand/v2"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// ErrRateLimited indica que a Netlify continuou limitando as requisições após todas as tentativas
+var ErrRateLimited = errors.New("limite de requisições da API da Netlify atingido")
+
+// ErrUnavailable indica que a Netlify continuou respondendo com erro temporário após todas as tentativas
+var ErrUnavailable = errors.New("API da Netlify temporariamente indisponível")
+
+// RetryPolicy define como as chamadas à API da Netlify são repetidas em caso de erro temporário
+type RetryPolicy struct {
+	// MaxRetries é o número máximo de novas tentativas (0 desativa as repetições)
+	MaxRetries int
+	// BaseDelay é a espera antes da primeira nova tentativa; dobra a cada tentativa
+	BaseDelay time.Duration
+	// MaxDelay limita a espera entre tentativas, inclusive a pedida pela Netlify
+	MaxDelay time.Duration
+}
+
+// DefaultRetryPolicy é a política usada quando nenhuma outra é informada
+var DefaultRetryPolicy = RetryPolicy{
+	MaxRetries: 4,
+	BaseDelay:  500 * time.Millisecond,
+	MaxDelay:   30 * time.Second,
+}
+
+// WithRetryPolicy define a política de novas tentativas do cliente
+func WithRetryPolicy(policy RetryPolicy) ClientOption {
+	return func(o *clientOptions) {
+		o.retryPolicy = &policy
+	}
+}
+
+// RetryStats resume as novas tentativas feitas pelo cliente
+type RetryStats struct {
+	Retries   int    `json:"retries" example:"2" swagger:"description=Quantidade de novas tentativas feitas nas chamadas à Netlify"`
+	LastError string `json:"last_error,omitempty" example:"status 429" swagger:"description=Último erro que provocou uma nova tentativa"`
+}
+
+// RetryError é retornado quando uma chamada continua falhando com erro temporário
+type RetryError struct {
+	// Err é ErrRateLimited ou ErrUnavailable
+	Err error
+	// Status é o último status HTTP recebido
+	Status int
+	// Attempts é o total de tentativas feitas
+	Attempts int
+	// RetryAfter é a espera sugerida pela Netlify antes de tentar novamente
+	RetryAfter time.Duration
+}
+
+// Error implementa a interface error
+func (e *RetryError) Error() string {
+	msg := fmt.Sprintf("%v (status %d após %d tentativas)", e.Err, e.Status, e.Attempts)
+	if e.RetryAfter > 0 {
+		msg += fmt.Sprintf(", tente novamente em %s", e.RetryAfter.Round(time.Second))
+	}
+	return msg
+}
+
+// Unwrap permite comparar o erro com ErrRateLimited e ErrUnavailable
+func (e *RetryError) Unwrap() error {
+	return e.Err
+}
+
+// Code retorna o último status HTTP, como nos erros gerados pelo porcelain
+func (e *RetryError) Code() int {
+	return e.Status
+}
+
+// retryTransport repete as requisições que falham com erro temporário (429, 5xx ou falha de rede)
+type retryTransport struct {
+	base   http.RoundTripper
+	policy RetryPolicy
+
+	mu    sync.Mutex
+	stats RetryStats
+}
+
+// RoundTrip executa a requisição, repetindo-a enquanto a política permitir.
+// Requisições com corpo que não pode ser relido (uploads em streaming) não são repetidas aqui;
+// o erro retornado (RetryError) permite que o chamador reabra o arquivo e tente novamente.
+func (t *retryTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	replayable := req.Body == nil || req.Body == http.NoBody || req.GetBody != nil
+
+	for attempt := 0; ; attempt++ {
+		if attempt > 0 && req.GetBody != nil {
+			body, err := req.GetBody()
+			if err != nil {
+				return nil, err
+			}
+			req.Body = body
+		}
+
+		resp, err := t.base.RoundTrip(req)
+
+		retryable, wait := t.classify(req, resp, err, attempt)
+		if !retryable {
+			return resp, err
+		}
+
+		cause := retryCause(resp, err)
+		if !replayable || attempt >= t.policy.MaxRetries {
+			if err != nil {
+				return nil, err
+			}
+			if !replayable {
+				// O chamador reabre o corpo e repete a operação (ver retryUpload)
+				t.record(cause)
+			}
+			drain(resp)
+			return nil, &RetryError{
+				Err:        retryErrorKind(resp.StatusCode),
+				Status:     resp.StatusCode,
+				Attempts:   attempt + 1,
+				RetryAfter: retryAfter(resp, time.Now()),
+			}
+		}
+
+		if resp != nil {
+			drain(resp)
+		}
+		t.record(cause)
+		slog.WarnContext(req.Context(), "Chamada à Netlify falhou, nova tentativa",
+			"method", req.Method,
+			"path", req.URL.Path,
+			"cause", cause,
+			"attempt", attempt+1,
+			"max_retries", t.policy.MaxRetries,
+			"wait", wait.Round(time.Millisecond),
+		)
+
+		timer := time.NewTimer(wait)
+		select {
+		case <-req.Context().Done():
+			timer.Stop()
+			return nil, req.Context().Err()
+		case <-timer.C:
+		}
+	}
+}
+
+// classify indica se a resposta deve ser repetida e quanto esperar antes da nova tentativa
+func (t *retryTransport) classify(req *http.Request, resp *http.Response, err error, attempt int) (bool, time.Duration) {
+	if err != nil {
+		// Falhas de rede só são repetidas em métodos idempotentes, pois a Netlify pode ter processado a requisição
+		if req.Context().Err() != nil || !idempotent(req.Method) {
+			return false, 0
+		}
+		return true, t.backoff(attempt)
+	}
+
+	switch resp.StatusCode {
+	case http.StatusTooManyRequests:
+		// A Netlify não processa requisições limitadas, então qualquer método pode ser repetido
+	case http.StatusInternalServerError, http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		if !idempotent(req.Method) {
+			return false, 0
+		}
+	default:
+		return false, 0
+	}
+
+	wait := t.backoff(attempt)
+	if after := retryAfter(resp, time.Now()); after > 0 {
+		wait = after
+	}
+	if wait > t.policy.MaxDelay {
+		wait = t.policy.MaxDelay
+	}
+	return true, wait
+}
+
+// backoff calcula a espera exponencial com jitter (entre metade e o total do intervalo)
+func (t *retryTransport) backoff(attempt int) time.Duration {
+	delay := t.policy.BaseDelay << attempt
+	if delay <= 0 || delay > t.policy.MaxDelay {
+		delay = t.policy.MaxDelay
+	}
+	half := delay / 2
+	if half <= 0 {
+		return delay
+	}
+	return half + rand.N(half+1)
+}
+
+// record contabiliza uma nova tentativa
+func (t *retryTransport) record(cause string) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.stats.Retries++
+	t.stats.LastError = cause
+}
+
+// snapshot retorna uma cópia das estatísticas de novas tentativas
+func (t *retryTransport) snapshot() RetryStats {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.stats
+}
+
+// RetryStats retorna quantas novas tentativas o cliente fez e o último erro que as provocou
+func (c *Client) RetryStats() RetryStats {
+	if c.retries == nil {
+		return RetryStats{}
+	}
+	return c.retries.snapshot()
+}
+
+// retryUpload repete o envio de um arquivo quando a Netlify responde com erro temporário.
+// O corpo do upload não pode ser relido pelo transporte, então upload deve reabrir o arquivo a cada tentativa.
+func (c *Client) retryUpload(ctx context.Context, name string, upload func() error) error {
+	policy := DefaultRetryPolicy
+	if c.retries != nil {
+		policy = c.retries.policy
+	}
+	backoff := &retryTransport{policy: policy}
+
+	for attempt := 0; ; attempt++ {
+		err := upload()
+
+		var retryErr *RetryError
+		if err == nil || !errors.As(err, &retryErr) || attempt >= policy.MaxRetries {
+			return err
+		}
+
+		wait := backoff.backoff(attempt)
+		if retryErr.RetryAfter > 0 {
+			wait = min(retryErr.RetryAfter, policy.MaxDelay)
+		}
+		slog.WarnContext(ctx, "Envio de arquivo para a Netlify falhou, nova tentativa",
+			"path", name,
+			"error", retryErr,
+			"attempt", attempt+1,
+			"max_retries", policy.MaxRetries,
+			"wait", wait.Round(time.Millisecond),
+		)
+
+		timer := time.NewTimer(wait)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return ctx.Err()
+		case <-timer.C:
+		}
+	}
+}
+
+// retryAfter lê a espera pedida pela Netlify em Retry-After (segundos ou data HTTP)
+// ou, na ausência dele, em X-RateLimit-Reset (timestamp Unix)
+func retryAfter(resp *http.Response, now time.Time) time.Duration {
+	if value := resp.Header.Get("Retry-After"); value != "" {
+		if seconds, err := strconv.Atoi(value); err == nil && seconds >= 0 {
+			return time.Duration(seconds) * time.Second
+		}
+		if at, err := http.ParseTime(value); err == nil && at.After(now) {
+			return at.Sub(now)
+		}
+	}
+
+	if resp.StatusCode == http.StatusTooManyRequests || resp.Header.Get("X-RateLimit-Remaining") == "0" {
+		if reset, err := strconv.ParseInt(resp.Header.Get("X-RateLimit-Reset"), 10, 64); err == nil {
+			if at := time.Unix(reset, 0); at.After(now) {
+				return at.Sub(now)
+			}
+		}
+	}
+	return 0
+}
+
+// retryCause descreve o motivo de uma nova tentativa
+func retryCause(resp *http.Response, err error) string {
+	if err != nil {
+		return err.Error()
+	}
+	return fmt.Sprintf("status %d", resp.StatusCode)
+}
+
+// retryErrorKind retorna o erro sentinela correspondente ao status
+func retryErrorKind(status int) error {
+	if status == http.StatusTooManyRequests {
+		return ErrRateLimited
+	}
+	return ErrUnavailable
+}
+
+// idempotent indica se repetir o método não produz efeitos duplicados
+func idempotent(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodPut, http.MethodDelete, http.MethodPatch:
+		return true
+	}
+	return false
+}
+
+// drain descarta e fecha o corpo da resposta para reaproveitar a conexão
+func drain(resp *http.Response) {
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 64<<10))
+	resp.Body.Close()
+}
@@ -0,0 +1,223 @@
+package netlify
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/kodestech/poc-netlify/internal/logging"
+	"github.com/kodestech/poc-netlify/internal/tracing"
+	"github.com/netlify/open-api/go/models"
+	"github.com/netlify/open-api/go/plumbing/operations"
+)
+
+// PreviousDeployTarget indica que o rollback deve usar o deploy publicado antes do atual
+const PreviousDeployTarget = "previous"
+
+var (
+	// ErrDeployNotReady indica que o deploy alvo não está no estado ready
+	ErrDeployNotReady = errors.New("deploy não está pronto para publicação")
+	// ErrNoPreviousDeploy indica que o site não possui um deploy anterior para restaurar
+	ErrNoPreviousDeploy = errors.New("nenhum deploy anterior disponível para rollback")
+)
+
+// GetDeploy obtém um deploy pelo ID
+func (c *Client) GetDeploy(ctx context.Context, deployID string) (deploy *models.Deploy, err error) {
+	ctx, span := tracing.Start(ctx, "netlify.GetDeploy", tracing.DeployID.String(deployID))
+	defer func() { tracing.End(span, err) }()
+
+	deploy, err = c.netlify.GetDeploy(c.createAuthContext(ctx), deployID)
+	if err != nil {
+		if isNotFound(err) {
+			return nil, fmt.Errorf("%w: %s", ErrDeployNotFound, deployID)
+		}
+		return nil, fmt.Errorf("erro ao obter deploy: %w", err)
+	}
+	return deploy, nil
+}
+
+// deploysPerPage é a quantidade de deploys pedida por página ao procurar o deploy anterior
+var deploysPerPage = 100
+
+// ListSiteDeploys lista a primeira página dos deploys de um site, do mais recente para o mais antigo
+func (c *Client) ListSiteDeploys(ctx context.Context, siteID string) (deploys []*models.Deploy, err error) {
+	return c.listSiteDeploys(ctx, siteID, 0, 0)
+}
+
+// listSiteDeploys lista uma página dos deploys de um site; page 0 usa a paginação padrão da Netlify
+func (c *Client) listSiteDeploys(ctx context.Context, siteID string, page, perPage int) (deploys []*models.Deploy, err error) {
+	ctx, span := tracing.Start(ctx, "netlify.ListSiteDeploys", tracing.SiteID.String(siteID))
+	defer func() { tracing.End(span, err) }()
+
+	slog.DebugContext(ctx, "Listando deploys do site", "site_id", siteID, "page", page)
+
+	params := operations.NewListSiteDeploysParams().WithContext(ctx).WithSiteID(siteID)
+	if page > 0 {
+		pageNumber, pageSize := int32(page), int32(perPage)
+		params = params.WithPage(&pageNumber).WithPerPage(&pageSize)
+	}
+	resp, err := c.netlify.Operations.ListSiteDeploys(params, c.auth)
+	if err != nil {
+		if isNotFound(err) {
+			return nil, fmt.Errorf("%w: %s", ErrSiteNotFound, siteID)
+		}
+		return nil, fmt.Errorf("erro ao listar deploys do site: %w", Classify(err))
+	}
+
+	slog.DebugContext(ctx, "Deploys do site listados", "site_id", siteID, "page", page, "deploys", len(resp.Payload))
+	return resp.Payload, nil
+}
+
+// PreviousDeploy retorna o último deploy pronto publicado antes do deploy atual do site.
+// Os deploys são lidos página a página até encontrá-lo.
+func (c *Client) PreviousDeploy(ctx context.Context, siteID string) (deploy *models.Deploy, err error) {
+	ctx, span := tracing.Start(ctx, "netlify.PreviousDeploy", tracing.SiteID.String(siteID))
+	defer func() { tracing.End(span, err) }()
+
+	site, err := c.netlify.GetSite(c.createAuthContext(ctx), siteID)
+	if err != nil {
+		if isNotFound(err) {
+			return nil, fmt.Errorf("%w: %s", ErrSiteNotFound, siteID)
+		}
+		return nil, fmt.Errorf("erro ao obter site: %w", Classify(err))
+	}
+
+	currentID := ""
+	if site.PublishedDeploy != nil {
+		currentID = site.PublishedDeploy.ID
+	}
+
+	// Os deploys vêm do mais recente para o mais antigo: procurar o primeiro
+	// deploy pronto (e que não seja rascunho) depois do deploy publicado atualmente
+	passedCurrent := currentID == ""
+	for page := 1; ; page++ {
+		deploys, err := c.listSiteDeploys(ctx, siteID, page, deploysPerPage)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, deploy := range deploys {
+			if deploy.ID == currentID {
+				passedCurrent = true
+				continue
+			}
+			if passedCurrent && deploy.State == "ready" && !deploy.Draft {
+				return deploy, nil
+			}
+		}
+
+		// Uma página incompleta é a última
+		if len(deploys) < deploysPerPage {
+			return nil, ErrNoPreviousDeploy
+		}
+	}
+}
+
+// RollbackDeploy publica novamente um deploy anterior do site e aguarda a publicação.
+// O deployID pode ser PreviousDeployTarget para restaurar o deploy publicado antes do atual.
+func (c *Client) RollbackDeploy(ctx context.Context, siteID, deployID string) (deploy *models.Deploy, err error) {
+	ctx, span := tracing.Start(ctx, "netlify.RollbackDeploy", tracing.SiteID.String(siteID), tracing.DeployID.String(deployID))
+	defer func() { tracing.End(span, err) }()
+
+	ctx = logging.With(ctx, "site_id", siteID)
+	slog.InfoContext(ctx, "Iniciando rollback", "target_deploy", deployID)
+
+	if siteID == "" {
+		return nil, fmt.Errorf("%w: ID do site não pode ser vazio", ErrInvalidInput)
+	}
+	if deployID == "" {
+		return nil, fmt.Errorf("%w: ID do deploy não pode ser vazio", ErrInvalidInput)
+	}
+
+	var target *models.Deploy
+	if deployID == PreviousDeployTarget {
+		target, err = c.PreviousDeploy(ctx, siteID)
+	} else {
+		target, err = c.GetDeploy(ctx, deployID)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return c.restoreDeploy(ctx, siteID, target)
+}
+
+// restoreDeploy valida que o deploy pertence ao site e está pronto, publica-o e aguarda a publicação
+func (c *Client) restoreDeploy(ctx context.Context, siteID string, target *models.Deploy) (*models.Deploy, error) {
+	// Validar o deploy alvo
+	if target.SiteID != "" && target.SiteID != siteID {
+		return nil, fmt.Errorf("%w: deploy %s não pertence ao site %s", ErrDeployNotFound, target.ID, siteID)
+	}
+	if target.State != "ready" {
+		return nil, fmt.Errorf("%w: deploy %s está no estado %s", ErrDeployNotReady, target.ID, target.State)
+	}
+
+	// Restaurar o deploy
+	params := operations.NewRestoreSiteDeployParams().WithContext(ctx).WithSiteID(siteID).WithDeployID(target.ID)
+	resp, err := c.netlify.Operations.RestoreSiteDeploy(params, c.auth)
+	if err != nil {
+		return nil, fmt.Errorf("erro ao restaurar deploy: %w", err)
+	}
+	slog.InfoContext(ctx, "Restauração do deploy solicitada", "deploy_id", target.ID)
+
+	// Aguardar a publicação do deploy restaurado
+	if _, err := c.WaitForPublish(ctx, siteID, target.ID); err != nil {
+		return nil, err
+	}
+
+	if resp.Payload != nil {
+		return resp.Payload, nil
+	}
+	return target, nil
+}
+
+// WaitForPublish aguarda até que o deploy informado seja o deploy publicado do site, com as mesmas
+// opções de intervalo, backoff e tempo máximo de WaitForDeploy (WithStateCallback é ignorada)
+func (c *Client) WaitForPublish(ctx context.Context, siteID, deployID string, opts ...WaitOption) (site *models.Site, err error) {
+	ctx, span := tracing.Start(ctx, "netlify.WaitForPublish", tracing.SiteID.String(siteID), tracing.DeployID.String(deployID))
+	defer func() { tracing.End(span, err) }()
+
+	options := c.resolveWaitOptions(opts)
+
+	ctx = logging.With(ctx, "site_id", siteID, "deploy_id", deployID)
+	slog.InfoContext(ctx, "Aguardando publicação do deploy")
+
+	if options.maxWait > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, options.maxWait)
+		defer cancel()
+	}
+
+	interval := options.pollInterval
+	for {
+		site, err := c.netlify.GetSite(c.createAuthContext(ctx), siteID)
+		if err != nil {
+			if ctx.Err() != nil {
+				return nil, waitTimeoutError(deployID, "publicando", ctx.Err())
+			}
+			return nil, fmt.Errorf("erro ao verificar deploy publicado: %w", err)
+		}
+
+		if site.PublishedDeploy != nil && site.PublishedDeploy.ID == deployID {
+			slog.InfoContext(ctx, "Deploy publicado", "site_name", site.Name)
+			return site, nil
+		}
+
+		slog.DebugContext(ctx, "Deploy ainda não publicado", "interval", interval)
+
+		timer := time.NewTimer(interval)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return nil, waitTimeoutError(deployID, "publicando", ctx.Err())
+		case <-timer.C:
+		}
+
+		interval = time.Duration(float64(interval) * options.backoff)
+		if interval > options.maxInterval {
+			interval = options.maxInterval
+		}
+	}
+}
@@ -0,0 +1,71 @@
+package netlify
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+
+	"github.com/kodestech/poc-netlify/internal/tracing"
+	"github.com/netlify/open-api/go/models"
+)
+
+// GetSite obtém os detalhes de um site pelo ID
+func (c *Client) GetSite(ctx context.Context, siteID string) (site *models.Site, err error) {
+	ctx, span := tracing.Start(ctx, "netlify.GetSite", tracing.SiteID.String(siteID))
+	defer func() { tracing.End(span, err) }()
+
+	site, err = c.netlify.GetSite(c.createAuthContext(ctx), siteID)
+	if err != nil {
+		if isNotFound(err) {
+			return nil, fmt.Errorf("%w: %s", ErrSiteNotFound, siteID)
+		}
+		return nil, fmt.Errorf("erro ao obter site: %w", err)
+	}
+	return site, nil
+}
+
+// RenameSite altera o nome (e o subdomínio .netlify.app) de um site
+func (c *Client) RenameSite(ctx context.Context, siteID, name string) (site *models.Site, err error) {
+	ctx, span := tracing.Start(ctx, "netlify.RenameSite", tracing.SiteID.String(siteID), tracing.SiteName.String(name))
+	defer func() { tracing.End(span, err) }()
+
+	slog.InfoContext(ctx, "Renomeando site", "site_id", siteID, "name", name)
+
+	if name == "" {
+		return nil, fmt.Errorf("nome do site não pode ser vazio")
+	}
+
+	site, err = c.netlify.UpdateSite(c.createAuthContext(ctx), &models.SiteSetup{
+		Site: models.Site{
+			ID:   siteID,
+			Name: name,
+		},
+	})
+	if err != nil {
+		if isNotFound(err) {
+			return nil, fmt.Errorf("%w: %s", ErrSiteNotFound, siteID)
+		}
+		return nil, fmt.Errorf("erro ao renomear site: %w", err)
+	}
+
+	slog.InfoContext(ctx, "Site renomeado", "site_id", siteID, "name", site.Name)
+	return site, nil
+}
+
+// DeleteSite exclui um site da Netlify
+func (c *Client) DeleteSite(ctx context.Context, siteID string) (err error) {
+	ctx, span := tracing.Start(ctx, "netlify.DeleteSite", tracing.SiteID.String(siteID))
+	defer func() { tracing.End(span, err) }()
+
+	slog.InfoContext(ctx, "Excluindo site", "site_id", siteID)
+
+	if err := c.netlify.DeleteSite(c.createAuthContext(ctx), siteID); err != nil {
+		if isNotFound(err) {
+			return fmt.Errorf("%w: %s", ErrSiteNotFound, siteID)
+		}
+		return fmt.Errorf("erro ao excluir site: %w", err)
+	}
+
+	slog.InfoContext(ctx, "Site excluído com sucesso", "site_id", siteID)
+	return nil
+}
@@ -3,11 +3,14 @@ package netlify
 import (
 	"context"
 	"fmt"
-	"log"
+	"log/slog"
 	"os"
 	"time"
 	"encoding/base64"
 
+	"github.com/kodestech/poc-netlify/internal/logging"
+	"github.com/kodestech/poc-netlify/internal/store"
+	"github.com/kodestech/poc-netlify/internal/tracing"
 	"github.com/netlify/open-api/go/models"
 	porcelainctx "github.com/netlify/open-api/go/porcelain/context"
 )
@@ -22,48 +25,69 @@ type TestDeployParams struct {
 	CustomDomain    string `json:"custom_domain" example:"meu-site.exemplo.com" swagger:"description=Domínio personalizado para o site (opcional)"`
 	FileContent     string `json:"file_content" example:"" swagger:"description=Conteúdo de arquivo HTML em formato base64 (opcional, alternativa ao TestContent)"`
 	FolderPath      string `json:"folder_path" example:"/path/to/folder" swagger:"description=Caminho da pasta local para deploy (opcional, alternativa ao FileContent e TestContent)"`
+	Draft           bool   `json:"draft" example:"false" swagger:"description=Criar o deploy como rascunho, publicado apenas na URL de pré-visualização"`
 }
 
 // TestDeployResult contém o resultado do teste de deploy
 type TestDeployResult struct {
-	Success     bool      `json:"success" example:"true" swagger:"description=Indica se o teste foi bem-sucedido"`
-	Message     string    `json:"message" example:"Site de teste criado com sucesso" swagger:"description=Mensagem do resultado do teste"`
-	SiteID      string    `json:"site_id,omitempty" example:"a1b2c3d4" swagger:"description=ID do site criado na Netlify"`
-	SiteURL     string    `json:"site_url,omitempty" example:"https://test-site.netlify.app" swagger:"description=URL do site criado"`
-	CreatedAt   time.Time `json:"created_at,omitempty" swagger:"description=Data e hora de criação do site"`
-	TestSuccess bool      `json:"test_success" example:"true" swagger:"description=Indica se o teste específico foi bem-sucedido"`
+	Success        bool       `json:"success" example:"true" swagger:"description=Indica se o teste foi bem-sucedido"`
+	SiteID         string     `json:"site_id,omitempty" example:"a1b2c3d4" swagger:"description=ID do site criado na Netlify"`
+	SiteURL        string     `json:"site_url,omitempty" example:"https://test-site.netlify.app" swagger:"description=URL do site criado"`
+	CreatedAt      time.Time  `json:"created_at,omitempty" swagger:"description=Data e hora de criação do site"`
+	TestSuccess    bool       `json:"test_success" example:"true" swagger:"description=Indica se o teste específico foi bem-sucedido"`
+	DeployID       string     `json:"deploy_id,omitempty" example:"5f1b2c3d4e5f6a7b8c9d0e1f" swagger:"description=ID do deploy criado na Netlify"`
+	ExpiresAt      *time.Time `json:"expires_at,omitempty" swagger:"description=Momento em que o site temporário será excluído (cleanup_after)"`
+	Retries        int        `json:"retries" example:"0" swagger:"description=Novas tentativas feitas nas chamadas à Netlify (429, 5xx ou falha de rede)"`
+	LastRetryError string     `json:"last_retry_error,omitempty" example:"status 429" swagger:"description=Último erro que provocou uma nova tentativa"`
 }
 
-// ExecuteTestDeploy realiza um teste de deploy na Netlify
-func (c *Client) ExecuteTestDeploy(ctx context.Context, params TestDeployParams) (*TestDeployResult, error) {
-	log.Printf("[TEST] Iniciando teste de deploy: %s", params.SiteName)
-	log.Printf("[TEST] Detalhes completos dos parâmetros: %+v", params)
+// ExecuteTestDeploy realiza um teste de deploy na Netlify.
+// Se o site foi criado mas o deploy falhou, retorna o resultado com o site junto com o erro do deploy.
+func (c *Client) ExecuteTestDeploy(ctx context.Context, params TestDeployParams) (_ *TestDeployResult, err error) {
+	ctx, span := tracing.Start(ctx, "netlify.ExecuteTestDeploy", tracing.SiteName.String(params.SiteName))
+	defer func() { tracing.End(span, err) }()
+
+	ctx = logging.With(ctx, "site_name", params.SiteName)
+	slog.InfoContext(ctx, "Iniciando teste de deploy",
+		"site_id", params.SiteID,
+		"custom_domain", params.CustomDomain,
+		"cleanup_after", params.CleanupAfter,
+		"test_content_bytes", len(params.TestContent),
+		"file_content_bytes", len(params.FileContent),
+		"folder_path", params.FolderPath,
+		"draft", params.Draft,
+	)
 
 	// Preparar resultado
 	result := &TestDeployResult{
 		CreatedAt: time.Now(),
 	}
-
-	log.Printf("[TEST] Token da Netlify: %s...", c.config.NetlifyToken[:10])
+	defer func() {
+		stats := c.RetryStats()
+		result.Retries = stats.Retries
+		result.LastRetryError = stats.LastError
+	}()
 
 	// Criar um novo contexto com a autenticação
-	authCtx := porcelainctx.WithAuthInfo(context.Background(), c.auth)
+	authCtx := porcelainctx.WithAuthInfo(ctx, c.tracedAuth(ctx))
 
 	// Se um SiteID foi fornecido, buscar o site diretamente
 	var site *models.Site
-	var err error
 	if params.SiteID != "" {
-		log.Printf("[TEST] Buscando site pelo ID fornecido: %s", params.SiteID)
+		slog.DebugContext(ctx, "Buscando site pelo ID fornecido", "site_id", params.SiteID)
 		site, err = c.netlify.GetSite(authCtx, params.SiteID)
 		if err != nil {
-			log.Printf("[TEST] ERRO ao buscar site pelo ID: %v", err)
-			return nil, fmt.Errorf("erro ao buscar site pelo ID: %w", err)
+			slog.ErrorContext(ctx, "Erro ao buscar site pelo ID", "site_id", params.SiteID, "error", err)
+			if isNotFound(err) {
+				return nil, fmt.Errorf("%w: %s", ErrSiteNotFound, params.SiteID)
+			}
+			return nil, fmt.Errorf("erro ao buscar site pelo ID: %w", Classify(err))
 		}
-		log.Printf("[TEST] Site encontrado: %s (ID: %s)", site.Name, site.ID)
+		slog.InfoContext(ctx, "Site encontrado", "site_id", site.ID, "current_name", site.Name)
 		
 		// Atualizar o nome do site se necessário
 		if site.Name != params.SiteName {
-			log.Printf("[TEST] Atualizando nome do site de '%s' para '%s'", site.Name, params.SiteName)
+			slog.InfoContext(ctx, "Atualizando nome do site", "site_id", site.ID, "from", site.Name, "to", params.SiteName)
 			
 			// Criar um objeto SiteSetup para atualização
 			updateSiteParams := &models.SiteSetup{
@@ -76,42 +100,42 @@ func (c *Client) ExecuteTestDeploy(ctx context.Context, params TestDeployParams)
 			// Atualizar o site na Netlify
 			site, err = c.netlify.UpdateSite(authCtx, updateSiteParams)
 			if err != nil {
-				log.Printf("[TEST] ERRO ao atualizar site: %v", err)
+				slog.ErrorContext(ctx, "Erro ao atualizar site", "site_id", params.SiteID, "error", err)
 				return nil, fmt.Errorf("erro ao atualizar site: %w", err)
 			}
-			log.Printf("[TEST] Site atualizado com sucesso: %s (ID: %s)", site.Name, site.ID)
+			slog.InfoContext(ctx, "Site atualizado com sucesso", "site_id", site.ID)
 		}
 	} else {
 		// Verificar se já existe um site com este nome
-		log.Printf("[TEST] Buscando sites existentes na Netlify...")
+		slog.DebugContext(ctx, "Buscando sites existentes na Netlify")
 		
 		sites, err := c.netlify.ListSites(authCtx, nil)
 		if err != nil {
-			log.Printf("[TEST] ERRO ao listar sites: %v", err)
+			slog.ErrorContext(ctx, "Erro ao listar sites", "error", err)
 			return nil, fmt.Errorf("erro ao listar sites: %w", err)
 		}
 
-		log.Printf("[TEST] Encontrado %d sites no total", len(sites))
+		slog.DebugContext(ctx, "Sites listados", "sites", len(sites))
 
 		var existingSite *models.Site
 		for _, s := range sites {
 			if s.Name == params.SiteName {
 				existingSite = s
-				log.Printf("[TEST] Site com nome '%s' já existe (ID: %s)", params.SiteName, s.ID)
+				slog.InfoContext(ctx, "Site com o mesmo nome já existe", "site_id", s.ID)
 				break
 			}
 		}
 
 		// Se o site já existe e devemos limpá-lo, excluí-lo primeiro
 		if existingSite != nil && params.CleanupAfter {
-			log.Printf("[TEST] Excluindo site existente: %s (ID: %s)", existingSite.Name, existingSite.ID)
+			slog.InfoContext(ctx, "Excluindo site existente", "site_id", existingSite.ID)
 			err := c.netlify.DeleteSite(authCtx, existingSite.ID)
 			if err != nil {
-				log.Printf("[TEST] Erro ao excluir site existente: %v", err)
+				slog.ErrorContext(ctx, "Erro ao excluir site existente", "site_id", existingSite.ID, "error", err)
 				return nil, fmt.Errorf("erro ao excluir site existente: %w", err)
 			}
+			slog.InfoContext(ctx, "Site existente excluído", "site_id", existingSite.ID)
 			existingSite = nil
-			log.Printf("[TEST] Site excluído com sucesso")
 		}
 
 		// Criar novo site de teste ou usar o existente
@@ -124,94 +148,104 @@ func (c *Client) ExecuteTestDeploy(ctx context.Context, params TestDeployParams)
 			}
 
 			// Criar o site
-			log.Printf("[TEST] Criando novo site de teste: %s", params.SiteName)
-			log.Printf("[TEST] Parâmetros do site: %+v", siteParams)
+			slog.InfoContext(ctx, "Criando novo site de teste")
 			site, err = c.netlify.CreateSite(authCtx, &siteParams, false)
 			if err != nil {
-				log.Printf("[TEST] ERRO ao criar site: %v", err)
-				log.Printf("[TEST] ERRO detalhado: %#v", err)
-				
-				// Tentar extrair mais detalhes do erro
-				if apiErr, ok := err.(interface{ Error() string }); ok {
-					log.Printf("[TEST] Mensagem de erro: %s", apiErr.Error())
-				}
-				
+				slog.ErrorContext(ctx, "Erro ao criar site de teste", "error", err)
 				return nil, fmt.Errorf("erro ao criar site de teste: %w", err)
 			}
 			
 			// Configurar domínio personalizado após a criação do site
 			if params.CustomDomain != "" {
-				log.Printf("[TEST] Configurando domínio personalizado após criação: %s", params.CustomDomain)
+				slog.InfoContext(ctx, "Configurando domínio personalizado após a criação", "site_id", site.ID, "domain", params.CustomDomain)
 				err = c.configureCustomDomain(authCtx, site, params.CustomDomain)
 				if err != nil {
-					log.Printf("[TEST] AVISO: Erro ao configurar domínio personalizado: %v", err)
+					slog.WarnContext(ctx, "Erro ao configurar domínio personalizado", "site_id", site.ID, "domain", params.CustomDomain, "error", err)
 					// Não falhar o processo por erro no domínio personalizado
 				}
 			}
-			log.Printf("[TEST] Site criado com sucesso: %s (ID: %s)", site.Name, site.ID)
+			slog.InfoContext(ctx, "Site de teste criado com sucesso", "site_id", site.ID)
 		} else {
 			site = existingSite
-			log.Printf("[TEST] Usando site existente: %s (ID: %s)", site.Name, site.ID)
+			slog.InfoContext(ctx, "Usando site existente", "site_id", site.ID)
 		}
 	}
 
+	// Os logs seguintes, inclusive os do deploy, trazem o ID do site
+	ctx = logging.With(ctx, "site_id", site.ID)
+	authCtx = porcelainctx.WithAuthInfo(ctx, c.tracedAuth(ctx))
+
 	// Preencher resultado com informações do site
 	result.SiteID = site.ID
+	span.SetAttributes(tracing.SiteID.String(site.ID))
 	result.SiteURL = site.URL
 	result.Success = true
-	result.Message = "Site de teste criado/atualizado com sucesso"
 	result.TestSuccess = true
 
+	// Se solicitado para limpar após o teste e não é um site existente (que queremos manter)
+	if params.CleanupAfter && params.SiteID == "" {
+		expiresAt := time.Now().Add(c.config.TestSiteTTL)
+		result.ExpiresAt = &expiresAt
+		slog.InfoContext(ctx, "Site temporário agendado para exclusão", "expires_at", expiresAt.Format(time.RFC3339))
+	}
+
+	// Criar o deploy como rascunho, sem publicá-lo em produção
+	if params.Draft {
+		authCtx = WithDraft(authCtx)
+	}
+
 	// Verificar o conteúdo para deploy - priorizar o arquivo sobre conteúdo de texto
 	var files map[string]string
 	if params.FileContent != "" {
-		log.Printf("[TEST] Processando arquivo enviado para deploy")
+		slog.DebugContext(ctx, "Processando arquivo enviado para deploy")
 		
 		// Decodificar o arquivo Base64
 		fileData, err := base64.StdEncoding.DecodeString(params.FileContent)
 		if err != nil {
-			log.Printf("[TEST] Erro ao decodificar conteúdo do arquivo: %v", err)
+			slog.ErrorContext(ctx, "Erro ao decodificar conteúdo do arquivo", "error", err)
 			return nil, fmt.Errorf("erro ao decodificar conteúdo do arquivo: %w", err)
 		}
 		
-		log.Printf("[TEST] Arquivo decodificado com sucesso, tamanho: %d bytes", len(fileData))
+		slog.InfoContext(ctx, "Arquivo enviado decodificado", "bytes", len(fileData))
 		
+		// Registrar no histórico que o conteúdo veio de um upload
+		authCtx = WithDeploySource(authCtx, store.SourceUpload, "index.html")
+
 		// Criar o arquivo index.html com o conteúdo decodificado
 		files = map[string]string{
 			"index.html": string(fileData),
 		}
 	} else if params.TestContent != "" {
-		log.Printf("[TEST] Realizando deploy de conteúdo de teste")
+		slog.InfoContext(ctx, "Realizando deploy de conteúdo de teste")
 		
 		// Preparar o conteúdo para deploy
 		files = map[string]string{
 			"index.html": params.TestContent,
 		}
 	} else if params.FolderPath != "" {
-		log.Printf("[TEST] Realizando deploy de pasta local: %s", params.FolderPath)
+		slog.InfoContext(ctx, "Realizando deploy de pasta local", "folder_path", params.FolderPath)
 		
 		// Verificar se a pasta existe
 		if _, err := os.Stat(params.FolderPath); os.IsNotExist(err) {
-			log.Printf("[TEST] Erro: Pasta não encontrada: %s", params.FolderPath)
+			slog.ErrorContext(ctx, "Pasta não encontrada", "folder_path", params.FolderPath)
 			return nil, fmt.Errorf("pasta não encontrada: %w", err)
 		}
 		
 		// Realizar deploy diretamente da pasta local
 		deployment, err := c.DeployLocalFolder(authCtx, site, params.FolderPath)
 		if err != nil {
-			log.Printf("[TEST] Erro ao realizar deploy da pasta local: %v", err)
+			slog.ErrorContext(ctx, "Erro ao realizar deploy da pasta local", "folder_path", params.FolderPath, "error", err)
 			result.TestSuccess = false
-			result.Message += fmt.Sprintf(". Porém, ocorreu um erro durante o deploy: %v", err)
-			return result, nil
+			return result, fmt.Errorf("erro ao realizar deploy: %w", err)
 		}
 		
-		log.Printf("[TEST] Deploy da pasta local realizado com sucesso, ID: %s", deployment.ID)
-		result.Message += fmt.Sprintf(". Deploy realizado com sucesso (ID: %s)", deployment.ID)
+		slog.InfoContext(ctx, "Deploy da pasta local realizado com sucesso", "deploy_id", deployment.ID)
+		result.DeployID = deployment.ID
 		
 		// Retornar resultado sem continuar com o deploy de arquivos
 		return result, nil
 	} else {
-		log.Printf("[TEST] Nenhum conteúdo fornecido para deploy")
+		slog.InfoContext(ctx, "Nenhum conteúdo fornecido para deploy, usando conteúdo padrão")
 		// Nenhum conteúdo fornecido, apenas criar um arquivo HTML padrão
 		files = map[string]string{
 			"index.html": "<html><body><h1>Teste de Deploy</h1><p>Site criado em " + time.Now().Format(time.RFC3339) + "</p></body></html>",
@@ -222,22 +256,12 @@ func (c *Client) ExecuteTestDeploy(ctx context.Context, params TestDeployParams)
 	if len(files) > 0 {
 		deployment, err := c.DeployContent(authCtx, site, files)
 		if err != nil {
-			log.Printf("[TEST] Erro ao realizar deploy do conteúdo: %v", err)
-			// Não falharemos o teste se o deploy não funcionar, apenas registramos
+			slog.ErrorContext(ctx, "Erro ao realizar deploy do conteúdo", "error", err)
 			result.TestSuccess = false
-			result.Message += fmt.Sprintf(". Porém, ocorreu um erro durante o deploy: %v", err)
-		} else {
-			log.Printf("[TEST] Deploy de conteúdo realizado com sucesso, ID: %s", deployment.ID)
-			result.Message += fmt.Sprintf(". Deploy realizado com sucesso (ID: %s)", deployment.ID)
+			return result, fmt.Errorf("erro ao realizar deploy: %w", err)
 		}
-	}
-
-	// Se solicitado para limpar após o teste e não é um site existente (que queremos manter)
-	if params.CleanupAfter && params.SiteID == "" {
-		log.Printf("[TEST] Agendando limpeza do site após teste")
-		// Em um caso real, poderíamos agendar a exclusão do site após um período
-		// ou criar um job em background. Por simplicidade, apenas registramos a intenção.
-		log.Printf("[TEST] Site %s será excluído após o período de teste", site.Name)
+		slog.InfoContext(ctx, "Deploy de conteúdo realizado com sucesso", "deploy_id", deployment.ID)
+		result.DeployID = deployment.ID
 	}
 
 	return result, nil
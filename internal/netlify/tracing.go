@@ -0,0 +1,65 @@
+package netlify
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/go-openapi/runtime"
+	"github.com/go-openapi/strfmt"
+	"github.com/kodestech/poc-netlify/internal/tracing"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// tracingTransport cria um span para cada chamada à API da Netlify, inclusive as novas tentativas,
+// como filho do span da operação do cliente em andamento
+type tracingTransport struct {
+	base     http.RoundTripper
+	basePath string
+}
+
+// RoundTrip executa a requisição dentro de um span com o método, a operação e o status da resposta
+func (t *tracingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	// As chamadas feitas pela biblioteca da Netlify não recebem o contexto da operação:
+	// o span pai vem do cabeçalho traceparent incluído por tracedAuth
+	ctx := req.Context()
+	if !trace.SpanContextFromContext(ctx).IsValid() {
+		ctx = tracing.Extract(ctx, req.Header)
+	}
+
+	path := operationPath(strings.TrimPrefix(req.URL.Path, t.basePath))
+	ctx, span := tracing.StartClient(ctx, "netlify "+req.Method+" "+path,
+		tracing.HTTPMethod.String(req.Method),
+		tracing.URLPath.String(path),
+	)
+
+	resp, err := t.base.RoundTrip(req.WithContext(ctx))
+
+	spanErr := err
+	if err == nil {
+		span.SetAttributes(tracing.HTTPStatus.Int(resp.StatusCode))
+		if resp.StatusCode >= http.StatusBadRequest {
+			spanErr = fmt.Errorf("status %d", resp.StatusCode)
+		}
+	}
+	tracing.End(span, spanErr)
+	return resp, err
+}
+
+// tracedAuth retorna a autenticação do cliente incluindo nas requisições o contexto do trace em ctx
+// (cabeçalho traceparent), já que a biblioteca da Netlify não repassa o contexto às requisições
+func (c *Client) tracedAuth(ctx context.Context) runtime.ClientAuthInfoWriter {
+	headers := tracing.Inject(ctx)
+	if len(headers) == 0 {
+		return c.auth
+	}
+	return runtime.ClientAuthInfoWriterFunc(func(req runtime.ClientRequest, reg strfmt.Registry) error {
+		for key := range headers {
+			if err := req.SetHeaderParam(key, headers.Get(key)); err != nil {
+				return err
+			}
+		}
+		return c.auth.AuthenticateRequest(req, reg)
+	})
+}
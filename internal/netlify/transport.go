@@ -0,0 +1,112 @@
+package netlify
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/go-openapi/runtime"
+	"github.com/go-openapi/strfmt"
+	"github.com/netlify/open-api/go/models"
+)
+
+// DefaultAPIURL é o endereço da API da Netlify usado quando NETLIFY_API_URL não é definido
+const DefaultAPIURL = "https://api.netlify.com/api/v1"
+
+// ClientOption personaliza a criação do Client
+type ClientOption func(*clientOptions)
+
+type clientOptions struct {
+	httpClient  *http.Client
+	retryPolicy *RetryPolicy
+}
+
+// WithHTTPClient define o cliente HTTP usado em todas as chamadas à API da Netlify
+func WithHTTPClient(httpClient *http.Client) ClientOption {
+	return func(o *clientOptions) {
+		o.httpClient = httpClient
+	}
+}
+
+// WithTransport define o http.RoundTripper usado em todas as chamadas à API da Netlify
+func WithTransport(transport http.RoundTripper) ClientOption {
+	return func(o *clientOptions) {
+		o.httpClient = &http.Client{Transport: transport}
+	}
+}
+
+// parseAPIURL separa o endereço da API em host, caminho base e esquema
+func parseAPIURL(apiURL string) (host, basePath, scheme string, err error) {
+	if apiURL == "" {
+		apiURL = DefaultAPIURL
+	}
+
+	u, err := url.Parse(apiURL)
+	if err != nil {
+		return "", "", "", fmt.Errorf("URL da API da Netlify inválida: %w", err)
+	}
+	if u.Host == "" || (u.Scheme != "http" && u.Scheme != "https") {
+		return "", "", "", fmt.Errorf("URL da API da Netlify inválida: %s", apiURL)
+	}
+
+	basePath = strings.TrimSuffix(u.Path, "/")
+	if basePath == "" {
+		basePath = "/"
+	}
+	return u.Host, basePath, u.Scheme, nil
+}
+
+// APIError representa uma resposta de erro da API da Netlify em chamadas fora do porcelain
+type APIError struct {
+	Operation string
+	Status    int
+	Body      string
+}
+
+// Error implementa a interface error
+func (e *APIError) Error() string {
+	return fmt.Sprintf("%s: status %d, resposta: %s", e.Operation, e.Status, e.Body)
+}
+
+// Code retorna o status HTTP da resposta, como nos erros gerados pelo porcelain
+func (e *APIError) Code() int {
+	return e.Status
+}
+
+// patchSite envia um PATCH /sites/{site_id} com campos ausentes em models.SiteSetup (ex: record_txt_value),
+// usando o mesmo transporte e autenticação do porcelain
+func (c *Client) patchSite(ctx context.Context, siteID string, payload map[string]interface{}) (*models.Site, error) {
+	result, err := c.transport.Submit(&runtime.ClientOperation{
+		ID:                 "updateSite",
+		Method:             http.MethodPatch,
+		PathPattern:        "/sites/{site_id}",
+		ProducesMediaTypes: []string{"application/json"},
+		ConsumesMediaTypes: []string{"application/json"},
+		AuthInfo:           c.auth,
+		Context:            ctx,
+		Params: runtime.ClientRequestWriterFunc(func(r runtime.ClientRequest, _ strfmt.Registry) error {
+			if err := r.SetPathParam("site_id", siteID); err != nil {
+				return err
+			}
+			return r.SetBodyParam(payload)
+		}),
+		Reader: runtime.ClientResponseReaderFunc(func(resp runtime.ClientResponse, consumer runtime.Consumer) (interface{}, error) {
+			if resp.Code() >= 300 {
+				body, _ := io.ReadAll(resp.Body())
+				return nil, &APIError{Operation: "updateSite", Status: resp.Code(), Body: string(body)}
+			}
+			site := &models.Site{}
+			if err := consumer.Consume(resp.Body(), site); err != nil && err != io.EOF {
+				return nil, err
+			}
+			return site, nil
+		}),
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result.(*models.Site), nil
+}
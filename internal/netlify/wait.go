@@ -0,0 +1,235 @@
+package netlify
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/kodestech/poc-netlify/internal/logging"
+	"github.com/kodestech/poc-netlify/internal/tracing"
+	"github.com/netlify/open-api/go/models"
+	"github.com/netlify/open-api/go/plumbing/operations"
+)
+
+var (
+	// ErrDeployFailed indica que a Netlify encerrou o deploy no estado error
+	ErrDeployFailed = errors.New("deploy falhou na Netlify")
+	// ErrDeployRejected indica que a Netlify rejeitou o deploy (estado rejected)
+	ErrDeployRejected = errors.New("deploy rejeitado pela Netlify")
+	// ErrDeployTimeout indica que o deploy não terminou dentro do tempo máximo de espera
+	ErrDeployTimeout = errors.New("tempo esgotado aguardando o deploy")
+)
+
+// DeployStateError descreve um deploy que terminou em um estado de falha
+type DeployStateError struct {
+	DeployID string
+	State    string
+	Message  string
+}
+
+// Error implementa a interface error
+func (e *DeployStateError) Error() string {
+	if e.Message == "" {
+		return fmt.Sprintf("deploy %s terminou no estado %s", e.DeployID, e.State)
+	}
+	return fmt.Sprintf("deploy %s terminou no estado %s: %s", e.DeployID, e.State, e.Message)
+}
+
+// Unwrap permite comparar o erro com ErrDeployRejected e ErrDeployFailed
+func (e *DeployStateError) Unwrap() error {
+	if e.State == "rejected" {
+		return ErrDeployRejected
+	}
+	return ErrDeployFailed
+}
+
+// DeployStateFunc é chamada a cada mudança de estado do deploy (ex: uploading → processing).
+// from é vazio na primeira consulta.
+type DeployStateFunc func(from, to string, deploy *models.Deploy)
+
+// WaitOption personaliza a espera de WaitForDeploy
+type WaitOption func(*waitOptions)
+
+type waitOptions struct {
+	pollInterval time.Duration
+	maxInterval  time.Duration
+	backoff      float64
+	maxWait      time.Duration
+	onState      DeployStateFunc
+}
+
+// Valores padrão da espera por um deploy
+const (
+	defaultPollInterval = 2 * time.Second
+	defaultMaxInterval  = 10 * time.Second
+	defaultWaitBackoff  = 1.5
+	defaultMaxWait      = 15 * time.Minute
+)
+
+// WithPollInterval define o intervalo inicial entre as consultas ao estado do deploy
+func WithPollInterval(interval time.Duration) WaitOption {
+	return func(o *waitOptions) {
+		if interval > 0 {
+			o.pollInterval = interval
+		}
+	}
+}
+
+// WithMaxWait define o tempo máximo de espera; 0 espera até o cancelamento do contexto
+func WithMaxWait(maxWait time.Duration) WaitOption {
+	return func(o *waitOptions) {
+		o.maxWait = maxWait
+	}
+}
+
+// WithBackoff multiplica o intervalo entre consultas por factor enquanto o estado não muda, até maxInterval
+func WithBackoff(factor float64, maxInterval time.Duration) WaitOption {
+	return func(o *waitOptions) {
+		if factor >= 1 {
+			o.backoff = factor
+		}
+		if maxInterval > 0 {
+			o.maxInterval = maxInterval
+		}
+	}
+}
+
+// WithStateCallback registra uma função chamada a cada mudança de estado do deploy
+func WithStateCallback(fn DeployStateFunc) WaitOption {
+	return func(o *waitOptions) {
+		o.onState = fn
+	}
+}
+
+// WaitForDeploy aguarda o deploy chegar ao estado ready, respeitando o cancelamento de ctx.
+// Retorna DeployStateError (ErrDeployFailed/ErrDeployRejected) se o deploy falhar
+// e ErrDeployTimeout se o tempo máximo de espera (DEPLOY_WAIT_TIMEOUT) se esgotar.
+func (c *Client) WaitForDeploy(ctx context.Context, deployID string, opts ...WaitOption) (deploy *models.Deploy, err error) {
+	ctx, span := tracing.Start(ctx, "netlify.WaitForDeploy", tracing.DeployID.String(deployID))
+	defer func() { tracing.End(span, err) }()
+	defer func() { c.observeDeployResult(deployID, err) }()
+
+	options := c.resolveWaitOptions(opts)
+
+	ctx = logging.With(ctx, "deploy_id", deployID)
+	slog.InfoContext(ctx, "Aguardando conclusão do deploy")
+
+	progress := progressFromContext(ctx)
+	progress.SetPhase(PhaseProcessing)
+
+	if options.maxWait > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, options.maxWait)
+		defer cancel()
+	}
+
+	state := ""
+	interval := options.pollInterval
+	for {
+		params := operations.NewGetDeployParams().WithContext(ctx).WithDeployID(deployID)
+		resp, err := c.netlify.Operations.GetDeploy(params, c.auth)
+		if err != nil {
+			if ctx.Err() != nil {
+				progress.SetPhase(PhaseError)
+				return nil, waitTimeoutError(deployID, state, ctx.Err())
+			}
+			progress.SetPhase(PhaseError)
+			if isNotFound(err) {
+				return nil, fmt.Errorf("%w: %s", ErrDeployNotFound, deployID)
+			}
+			return nil, fmt.Errorf("erro ao verificar status do deploy: %w", err)
+		}
+		deploy := resp.Payload
+
+		if deploy.State != state {
+			slog.InfoContext(ctx, "Deploy mudou de estado", "from", stateOrNone(state), "to", deploy.State)
+			if phase, ok := deployStatePhase(deploy.State); ok {
+				progress.SetPhase(phase)
+			}
+			if options.onState != nil {
+				options.onState(state, deploy.State, deploy)
+			}
+			state = deploy.State
+			interval = options.pollInterval
+		} else {
+			interval = time.Duration(float64(interval) * options.backoff)
+			if interval > options.maxInterval {
+				interval = options.maxInterval
+			}
+		}
+
+		switch deploy.State {
+		case "ready":
+			c.recordDeployState(ctx, deploy)
+			slog.InfoContext(ctx, "Deploy concluído com sucesso", "url", deploy.URL)
+			return deploy, nil
+		case "error", "rejected":
+			c.recordDeployState(ctx, deploy)
+			return nil, &DeployStateError{DeployID: deployID, State: deploy.State, Message: deploy.ErrorMessage}
+		}
+
+		slog.DebugContext(ctx, "Deploy ainda em progresso", "state", deploy.State, "interval", interval)
+
+		timer := time.NewTimer(interval)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			progress.SetPhase(PhaseError)
+			return nil, waitTimeoutError(deployID, state, ctx.Err())
+		case <-timer.C:
+		}
+	}
+}
+
+// resolveWaitOptions aplica as opções sobre os valores padrão e o tempo máximo configurado (DEPLOY_WAIT_TIMEOUT)
+func (c *Client) resolveWaitOptions(opts []WaitOption) waitOptions {
+	options := waitOptions{
+		pollInterval: defaultPollInterval,
+		maxInterval:  defaultMaxInterval,
+		backoff:      defaultWaitBackoff,
+		maxWait:      defaultMaxWait,
+	}
+	if c.config != nil && c.config.DeployWaitTimeout > 0 {
+		options.maxWait = c.config.DeployWaitTimeout
+	}
+	for _, opt := range opts {
+		opt(&options)
+	}
+	if options.maxInterval < options.pollInterval {
+		options.maxInterval = options.pollInterval
+	}
+	return options
+}
+
+// waitTimeoutError descreve a interrupção da espera, distinguindo tempo esgotado de cancelamento
+func waitTimeoutError(deployID, state string, err error) error {
+	if errors.Is(err, context.DeadlineExceeded) {
+		return fmt.Errorf("%w: deploy %s ainda no estado %s: %w", ErrDeployTimeout, deployID, stateOrNone(state), err)
+	}
+	return fmt.Errorf("espera pelo deploy %s interrompida no estado %s: %w", deployID, stateOrNone(state), err)
+}
+
+// deployStatePhase converte um estado de deploy da Netlify na fase reportada ao DeployProgress
+func deployStatePhase(state string) (DeployPhase, bool) {
+	switch state {
+	case "uploading", "uploaded":
+		return PhaseUploading, true
+	case "preparing", "prepared", "processing", "processed", "building", "enqueued":
+		return PhaseProcessing, true
+	case "ready":
+		return PhaseReady, true
+	case "error", "rejected":
+		return PhaseError, true
+	}
+	return "", false
+}
+
+// stateOrNone retorna o estado ou "nenhum" quando ainda não há estado conhecido
+func stateOrNone(state string) string {
+	if state == "" {
+		return "nenhum"
+	}
+	return state
+}
@@ -0,0 +1,172 @@
+package store
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"regexp"
+	"time"
+
+	bolt "go.etcd.io/bbolt"
+)
+
+// bucketAccounts guarda as contas indexadas pelo ID
+var bucketAccounts = []byte("accounts")
+
+// accountIDPattern define os IDs de conta aceitos (também usados como nome de pasta)
+var accountIDPattern = regexp.MustCompile(`^[a-z0-9][a-z0-9-]{0,62}$`)
+
+// ErrAccountNotFound é retornado quando a conta não existe
+var ErrAccountNotFound = errors.New("conta não encontrada")
+
+// Account representa um cliente (ou agência) com seu próprio time na Netlify.
+// NetlifyToken é gravado cifrado no banco e decifrado na leitura.
+type Account struct {
+	ID           string    `json:"id"`
+	Name         string    `json:"name,omitempty"`
+	NetlifyToken string    `json:"netlify_token"`
+	S3Prefix     string    `json:"s3_prefix,omitempty"`
+	BaseDomain   string    `json:"base_domain,omitempty"`
+	Language     string    `json:"language,omitempty"`
+	CreatedAt    time.Time `json:"created_at"`
+	UpdatedAt    time.Time `json:"updated_at"`
+}
+
+// ValidAccountID indica se o ID informado pode ser usado como ID de conta
+func ValidAccountID(id string) bool {
+	return accountIDPattern.MatchString(id)
+}
+
+// SaveAccount grava (ou substitui) uma conta
+func (s *Store) SaveAccount(acc *Account) error {
+	if !ValidAccountID(acc.ID) {
+		return fmt.Errorf("ID de conta inválido: %q", acc.ID)
+	}
+	if acc.NetlifyToken == "" {
+		return fmt.Errorf("token da Netlify é obrigatório")
+	}
+
+	now := time.Now()
+	if acc.CreatedAt.IsZero() {
+		acc.CreatedAt = now
+	}
+	acc.UpdatedAt = now
+
+	return s.db.Update(func(tx *bolt.Tx) error {
+		return s.putAccount(tx, *acc)
+	})
+}
+
+// putAccount grava a conta com o token da Netlify cifrado
+func (s *Store) putAccount(tx *bolt.Tx, acc Account) error {
+	token, err := s.encrypt(acc.NetlifyToken, acc.ID)
+	if err != nil {
+		return fmt.Errorf("erro ao cifrar token da conta: %w", err)
+	}
+	acc.NetlifyToken = token
+
+	data, err := json.Marshal(acc)
+	if err != nil {
+		return fmt.Errorf("erro ao serializar conta: %w", err)
+	}
+	return tx.Bucket(bucketAccounts).Put([]byte(acc.ID), data)
+}
+
+// decodeAccount lê uma conta gravada, decifrando o token da Netlify
+func (s *Store) decodeAccount(data []byte) (*Account, error) {
+	acc := &Account{}
+	if err := json.Unmarshal(data, acc); err != nil {
+		return nil, err
+	}
+	token, err := s.decrypt(acc.NetlifyToken, acc.ID)
+	if err != nil {
+		return nil, fmt.Errorf("erro ao decifrar token da conta %s: %w", acc.ID, err)
+	}
+	acc.NetlifyToken = token
+	return acc, nil
+}
+
+// migrateAccountTokens cifra os tokens das contas gravados em texto puro por versões anteriores
+func (s *Store) migrateAccountTokens() error {
+	migrated := 0
+	err := s.db.Update(func(tx *bolt.Tx) error {
+		var legacy []Account
+		err := tx.Bucket(bucketAccounts).ForEach(func(_, data []byte) error {
+			var acc Account
+			if err := json.Unmarshal(data, &acc); err != nil {
+				return err
+			}
+			if acc.NetlifyToken != "" && !isEncrypted(acc.NetlifyToken) {
+				legacy = append(legacy, acc)
+			}
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+		// O bucket não pode ser alterado durante o ForEach
+		for _, acc := range legacy {
+			if err := s.putAccount(tx, acc); err != nil {
+				return err
+			}
+		}
+		migrated = len(legacy)
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("erro ao cifrar tokens das contas: %w", err)
+	}
+	if migrated > 0 {
+		slog.Info("Tokens das contas cifrados no banco", "accounts", migrated)
+	}
+	return nil
+}
+
+// GetAccount retorna uma conta pelo ID
+func (s *Store) GetAccount(id string) (*Account, bool, error) {
+	var acc *Account
+	err := s.db.View(func(tx *bolt.Tx) error {
+		data := tx.Bucket(bucketAccounts).Get([]byte(id))
+		if data == nil {
+			return nil
+		}
+		var err error
+		acc, err = s.decodeAccount(data)
+		return err
+	})
+	if err != nil {
+		return nil, false, fmt.Errorf("erro ao ler conta: %w", err)
+	}
+	return acc, acc != nil, nil
+}
+
+// ListAccounts lista as contas em ordem de ID
+func (s *Store) ListAccounts() ([]Account, error) {
+	accounts := []Account{}
+	err := s.db.View(func(tx *bolt.Tx) error {
+		return tx.Bucket(bucketAccounts).ForEach(func(_, data []byte) error {
+			acc, err := s.decodeAccount(data)
+			if err != nil {
+				return err
+			}
+			accounts = append(accounts, *acc)
+			return nil
+		})
+	})
+	if err != nil {
+		return nil, fmt.Errorf("erro ao listar contas: %w", err)
+	}
+	return accounts, nil
+}
+
+// DeleteAccount remove uma conta
+func (s *Store) DeleteAccount(id string) error {
+	return s.db.Update(func(tx *bolt.Tx) error {
+		bucket := tx.Bucket(bucketAccounts)
+		if bucket.Get([]byte(id)) == nil {
+			return ErrAccountNotFound
+		}
+		return bucket.Delete([]byte(id))
+	})
+}
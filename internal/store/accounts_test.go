@@ -0,0 +1,145 @@
+package store
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"path/filepath"
+	"testing"
+
+	bolt "go.etcd.io/bbolt"
+)
+
+const testToken = "nfp_aB3dE5fG7hJ9kL1mN3pQ5rS7tU9vW1xY"
+
+// testKey é uma chave de criptografia válida para os testes
+var testKey = bytes.Repeat([]byte{7}, EncryptionKeySize)
+
+// openTestStore abre um banco novo em um diretório temporário
+func openTestStore(t *testing.T, path string, key []byte) *Store {
+	t.Helper()
+
+	st, err := Open(path, key)
+	if err != nil {
+		t.Fatalf("erro ao abrir o banco: %v", err)
+	}
+	t.Cleanup(func() { st.Close() })
+	return st
+}
+
+// rawAccount retorna a conta como gravada no banco, sem decifrar o token
+func rawAccount(t *testing.T, st *Store, id string) []byte {
+	t.Helper()
+
+	var data []byte
+	err := st.db.View(func(tx *bolt.Tx) error {
+		data = append(data, tx.Bucket(bucketAccounts).Get([]byte(id))...)
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return data
+}
+
+func TestAccountTokenEncryptedAtRest(t *testing.T) {
+	st := openTestStore(t, filepath.Join(t.TempDir(), "data.db"), testKey)
+
+	acc := &Account{ID: "elizio", NetlifyToken: testToken}
+	if err := st.SaveAccount(acc); err != nil {
+		t.Fatalf("erro ao gravar conta: %v", err)
+	}
+	if acc.NetlifyToken != testToken {
+		t.Fatalf("SaveAccount alterou o token da conta recebida: %s", acc.NetlifyToken)
+	}
+
+	raw := rawAccount(t, st, "elizio")
+	if bytes.Contains(raw, []byte(testToken)) {
+		t.Fatalf("token gravado em texto puro: %s", raw)
+	}
+
+	got, ok, err := st.GetAccount("elizio")
+	if err != nil || !ok {
+		t.Fatalf("erro ao ler conta: %v (encontrada: %v)", err, ok)
+	}
+	if got.NetlifyToken != testToken {
+		t.Fatalf("token lido %q, esperado %q", got.NetlifyToken, testToken)
+	}
+
+	accounts, err := st.ListAccounts()
+	if err != nil || len(accounts) != 1 || accounts[0].NetlifyToken != testToken {
+		t.Fatalf("listagem retornou %+v (erro: %v)", accounts, err)
+	}
+}
+
+func TestAccountTokenBoundToAccount(t *testing.T) {
+	st := openTestStore(t, filepath.Join(t.TempDir(), "data.db"), testKey)
+	if err := st.SaveAccount(&Account{ID: "conta-a", NetlifyToken: testToken}); err != nil {
+		t.Fatal(err)
+	}
+
+	// O token cifrado de uma conta não é aceito em outra
+	var acc Account
+	if err := json.Unmarshal(rawAccount(t, st, "conta-a"), &acc); err != nil {
+		t.Fatal(err)
+	}
+	acc.ID = "conta-b"
+	data, _ := json.Marshal(acc)
+	err := st.db.Update(func(tx *bolt.Tx) error {
+		return tx.Bucket(bucketAccounts).Put([]byte("conta-b"), data)
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, _, err := st.GetAccount("conta-b"); !errors.Is(err, ErrInvalidEncryptionKey) {
+		t.Fatalf("leitura do token copiado retornou %v, esperado ErrInvalidEncryptionKey", err)
+	}
+}
+
+func TestLegacyAccountTokensMigrated(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "data.db")
+
+	// Conta gravada por uma versão anterior, com o token em texto puro
+	st := openTestStore(t, path, testKey)
+	data, _ := json.Marshal(Account{ID: "antiga", NetlifyToken: testToken})
+	err := st.db.Update(func(tx *bolt.Tx) error {
+		return tx.Bucket(bucketAccounts).Put([]byte("antiga"), data)
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	st.Close()
+
+	st = openTestStore(t, path, testKey)
+	if raw := rawAccount(t, st, "antiga"); bytes.Contains(raw, []byte(testToken)) {
+		t.Fatalf("token não foi cifrado na abertura: %s", raw)
+	}
+	acc, ok, err := st.GetAccount("antiga")
+	if err != nil || !ok || acc.NetlifyToken != testToken {
+		t.Fatalf("conta migrada lida como %+v (erro: %v)", acc, err)
+	}
+}
+
+func TestEncryptionKeyRequired(t *testing.T) {
+	dir := t.TempDir()
+
+	for _, key := range [][]byte{nil, []byte("curta")} {
+		if _, err := Open(filepath.Join(dir, "invalida.db"), key); !errors.Is(err, ErrInvalidEncryptionKey) {
+			t.Fatalf("abertura com chave de %d bytes retornou %v, esperado ErrInvalidEncryptionKey", len(key), err)
+		}
+	}
+
+	// Os tokens gravados com uma chave não são lidos com outra
+	path := filepath.Join(dir, "data.db")
+	st := openTestStore(t, path, testKey)
+	if err := st.SaveAccount(&Account{ID: "elizio", NetlifyToken: testToken}); err != nil {
+		t.Fatal(err)
+	}
+	st.Close()
+
+	st = openTestStore(t, path, bytes.Repeat([]byte{9}, EncryptionKeySize))
+	if _, _, err := st.GetAccount("elizio"); !errors.Is(err, ErrInvalidEncryptionKey) {
+		t.Fatalf("leitura com outra chave retornou %v, esperado ErrInvalidEncryptionKey", err)
+	}
+}
@@ -0,0 +1,68 @@
+package store
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// EncryptionKeySize é o tamanho da chave AES-256 usada para cifrar os segredos das contas
+const EncryptionKeySize = 32
+
+// encryptedPrefix identifica os valores cifrados no banco; valores sem o prefixo são de versões
+// anteriores, gravados em texto puro, e são cifrados na abertura do banco (ver migrateAccountTokens)
+const encryptedPrefix = "enc:v1:"
+
+// ErrInvalidEncryptionKey indica uma chave de criptografia ausente ou com tamanho diferente de EncryptionKeySize
+var ErrInvalidEncryptionKey = errors.New("chave de criptografia inválida")
+
+// newAEAD cria a cifra AES-GCM com a chave do banco
+func newAEAD(key []byte) (cipher.AEAD, error) {
+	if len(key) != EncryptionKeySize {
+		return nil, fmt.Errorf("%w: esperados %d bytes, recebidos %d", ErrInvalidEncryptionKey, EncryptionKeySize, len(key))
+	}
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidEncryptionKey, err)
+	}
+	return cipher.NewGCM(block)
+}
+
+// encrypt cifra um segredo. O contexto (ex: ID da conta) é autenticado junto, impedindo
+// que o valor cifrado de um registro seja copiado para outro.
+func (s *Store) encrypt(plain, context string) (string, error) {
+	nonce := make([]byte, s.aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return "", fmt.Errorf("erro ao gerar nonce: %w", err)
+	}
+	sealed := s.aead.Seal(nonce, nonce, []byte(plain), []byte(context))
+	return encryptedPrefix + base64.StdEncoding.EncodeToString(sealed), nil
+}
+
+// decrypt decifra um segredo gravado por encrypt. Valores sem o prefixo são retornados sem alteração.
+func (s *Store) decrypt(value, context string) (string, error) {
+	encoded, ok := strings.CutPrefix(value, encryptedPrefix)
+	if !ok {
+		return value, nil
+	}
+
+	sealed, err := base64.StdEncoding.DecodeString(encoded)
+	if err != nil || len(sealed) < s.aead.NonceSize() {
+		return "", fmt.Errorf("valor cifrado corrompido")
+	}
+	nonce, ciphertext := sealed[:s.aead.NonceSize()], sealed[s.aead.NonceSize():]
+	plain, err := s.aead.Open(nil, nonce, ciphertext, []byte(context))
+	if err != nil {
+		return "", fmt.Errorf("%w: não foi possível decifrar o valor gravado", ErrInvalidEncryptionKey)
+	}
+	return string(plain), nil
+}
+
+// isEncrypted indica se o valor gravado já está cifrado
+func isEncrypted(value string) bool {
+	return strings.HasPrefix(value, encryptedPrefix)
+}
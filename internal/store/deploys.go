@@ -0,0 +1,182 @@
+package store
+
+import (
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	bolt "go.etcd.io/bbolt"
+)
+
+var (
+	// bucketDeploys guarda os registros de deploy indexados pelo ID do deploy
+	bucketDeploys = []byte("deploys")
+	// bucketSiteDeploys guarda, por site, o índice cronológico dos deploys
+	bucketSiteDeploys = []byte("site_deploys")
+)
+
+// SourceType identifica a origem dos arquivos de um deploy
+type SourceType string
+
+const (
+	SourceUpload  SourceType = "upload"
+	SourceFolder  SourceType = "folder"
+	SourceS3      SourceType = "s3"
+	SourceContent SourceType = "content"
+)
+
+// DeployRecord representa um deploy registrado no histórico
+type DeployRecord struct {
+	DeployID   string     `json:"deploy_id" example:"5f1b2c3d4e5f6a7b8c9d0e1f" swagger:"description=ID do deploy na Netlify"`
+	SiteID     string     `json:"site_id" example:"a1b2c3d4" swagger:"description=ID do site na Netlify"`
+	SiteName   string     `json:"site_name,omitempty" example:"test-site" swagger:"description=Nome do site na Netlify"`
+	AccountID  string     `json:"account_id,omitempty" example:"elizio" swagger:"description=Conta que realizou o deploy"`
+	SourceType SourceType `json:"source_type" example:"folder" swagger:"description=Origem dos arquivos (upload, folder, s3, content)"`
+	Source     string     `json:"source,omitempty" example:"web/accounts/elizio/bolo-brigadeiro" swagger:"description=Pasta, prefixo S3 ou arquivo de origem"`
+	FileCount  int        `json:"file_count" example:"12" swagger:"description=Quantidade de arquivos do deploy"`
+	TotalBytes int64      `json:"total_bytes" example:"1048576" swagger:"description=Tamanho total dos arquivos do deploy"`
+	State      string     `json:"state" example:"ready" swagger:"description=Estado do deploy na Netlify"`
+	Draft      bool       `json:"draft,omitempty" example:"false" swagger:"description=Indica se o deploy foi criado como rascunho (não publicado)"`
+	Error      string     `json:"error,omitempty" swagger:"description=Mensagem de erro retornada pela Netlify"`
+	StartedAt  time.Time  `json:"started_at" swagger:"description=Início do deploy"`
+	FinishedAt *time.Time `json:"finished_at,omitempty" swagger:"description=Término do deploy"`
+	DurationMs int64      `json:"duration_ms" example:"5300" swagger:"description=Duração do deploy em milissegundos"`
+}
+
+// DeployFilter define os filtros e a paginação da listagem de deploys
+type DeployFilter struct {
+	// AccountID restringe a listagem aos deploys da conta; vazio lista os deploys de todas as contas
+	AccountID string
+	State     string
+	Page      int
+	PerPage   int
+}
+
+// SaveDeploy grava (ou substitui) um registro de deploy
+func (s *Store) SaveDeploy(rec *DeployRecord) error {
+	if rec.DeployID == "" || rec.SiteID == "" {
+		return fmt.Errorf("ID do deploy e ID do site são obrigatórios")
+	}
+
+	data, err := json.Marshal(rec)
+	if err != nil {
+		return fmt.Errorf("erro ao serializar deploy: %w", err)
+	}
+
+	return s.db.Update(func(tx *bolt.Tx) error {
+		if err := tx.Bucket(bucketDeploys).Put([]byte(rec.DeployID), data); err != nil {
+			return err
+		}
+
+		siteBucket, err := tx.Bucket(bucketSiteDeploys).CreateBucketIfNotExists([]byte(rec.SiteID))
+		if err != nil {
+			return err
+		}
+		return siteBucket.Put(siteDeployKey(rec.StartedAt, rec.DeployID), []byte(rec.DeployID))
+	})
+}
+
+// UpdateDeployState registra o estado final de um deploy e calcula sua duração
+func (s *Store) UpdateDeployState(deployID, state, errMsg string, finishedAt time.Time) error {
+	return s.db.Update(func(tx *bolt.Tx) error {
+		bucket := tx.Bucket(bucketDeploys)
+		data := bucket.Get([]byte(deployID))
+		if data == nil {
+			return fmt.Errorf("deploy %s não encontrado no histórico", deployID)
+		}
+
+		var rec DeployRecord
+		if err := json.Unmarshal(data, &rec); err != nil {
+			return fmt.Errorf("erro ao ler deploy: %w", err)
+		}
+
+		rec.State = state
+		rec.Error = errMsg
+		rec.FinishedAt = &finishedAt
+		rec.DurationMs = finishedAt.Sub(rec.StartedAt).Milliseconds()
+
+		data, err := json.Marshal(&rec)
+		if err != nil {
+			return fmt.Errorf("erro ao serializar deploy: %w", err)
+		}
+		return bucket.Put([]byte(deployID), data)
+	})
+}
+
+// GetDeploy retorna o registro de um deploy pelo ID
+func (s *Store) GetDeploy(deployID string) (*DeployRecord, bool, error) {
+	var rec *DeployRecord
+	err := s.db.View(func(tx *bolt.Tx) error {
+		data := tx.Bucket(bucketDeploys).Get([]byte(deployID))
+		if data == nil {
+			return nil
+		}
+		rec = &DeployRecord{}
+		return json.Unmarshal(data, rec)
+	})
+	if err != nil {
+		return nil, false, fmt.Errorf("erro ao ler deploy: %w", err)
+	}
+	return rec, rec != nil, nil
+}
+
+// ListSiteDeploys lista os deploys de um site, do mais recente para o mais antigo.
+// Retorna a página solicitada e o total de registros que atendem ao filtro.
+func (s *Store) ListSiteDeploys(siteID string, filter DeployFilter) ([]DeployRecord, int, error) {
+	if filter.Page < 1 {
+		filter.Page = 1
+	}
+	if filter.PerPage < 1 {
+		filter.PerPage = 20
+	}
+	offset := (filter.Page - 1) * filter.PerPage
+
+	records := []DeployRecord{}
+	total := 0
+
+	err := s.db.View(func(tx *bolt.Tx) error {
+		siteBucket := tx.Bucket(bucketSiteDeploys).Bucket([]byte(siteID))
+		if siteBucket == nil {
+			return nil
+		}
+		deploys := tx.Bucket(bucketDeploys)
+
+		cursor := siteBucket.Cursor()
+		for k, v := cursor.Last(); k != nil; k, v = cursor.Prev() {
+			data := deploys.Get(v)
+			if data == nil {
+				continue
+			}
+
+			var rec DeployRecord
+			if err := json.Unmarshal(data, &rec); err != nil {
+				return fmt.Errorf("erro ao ler deploy %s: %w", v, err)
+			}
+			if filter.AccountID != "" && rec.AccountID != filter.AccountID {
+				continue
+			}
+			if filter.State != "" && rec.State != filter.State {
+				continue
+			}
+
+			if total >= offset && len(records) < filter.PerPage {
+				records = append(records, rec)
+			}
+			total++
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return records, total, nil
+}
+
+// siteDeployKey monta a chave ordenável cronologicamente do índice de deploys de um site
+func siteDeployKey(startedAt time.Time, deployID string) []byte {
+	key := make([]byte, 8, 8+len(deployID))
+	binary.BigEndian.PutUint64(key, uint64(startedAt.UnixNano()))
+	return append(key, deployID...)
+}
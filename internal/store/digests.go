@@ -0,0 +1,45 @@
+package store
+
+import (
+	"encoding/json"
+	"fmt"
+
+	bolt "go.etcd.io/bbolt"
+)
+
+// bucketObjectDigests guarda os SHA1 já calculados dos objetos do S3, indexados por bucket/chave
+var bucketObjectDigests = []byte("object_digests")
+
+// objectDigest representa o SHA1 calculado para uma versão (ETag) de um objeto
+type objectDigest struct {
+	ETag string `json:"etag"`
+	SHA1 string `json:"sha1"`
+}
+
+// GetObjectDigest retorna o SHA1 já calculado de um objeto, desde que o ETag não tenha mudado
+func (s *Store) GetObjectDigest(key, etag string) (string, bool) {
+	var digest objectDigest
+	err := s.db.View(func(tx *bolt.Tx) error {
+		data := tx.Bucket(bucketObjectDigests).Get([]byte(key))
+		if data == nil {
+			return nil
+		}
+		return json.Unmarshal(data, &digest)
+	})
+	if err != nil || digest.SHA1 == "" || digest.ETag != etag {
+		return "", false
+	}
+	return digest.SHA1, true
+}
+
+// SaveObjectDigest grava o SHA1 calculado para uma versão (ETag) de um objeto
+func (s *Store) SaveObjectDigest(key, etag, sha1 string) error {
+	data, err := json.Marshal(objectDigest{ETag: etag, SHA1: sha1})
+	if err != nil {
+		return fmt.Errorf("erro ao serializar digest: %w", err)
+	}
+
+	return s.db.Update(func(tx *bolt.Tx) error {
+		return tx.Bucket(bucketObjectDigests).Put([]byte(key), data)
+	})
+}
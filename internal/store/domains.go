@@ -0,0 +1,54 @@
+package store
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	bolt "go.etcd.io/bbolt"
+)
+
+// bucketDomainVerifications guarda os valores TXT enviados à Netlify, indexados pelo domínio
+var bucketDomainVerifications = []byte("domain_verifications")
+
+// DomainVerification representa o registro TXT usado para verificar um domínio personalizado
+type DomainVerification struct {
+	Domain    string    `json:"domain"`
+	SiteID    string    `json:"site_id"`
+	TXTValue  string    `json:"txt_value"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// SaveDomainVerification grava (ou substitui) o valor TXT de um domínio
+func (s *Store) SaveDomainVerification(v *DomainVerification) error {
+	if v.Domain == "" {
+		return fmt.Errorf("domínio é obrigatório")
+	}
+
+	data, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Errorf("erro ao serializar verificação de domínio: %w", err)
+	}
+
+	return s.db.Update(func(tx *bolt.Tx) error {
+		return tx.Bucket(bucketDomainVerifications).Put([]byte(strings.ToLower(v.Domain)), data)
+	})
+}
+
+// GetDomainVerification retorna o valor TXT registrado para um domínio, se houver
+func (s *Store) GetDomainVerification(domain string) (*DomainVerification, bool, error) {
+	var v *DomainVerification
+	err := s.db.View(func(tx *bolt.Tx) error {
+		data := tx.Bucket(bucketDomainVerifications).Get([]byte(strings.ToLower(domain)))
+		if data == nil {
+			return nil
+		}
+		v = &DomainVerification{}
+		return json.Unmarshal(data, v)
+	})
+	if err != nil {
+		return nil, false, fmt.Errorf("erro ao ler verificação de domínio: %w", err)
+	}
+	return v, v != nil, nil
+}
@@ -0,0 +1,81 @@
+package store
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	bolt "go.etcd.io/bbolt"
+)
+
+// bucketSiteExpirations guarda as exclusões de sites agendadas, indexadas pelo ID do site
+var bucketSiteExpirations = []byte("site_expirations")
+
+// SiteExpiration representa a exclusão agendada de um site temporário
+type SiteExpiration struct {
+	SiteID    string    `json:"site_id" example:"a1b2c3d4" swagger:"description=ID do site na Netlify"`
+	SiteName  string    `json:"site_name,omitempty" example:"test-site" swagger:"description=Nome do site na Netlify"`
+	AccountID string    `json:"account_id" example:"elizio" swagger:"description=Conta dona do site"`
+	ExpiresAt time.Time `json:"expires_at" swagger:"description=Momento a partir do qual o site será excluído"`
+}
+
+// ScheduleSiteDeletion agenda (ou reagenda) a exclusão de um site
+func (s *Store) ScheduleSiteDeletion(exp *SiteExpiration) error {
+	if exp.SiteID == "" || exp.AccountID == "" {
+		return fmt.Errorf("ID do site e ID da conta são obrigatórios")
+	}
+
+	data, err := json.Marshal(exp)
+	if err != nil {
+		return fmt.Errorf("erro ao serializar agendamento: %w", err)
+	}
+
+	return s.db.Update(func(tx *bolt.Tx) error {
+		return tx.Bucket(bucketSiteExpirations).Put([]byte(exp.SiteID), data)
+	})
+}
+
+// GetSiteExpiration retorna a exclusão agendada de um site, se houver
+func (s *Store) GetSiteExpiration(siteID string) (*SiteExpiration, bool, error) {
+	var exp *SiteExpiration
+	err := s.db.View(func(tx *bolt.Tx) error {
+		data := tx.Bucket(bucketSiteExpirations).Get([]byte(siteID))
+		if data == nil {
+			return nil
+		}
+		exp = &SiteExpiration{}
+		return json.Unmarshal(data, exp)
+	})
+	if err != nil {
+		return nil, false, fmt.Errorf("erro ao ler agendamento: %w", err)
+	}
+	return exp, exp != nil, nil
+}
+
+// ListExpiredSites lista as exclusões agendadas cujo prazo já passou
+func (s *Store) ListExpiredSites(now time.Time) ([]SiteExpiration, error) {
+	var expired []SiteExpiration
+	err := s.db.View(func(tx *bolt.Tx) error {
+		return tx.Bucket(bucketSiteExpirations).ForEach(func(_, data []byte) error {
+			var exp SiteExpiration
+			if err := json.Unmarshal(data, &exp); err != nil {
+				return err
+			}
+			if !exp.ExpiresAt.After(now) {
+				expired = append(expired, exp)
+			}
+			return nil
+		})
+	})
+	if err != nil {
+		return nil, fmt.Errorf("erro ao listar agendamentos: %w", err)
+	}
+	return expired, nil
+}
+
+// CancelSiteDeletion remove a exclusão agendada de um site
+func (s *Store) CancelSiteDeletion(siteID string) error {
+	return s.db.Update(func(tx *bolt.Tx) error {
+		return tx.Bucket(bucketSiteExpirations).Delete([]byte(siteID))
+	})
+}
@@ -0,0 +1,115 @@
+package store
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	bolt "go.etcd.io/bbolt"
+)
+
+var (
+	// bucketAPIKeys guarda as chaves de API indexadas pelo ID
+	bucketAPIKeys = []byte("api_keys")
+	// bucketAPIKeyHashes indexa o ID das chaves pelo hash do segredo
+	bucketAPIKeyHashes = []byte("api_key_hashes")
+)
+
+// APIKey representa uma chave de acesso à API. Apenas o hash do segredo é armazenado.
+type APIKey struct {
+	ID        string    `json:"id"`
+	Name      string    `json:"name,omitempty"`
+	AccountID string    `json:"account_id,omitempty"`
+	Role      string    `json:"role"`
+	Prefix    string    `json:"prefix"`
+	Hash      string    `json:"hash"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// SaveAPIKey grava uma nova chave de API
+func (s *Store) SaveAPIKey(key *APIKey) error {
+	if key.ID == "" || key.Hash == "" {
+		return fmt.Errorf("ID e hash da chave são obrigatórios")
+	}
+	if key.CreatedAt.IsZero() {
+		key.CreatedAt = time.Now()
+	}
+
+	data, err := json.Marshal(key)
+	if err != nil {
+		return fmt.Errorf("erro ao serializar chave: %w", err)
+	}
+
+	return s.db.Update(func(tx *bolt.Tx) error {
+		if err := tx.Bucket(bucketAPIKeys).Put([]byte(key.ID), data); err != nil {
+			return err
+		}
+		return tx.Bucket(bucketAPIKeyHashes).Put([]byte(key.Hash), []byte(key.ID))
+	})
+}
+
+// GetAPIKeyByHash retorna a chave de API correspondente ao hash do segredo
+func (s *Store) GetAPIKeyByHash(hash string) (*APIKey, bool, error) {
+	var key *APIKey
+	err := s.db.View(func(tx *bolt.Tx) error {
+		id := tx.Bucket(bucketAPIKeyHashes).Get([]byte(hash))
+		if id == nil {
+			return nil
+		}
+		data := tx.Bucket(bucketAPIKeys).Get(id)
+		if data == nil {
+			return nil
+		}
+		key = &APIKey{}
+		return json.Unmarshal(data, key)
+	})
+	if err != nil {
+		return nil, false, fmt.Errorf("erro ao ler chave: %w", err)
+	}
+	return key, key != nil, nil
+}
+
+// ListAPIKeys lista as chaves de API cadastradas
+func (s *Store) ListAPIKeys() ([]APIKey, error) {
+	keys := []APIKey{}
+	err := s.db.View(func(tx *bolt.Tx) error {
+		return tx.Bucket(bucketAPIKeys).ForEach(func(_, data []byte) error {
+			var key APIKey
+			if err := json.Unmarshal(data, &key); err != nil {
+				return err
+			}
+			keys = append(keys, key)
+			return nil
+		})
+	})
+	if err != nil {
+		return nil, fmt.Errorf("erro ao listar chaves: %w", err)
+	}
+	return keys, nil
+}
+
+// DeleteAPIKey remove uma chave de API. Retorna false se a chave não existir.
+func (s *Store) DeleteAPIKey(id string) (bool, error) {
+	deleted := false
+	err := s.db.Update(func(tx *bolt.Tx) error {
+		bucket := tx.Bucket(bucketAPIKeys)
+		data := bucket.Get([]byte(id))
+		if data == nil {
+			return nil
+		}
+
+		var key APIKey
+		if err := json.Unmarshal(data, &key); err != nil {
+			return err
+		}
+		if err := tx.Bucket(bucketAPIKeyHashes).Delete([]byte(key.Hash)); err != nil {
+			return err
+		}
+		deleted = true
+		return bucket.Delete([]byte(id))
+	})
+	if err != nil {
+		return false, fmt.Errorf("erro ao remover chave: %w", err)
+	}
+	return deleted, nil
+}
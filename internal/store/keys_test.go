@@ -0,0 +1,46 @@
+package store
+
+import (
+	"path/filepath"
+	"testing"
+)
+
+func TestAPIKeyLifecycle(t *testing.T) {
+	st := openTestStore(t, filepath.Join(t.TempDir(), "data.db"), testKey)
+
+	if err := st.SaveAPIKey(&APIKey{ID: "sem-hash"}); err == nil {
+		t.Fatal("chave sem hash foi aceita")
+	}
+
+	key := &APIKey{ID: "k1", AccountID: "elizio", Role: "deployer", Prefix: "npk_1a2b", Hash: "hash-1"}
+	if err := st.SaveAPIKey(key); err != nil {
+		t.Fatalf("erro ao gravar chave: %v", err)
+	}
+	if key.CreatedAt.IsZero() {
+		t.Fatal("data de criação não preenchida")
+	}
+
+	got, ok, err := st.GetAPIKeyByHash("hash-1")
+	if err != nil || !ok || got.ID != "k1" || got.AccountID != "elizio" || got.Role != "deployer" {
+		t.Fatalf("chave lida como %+v (encontrada: %v, erro: %v)", got, ok, err)
+	}
+	if _, ok, _ := st.GetAPIKeyByHash("hash-2"); ok {
+		t.Fatal("hash desconhecido encontrou uma chave")
+	}
+
+	deleted, err := st.DeleteAPIKey("k1")
+	if err != nil || !deleted {
+		t.Fatalf("remoção retornou %v (erro: %v)", deleted, err)
+	}
+	if _, ok, _ := st.GetAPIKeyByHash("hash-1"); ok {
+		t.Fatal("chave removida continua válida")
+	}
+	if deleted, _ := st.DeleteAPIKey("k1"); deleted {
+		t.Fatal("segunda remoção da mesma chave retornou true")
+	}
+
+	keys, err := st.ListAPIKeys()
+	if err != nil || len(keys) != 0 {
+		t.Fatalf("listagem retornou %+v (erro: %v)", keys, err)
+	}
+}
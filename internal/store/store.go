@@ -0,0 +1,72 @@
+package store
+
+import (
+	"crypto/cipher"
+	"fmt"
+	"log/slog"
+	"time"
+
+	bolt "go.etcd.io/bbolt"
+)
+
+// Store persiste os dados da aplicação em um arquivo BoltDB local.
+// Os tokens da Netlify das contas são gravados cifrados com AES-GCM (ver crypto.go).
+type Store struct {
+	db   *bolt.DB
+	aead cipher.AEAD
+}
+
+// buckets lista os buckets criados na abertura do banco
+var buckets = [][]byte{
+	bucketDeploys,
+	bucketSiteDeploys,
+	bucketObjectDigests,
+	bucketAccounts,
+	bucketAPIKeys,
+	bucketAPIKeyHashes,
+	bucketSiteExpirations,
+	bucketDomainVerifications,
+}
+
+// Open abre (ou cria) o banco de dados no caminho informado.
+// encryptionKey (EncryptionKeySize bytes) cifra os tokens das contas; tokens gravados em texto puro
+// por versões anteriores são cifrados na abertura.
+func Open(path string, encryptionKey []byte) (*Store, error) {
+	slog.Info("Abrindo banco de dados local", "path", path)
+
+	aead, err := newAEAD(encryptionKey)
+	if err != nil {
+		return nil, err
+	}
+
+	db, err := bolt.Open(path, 0600, &bolt.Options{Timeout: 5 * time.Second})
+	if err != nil {
+		return nil, fmt.Errorf("erro ao abrir banco de dados: %w", err)
+	}
+
+	// Garantir que os buckets existam
+	err = db.Update(func(tx *bolt.Tx) error {
+		for _, name := range buckets {
+			if _, err := tx.CreateBucketIfNotExists(name); err != nil {
+				return fmt.Errorf("erro ao criar bucket %s: %w", name, err)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		db.Close()
+		return nil, err
+	}
+
+	s := &Store{db: db, aead: aead}
+	if err := s.migrateAccountTokens(); err != nil {
+		db.Close()
+		return nil, err
+	}
+	return s, nil
+}
+
+// Close fecha o banco de dados
+func (s *Store) Close() error {
+	return s.db.Close()
+}
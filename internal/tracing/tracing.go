@@ -0,0 +1,133 @@
+package tracing
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/kodestech/poc-netlify/internal/logging"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/exporters/stdout/stdouttrace"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// Exportadores de spans aceitos em Options.Exporter
+const (
+	ExporterNone   = ""
+	ExporterStdout = "stdout"
+	ExporterOTLP   = "otlp"
+)
+
+// instrumentationName identifica os spans criados pela aplicação
+const instrumentationName = "github.com/kodestech/poc-netlify"
+
+// Atributos dos spans que identificam os recursos envolvidos na operação
+const (
+	RequestID = attribute.Key("request.id")
+	Account   = attribute.Key("account.id")
+	JobID     = attribute.Key("job.id")
+	SiteID    = attribute.Key("netlify.site.id")
+	SiteName  = attribute.Key("netlify.site.name")
+	Domain    = attribute.Key("netlify.domain")
+	DeployID  = attribute.Key("netlify.deploy.id")
+	Files     = attribute.Key("deploy.files")
+	Bytes     = attribute.Key("deploy.bytes")
+	S3Bucket  = attribute.Key("s3.bucket")
+	S3Key     = attribute.Key("s3.key")
+
+	HTTPMethod = attribute.Key("http.request.method")
+	HTTPStatus = attribute.Key("http.response.status_code")
+	URLPath    = attribute.Key("url.template")
+)
+
+// Options define para onde os spans são exportados
+type Options struct {
+	// Exporter é o destino dos spans: stdout, otlp (configurado pelas variáveis OTEL_EXPORTER_OTLP_*)
+	// ou vazio para desativar o tracing
+	Exporter string
+	// ServiceName é o nome do serviço nos traces
+	ServiceName string
+}
+
+// Setup configura o provedor global de traces do OpenTelemetry com o exportador informado.
+// A função retornada envia os spans pendentes e deve ser chamada ao encerrar a aplicação.
+// Com o tracing desativado, os spans não são registrados e a função retornada não faz nada.
+func Setup(ctx context.Context, opts Options) (func(context.Context) error, error) {
+	var exporter sdktrace.SpanExporter
+	var err error
+	switch strings.ToLower(opts.Exporter) {
+	case ExporterNone:
+		return func(context.Context) error { return nil }, nil
+	case ExporterStdout:
+		exporter, err = stdouttrace.New()
+	case ExporterOTLP:
+		exporter, err = otlptracehttp.New(ctx)
+	default:
+		return nil, fmt.Errorf("exportador de traces inválido: %s (use stdout ou otlp)", opts.Exporter)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("erro ao criar exportador de traces: %w", err)
+	}
+
+	res, err := resource.Merge(resource.Default(), resource.NewWithAttributes(semconv.SchemaURL,
+		semconv.ServiceName(opts.ServiceName),
+	))
+	if err != nil {
+		return nil, fmt.Errorf("erro ao configurar recurso dos traces: %w", err)
+	}
+
+	provider := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithResource(res),
+	)
+	otel.SetTracerProvider(provider)
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(propagation.TraceContext{}, propagation.Baggage{}))
+
+	return provider.Shutdown, nil
+}
+
+// Start inicia um span filho do span em ctx com os atributos informados
+func Start(ctx context.Context, name string, attrs ...attribute.KeyValue) (context.Context, trace.Span) {
+	return otel.Tracer(instrumentationName).Start(ctx, name, trace.WithAttributes(attrs...))
+}
+
+// StartClient inicia um span filho do span em ctx para uma chamada a um serviço externo
+func StartClient(ctx context.Context, name string, attrs ...attribute.KeyValue) (context.Context, trace.Span) {
+	return otel.Tracer(instrumentationName).Start(ctx, name, trace.WithAttributes(attrs...), trace.WithSpanKind(trace.SpanKindClient))
+}
+
+// End encerra o span, registrando err (sem valores sensíveis) como erro da operação
+func End(span trace.Span, err error) {
+	if err != nil {
+		message := logging.RedactString(err.Error())
+		span.RecordError(errors.New(message))
+		span.SetStatus(codes.Error, message)
+	}
+	span.End()
+}
+
+// Inject retorna os cabeçalhos que propagam o trace em ctx para outra requisição (ex: traceparent)
+func Inject(ctx context.Context) http.Header {
+	headers := http.Header{}
+	otel.GetTextMapPropagator().Inject(ctx, propagation.HeaderCarrier(headers))
+	return headers
+}
+
+// Extract retorna ctx com o trace propagado nos cabeçalhos de uma requisição
+func Extract(ctx context.Context, headers http.Header) context.Context {
+	return otel.GetTextMapPropagator().Extract(ctx, propagation.HeaderCarrier(headers))
+}
+
+// SetAttributes adiciona atributos ao span em ctx (ex: o ID do deploy, quando conhecido)
+func SetAttributes(ctx context.Context, attrs ...attribute.KeyValue) {
+	trace.SpanFromContext(ctx).SetAttributes(attrs...)
+}
@@ -6,43 +6,110 @@ package main
 // @host localhost:8080
 // @BasePath /
 // @schemes http https
+// @securityDefinitions.apikey ApiKeyAuth
+// @in header
+// @name X-API-Key
 
 import (
+	"context"
 	"log"
-	"os"
+	"log/slog"
 
 	"github.com/kodestech/poc-netlify/internal/api"
 	"github.com/kodestech/poc-netlify/internal/config"
-	"io"
+	"github.com/kodestech/poc-netlify/internal/dns"
+	"github.com/kodestech/poc-netlify/internal/lifecycle"
+	"github.com/kodestech/poc-netlify/internal/logging"
+	"github.com/kodestech/poc-netlify/internal/store"
+	"github.com/kodestech/poc-netlify/internal/tracing"
 )
 
 func main() {
-	// Configurar o logger para escrever em um arquivo
-	logFile, err := os.OpenFile("netlify-deploy.log", os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0666)
+	// Carregar configurações
+	cfg, err := config.LoadConfig()
+	if err != nil {
+		log.Fatalf("Erro ao carregar configurações: %v", err)
+	}
+
+	// Configurar os logs em JSON, no terminal e no arquivo com rotação, sem tokens e chaves da configuração
+	logging.AddSecrets(cfg.Secrets()...)
+	logLevel, err := logging.ParseLevel(cfg.LogLevel)
+	if err != nil {
+		log.Fatalf("Erro ao configurar logs: %v", err)
+	}
+	logFile, err := logging.Setup(logging.Options{
+		Path:       cfg.LogPath,
+		Level:      logLevel,
+		MaxSize:    int64(cfg.LogMaxSizeMB) * 1024 * 1024,
+		MaxBackups: cfg.LogMaxBackups,
+	})
 	if err != nil {
 		log.Fatalf("Erro ao abrir arquivo de log: %v", err)
 	}
 	defer logFile.Close()
-	
-	// Configurar o logger para escrever no terminal e no arquivo
-	multiWriter := io.MultiWriter(os.Stdout, logFile)
-	log.SetOutput(multiWriter)
-	log.SetFlags(log.Ldate | log.Ltime | log.Lshortfile)
-	
-	log.Println("Iniciando aplicação Netlify Deploy")
-	
-	// Carregar configurações
-	cfg, err := config.LoadConfig()
+
+	slog.Info("Iniciando aplicação Netlify Deploy", "log_file", cfg.LogPath, "log_level", logLevel.String())
+
+	// Configurar o tracing (OpenTelemetry), exportando os spans para stdout ou via OTLP
+	shutdownTracing, err := tracing.Setup(context.Background(), tracing.Options{
+		Exporter:    cfg.TracingExporter,
+		ServiceName: cfg.TracingServiceName,
+	})
 	if err != nil {
-		log.Fatalf("Erro ao carregar configurações: %v", err)
+		log.Fatalf("Erro ao configurar tracing: %v", err)
 	}
+	defer shutdownTracing(context.Background())
 	
+	// Abrir o banco de dados local com o histórico de deploys e as contas
+	if len(cfg.DataEncryptionKey) == 0 {
+		log.Fatalf("DATA_ENCRYPTION_KEY não definida: gere uma chave com openssl rand -base64 32")
+	}
+	st, err := store.Open(cfg.DataPath, cfg.DataEncryptionKey)
+	if err != nil {
+		log.Fatalf("Erro ao abrir banco de dados: %v", err)
+	}
+	defer st.Close()
+
+	// Criar a conta padrão a partir do NETLIFY_TOKEN, se definido
+	if err := seedDefaultAccount(cfg, st); err != nil {
+		log.Fatalf("Erro ao criar conta padrão: %v", err)
+	}
+
+	// Criar o provedor DNS usado para os registros dos domínios personalizados
+	dnsProvider, err := dns.NewProvider(cfg)
+	if err != nil {
+		log.Fatalf("Erro ao criar provedor DNS: %v", err)
+	}
+
+	// Excluir periodicamente os sites temporários expirados (cleanup_after)
+	go lifecycle.NewCleaner(cfg, st).Run(context.Background())
+
 	// Criar servidor API
-	s := api.NewServer(cfg)
+	s := api.NewServer(cfg, st, dnsProvider)
 
 	// Iniciar servidor
-	log.Printf("Iniciando servidor...")
+	slog.Info("Iniciando servidor", "port", cfg.APIPort)
 	if err := s.Start(); err != nil {
 		log.Fatalf("Erro ao iniciar servidor: %v", err)
 	}
 }
+
+// seedDefaultAccount cria a conta padrão com o NETLIFY_TOKEN do ambiente, caso ainda não exista
+func seedDefaultAccount(cfg *config.Config, st *store.Store) error {
+	if cfg.NetlifyToken == "" {
+		return nil
+	}
+
+	_, exists, err := st.GetAccount(cfg.DefaultAccount)
+	if err != nil || exists {
+		return err
+	}
+
+	slog.Info("Criando conta padrão a partir do NETLIFY_TOKEN", "account_id", cfg.DefaultAccount)
+	return st.SaveAccount(&store.Account{
+		ID:           cfg.DefaultAccount,
+		Name:         "Conta padrão",
+		NetlifyToken: cfg.NetlifyToken,
+		BaseDomain:   cfg.BaseDomain,
+	})
+}
@@ -0,0 +1,46 @@
+// Tradução da interface web com o catálogo de mensagens da API (/api/i18n/messages)
+const I18n = (function() {
+    const storageKey = 'lang';
+    let lang = localStorage.getItem(storageKey) || navigator.language || 'pt-BR';
+    let messages = {};
+
+    // Carrega as mensagens do idioma e traduz a página
+    async function load(value) {
+        const response = await fetch(`/api/i18n/messages?lang=${encodeURIComponent(value || lang)}`);
+        const data = await response.json();
+        lang = data.language;
+        messages = data.messages || {};
+        localStorage.setItem(storageKey, lang);
+        apply(document);
+        return data;
+    }
+
+    // Retorna a mensagem da chave, substituindo %s e %d pelos argumentos, em ordem
+    function t(key, ...args) {
+        let i = 0;
+        return (messages[key] || key).replace(/%[sd]/g, () => String(args[i++] ?? ''));
+    }
+
+    // Traduz os elementos marcados com data-i18n, data-i18n-html e data-i18n-placeholder
+    function apply(root) {
+        document.documentElement.lang = lang;
+        root.querySelectorAll('[data-i18n]').forEach(el => {
+            el.textContent = t(el.dataset.i18n);
+        });
+        root.querySelectorAll('[data-i18n-html]').forEach(el => {
+            el.innerHTML = t(el.dataset.i18nHtml);
+        });
+        root.querySelectorAll('[data-i18n-placeholder]').forEach(el => {
+            el.placeholder = t(el.dataset.i18nPlaceholder);
+        });
+    }
+
+    return {
+        load,
+        t,
+        apply,
+        get lang() {
+            return lang;
+        }
+    };
+})();
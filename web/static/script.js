@@ -1,4 +1,4 @@
-document.addEventListener('DOMContentLoaded', function() {
+document.addEventListener('DOMContentLoaded', async function() {
     const deployForm = document.getElementById('deployForm');
     const usernameInput = document.getElementById('username');
     const previewSubdomain = document.getElementById('previewSubdomain');
@@ -6,6 +6,31 @@ document.addEventListener('DOMContentLoaded', function() {
     const deploySpinner = document.getElementById('deploySpinner');
     const resultCard = document.getElementById('resultCard');
     const deployResult = document.getElementById('deployResult');
+    const progressCard = document.getElementById('progressCard');
+    const progressPhase = document.getElementById('progressPhase');
+    const progressCount = document.getElementById('progressCount');
+    const progressBar = document.getElementById('progressBar');
+    const progressLog = document.getElementById('progressLog');
+    const languageSelect = document.getElementById('language');
+    const folderInput = document.getElementById('folder');
+    const folderSummary = document.getElementById('folderSummary');
+
+    // Carregar as mensagens no idioma salvo e trocar de idioma pelo seletor
+    try {
+        await I18n.load();
+    } catch (error) {
+        console.error('Erro ao carregar as mensagens da interface:', error);
+    }
+    languageSelect.value = I18n.lang;
+    languageSelect.addEventListener('change', function() {
+        I18n.load(this.value);
+    });
+
+    function phaseLabel(phase) {
+        const key = `ui.phase.${phase}`;
+        const label = I18n.t(key);
+        return label === key ? phase : label;
+    }
 
     // Atualizar preview do subdomu00ednio quando o usuu00e1rio digitar
     usernameInput.addEventListener('input', function() {
@@ -13,6 +38,11 @@ document.addEventListener('DOMContentLoaded', function() {
         previewSubdomain.textContent = `${username}.sites.kodestech.com.br`;
     });
 
+    // Mostrar quantos arquivos foram encontrados na pasta escolhida
+    folderInput.addEventListener('change', function() {
+        folderSummary.textContent = this.files.length ? I18n.t('ui.form.folder_selected', this.files.length) : '';
+    });
+
     // Submeter formulu00e1rio
     deployForm.addEventListener('submit', function(e) {
         e.preventDefault();
@@ -24,45 +54,66 @@ document.addEventListener('DOMContentLoaded', function() {
         
         // Validar campos obrigatu00f3rios
         if (!username) {
-            alert('Por favor, informe o nome de usuu00e1rio.');
+            alert(I18n.t('ui.form.username_required'));
             usernameInput.focus();
             return;
         }
         
-        if (!s3Path) {
-            alert('Por favor, informe o caminho no S3.');
+        // A origem é a pasta escolhida ou, sem pasta, o caminho no S3
+        const folderFiles = Array.from(folderInput.files);
+        if (!s3Path && folderFiles.length === 0) {
+            alert(I18n.t('ui.form.source_required'));
             document.getElementById('s3Path').focus();
             return;
         }
         
+        const account = document.getElementById('account').value.trim();
+        const apiKey = document.getElementById('apiKey').value.trim();
+        if (!account || !apiKey) {
+            alert(I18n.t('ui.form.credentials_required'));
+            return;
+        }
+
         // Preparar dados para envio
-        const deployData = {
-            username: username,
-            custom_domain: customDomain,
-            s3_path: s3Path
-        };
+        const deployData = new FormData();
+        deployData.append('site_name', username);
+        deployData.append('custom_domain', customDomain);
+        if (folderFiles.length > 0) {
+            // O nome de cada parte leva o caminho relativo do arquivo (ex: site/css/pop-up.css)
+            folderFiles.forEach(file => deployData.append('files[]', file, file.webkitRelativePath || file.name));
+        } else {
+            deployData.append('s3_path', s3Path);
+        }
         
         // Mostrar spinner e desabilitar botu00e3o
         deployButton.disabled = true;
         deploySpinner.classList.remove('d-none');
         resultCard.classList.add('d-none');
+        resetProgress();
         
-        // Enviar requisiu00e7u00e3o
-        fetch('/api/deploy', {
+        // Enfileirar o deploy e acompanhar o andamento pelos eventos (SSE)
+        const baseURL = `/api/accounts/${encodeURIComponent(account)}`;
+        const endpoint = folderFiles.length > 0 ? 'deploy/site' : 'deploy/s3';
+        fetch(`${baseURL}/${endpoint}`, {
             method: 'POST',
             headers: {
-                'Content-Type': 'application/json'
+                'X-API-Key': apiKey,
+                'Accept-Language': I18n.lang
             },
-            body: JSON.stringify(deployData)
+            body: deployData
         })
         .then(response => response.json())
         .then(data => {
-            // Exibir resultado
-            showResult(data);
+            if (!data.success) {
+                showResult(data);
+                return;
+            }
+            progressCard.classList.remove('d-none');
+            return streamDeployEvents(`${baseURL}/deploys/${data.job_id}/events`, apiKey);
         })
         .catch(error => {
             // Exibir erro
-            showError('Erro ao processar a requisiu00e7u00e3o: ' + error.message);
+            showError(I18n.t('ui.result.request_failed', error.message));
         })
         .finally(() => {
             // Esconder spinner e habilitar botu00e3o
@@ -71,6 +122,99 @@ document.addEventListener('DOMContentLoaded', function() {
         });
     });
 
+    // Acompanha os eventos do deploy. EventSource não envia cabeçalhos, então o stream é lido com fetch.
+    async function streamDeployEvents(url, apiKey) {
+        const response = await fetch(url, { headers: { 'X-API-Key': apiKey, 'Accept-Language': I18n.lang } });
+        if (!response.ok) {
+            throw new Error(`status ${response.status}`);
+        }
+
+        const reader = response.body.getReader();
+        const decoder = new TextDecoder();
+        let buffer = '';
+        while (true) {
+            const { value, done } = await reader.read();
+            if (done) {
+                break;
+            }
+            buffer += decoder.decode(value, { stream: true });
+
+            let end;
+            while ((end = buffer.indexOf('\n\n')) >= 0) {
+                const block = buffer.slice(0, end);
+                buffer = buffer.slice(end + 2);
+
+                const data = block.split('\n')
+                    .filter(line => line.startsWith('data:'))
+                    .map(line => line.slice(5))
+                    .join('\n');
+                if (data) {
+                    handleDeployEvent(JSON.parse(data));
+                }
+            }
+        }
+    }
+
+    // Atualiza o andamento com um evento do deploy
+    function handleDeployEvent(event) {
+        switch (event.type) {
+        case 'phase':
+            progressPhase.textContent = phaseLabel(event.phase);
+            addProgressLog(I18n.t('ui.progress.phase', progressPhase.textContent));
+            break;
+        case 'file_downloaded':
+            addProgressLog(I18n.t('ui.progress.downloaded', event.path));
+            break;
+        case 'file_hashed':
+            progressCount.textContent = event.total ? I18n.t('ui.progress.hashed', event.done, event.total) : I18n.t('ui.progress.hashed_count', event.done);
+            break;
+        case 'upload_total':
+            progressCount.textContent = I18n.t('ui.progress.uploaded_count', 0, event.total);
+            addProgressLog(I18n.t('ui.progress.upload_total', event.total));
+            break;
+        case 'file_uploaded':
+            progressCount.textContent = I18n.t('ui.progress.uploaded_count', event.done, event.total);
+            if (event.bytes_total) {
+                progressBar.style.width = `${Math.round(event.bytes_done * 100 / event.bytes_total)}%`;
+            }
+            addProgressLog(I18n.t('ui.progress.uploaded', event.path));
+            break;
+        case 'deploy_state':
+            addProgressLog(I18n.t('ui.progress.deploy_state', event.from || '-', event.to));
+            break;
+        case 'message':
+            addProgressLog(event.message);
+            break;
+        case 'done':
+            progressBar.classList.remove('progress-bar-animated');
+            progressBar.style.width = '100%';
+            progressPhase.textContent = phaseLabel(event.phase);
+            showResult({
+                success: event.phase === 'ready',
+                message: event.error || event.message || I18n.t('ui.result.done'),
+                site_url: event.deploy_url,
+                deploy_id: event.deploy_id
+            });
+            break;
+        }
+    }
+
+    function addProgressLog(text) {
+        const item = document.createElement('li');
+        item.textContent = text;
+        progressLog.appendChild(item);
+        progressLog.scrollTop = progressLog.scrollHeight;
+    }
+
+    function resetProgress() {
+        progressCard.classList.add('d-none');
+        progressPhase.textContent = phaseLabel('queued');
+        progressCount.textContent = '';
+        progressBar.style.width = '0%';
+        progressBar.classList.add('progress-bar-animated');
+        progressLog.innerHTML = '';
+    }
+
     // Funu00e7u00e3o para exibir resultado
     function showResult(data) {
         // Limpar resultado anterior
@@ -81,23 +225,23 @@ document.addEventListener('DOMContentLoaded', function() {
         if (data.success) {
             cardHeader.classList.remove('bg-danger');
             cardHeader.classList.add('bg-success');
-            cardHeader.querySelector('h3').textContent = 'Deploy Iniciado com Sucesso';
+            cardHeader.querySelector('h3').textContent = I18n.t('ui.result.success_title');
         } else {
             cardHeader.classList.remove('bg-success');
             cardHeader.classList.add('bg-danger');
-            cardHeader.querySelector('h3').textContent = 'Erro no Deploy';
+            cardHeader.querySelector('h3').textContent = I18n.t('ui.result.error_title');
         }
         
         // Construir HTML do resultado
         let resultHTML = `
             <div class="result-item">
-                <span class="result-label">Status:</span> 
+                <span class="result-label">${I18n.t('ui.result.status')}</span> 
                 <span class="${data.success ? 'result-success' : 'result-error'}">
-                    ${data.success ? 'Sucesso' : 'Erro'}
+                    ${data.success ? I18n.t('ui.result.success') : I18n.t('ui.result.error')}
                 </span>
             </div>
             <div class="result-item">
-                <span class="result-label">Mensagem:</span> 
+                <span class="result-label">${I18n.t('ui.result.message')}</span> 
                 <span class="result-value">${data.message}</span>
             </div>
         `;
@@ -107,7 +251,7 @@ document.addEventListener('DOMContentLoaded', function() {
             if (data.subdomain) {
                 resultHTML += `
                     <div class="result-item">
-                        <span class="result-label">Subdomu00ednio:</span> 
+                        <span class="result-label">${I18n.t('ui.result.subdomain')}</span> 
                         <span class="result-value">
                             <a href="https://${data.subdomain}" target="_blank">${data.subdomain}</a>
                         </span>
@@ -118,7 +262,7 @@ document.addEventListener('DOMContentLoaded', function() {
             if (data.site_url) {
                 resultHTML += `
                     <div class="result-item">
-                        <span class="result-label">URL do Site:</span> 
+                        <span class="result-label">${I18n.t('ui.result.site_url')}</span> 
                         <span class="result-value">
                             <a href="${data.site_url}" target="_blank">${data.site_url}</a>
                         </span>
@@ -129,7 +273,7 @@ document.addEventListener('DOMContentLoaded', function() {
             if (data.custom_domain) {
                 resultHTML += `
                     <div class="result-item">
-                        <span class="result-label">Domu00ednio Personalizado:</span> 
+                        <span class="result-label">${I18n.t('ui.result.custom_domain')}</span> 
                         <span class="result-value">
                             <a href="https://${data.custom_domain}" target="_blank">${data.custom_domain}</a>
                         </span>
@@ -140,18 +284,11 @@ document.addEventListener('DOMContentLoaded', function() {
             if (data.deploy_id) {
                 resultHTML += `
                     <div class="result-item">
-                        <span class="result-label">ID do Deploy:</span> 
+                        <span class="result-label">${I18n.t('ui.result.deploy_id')}</span> 
                         <span class="result-value">${data.deploy_id}</span>
                     </div>
                 `;
             }
-            
-            resultHTML += `
-                <div class="alert alert-info mt-3">
-                    <strong>Nota:</strong> O deploy foi iniciado em segundo plano e pode levar alguns minutos para ser concluu00eddo.
-                    Vocu00ea pode verificar o status do deploy no painel da Netlify.
-                </div>
-            `;
         }
         
         // Exibir resultado
@@ -167,15 +304,15 @@ document.addEventListener('DOMContentLoaded', function() {
         const cardHeader = resultCard.querySelector('.card-header');
         cardHeader.classList.remove('bg-success');
         cardHeader.classList.add('bg-danger');
-        cardHeader.querySelector('h3').textContent = 'Erro';
+        cardHeader.querySelector('h3').textContent = I18n.t('ui.result.error');
         
         deployResult.innerHTML = `
             <div class="result-item">
-                <span class="result-label">Status:</span> 
-                <span class="result-error">Erro</span>
+                <span class="result-label">${I18n.t('ui.result.status')}</span> 
+                <span class="result-error">${I18n.t('ui.result.error')}</span>
             </div>
             <div class="result-item">
-                <span class="result-label">Mensagem:</span> 
+                <span class="result-label">${I18n.t('ui.result.message')}</span> 
                 <span class="result-value">${message}</span>
             </div>
         `;